@@ -0,0 +1,56 @@
+package swisstools
+
+import (
+	"fmt"
+	"time"
+)
+
+// AuditEntry records one change of the tournament.
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	Operator string    `json:"operator,omitempty"` // Who made the change, see SetOperator.
+	Action   string    `json:"action"`
+	Round    int       `json:"round,omitempty"`
+	PlayerId int       `json:"player_id,omitempty"`
+	Before   string    `json:"before,omitempty"`
+	After    string    `json:"after,omitempty"`
+	Reason   string    `json:"reason,omitempty"` // Why a result was overridden, see OverrideResult.
+}
+
+// SetOperator sets the name recorded in the audit log for the changes that follow, e.g. the
+// scorekeeper or judge at the keyboard.
+func (t *Tournament) SetOperator(name string) {
+	t.operator = name
+}
+
+// AuditLog returns every recorded change in the order they were made. The log is kept in dumps and
+// survives Undo, which is logged itself.
+func (t *Tournament) AuditLog() []AuditEntry {
+	return append([]AuditEntry{}, t.audit...)
+}
+
+func (t *Tournament) logChange(action string, round int, id int, before string, after string) {
+	t.logReason(action, round, id, before, after, "")
+}
+
+// logReason is logChange for changes that need a reason, see OverrideResult. Every logged change
+// is autosaved.
+func (t *Tournament) logReason(action string, round int, id int, before string, after string, reason string) {
+	t.audit = append(t.audit, AuditEntry{Time: time.Now(), Operator: t.operator, Action: action, Round: round, PlayerId: id, Before: before, After: after, Reason: reason})
+	t.autosave(action)
+}
+
+// describePairing formats a pairing and its result for the audit log.
+func describePairing(p Pairing) string {
+	if p.IsBye() {
+		return fmt.Sprintf("%d bye", p.playera)
+	}
+	if !p.IsComplete() {
+		return fmt.Sprintf("%d vs %d unreported", p.playera, p.playerb)
+	}
+	result := fmt.Sprintf("%d vs %d %d-%d-%d", p.playera, p.playerb, p.playeraWins, p.playerbWins, p.draws)
+	if p.IsForfeit() {
+		result += " forfeit"
+	}
+	return result
+}
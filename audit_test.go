@@ -0,0 +1,84 @@
+package swisstools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuditLog(t *testing.T) {
+	tournament := NewTournament()
+	tournament.SetOperator("Scorekeeper")
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.SetOperator("Judge")
+	tournament.AddResult(1, 2, 1, 0)
+	tournament.Undo()
+	tournament.RenamePlayer(2, "Samuel")
+
+	log := tournament.AuditLog()
+	actions := []string{"add_player", "add_player", "pair", "result", "undo", "rename_player"}
+	if len(log) != len(actions) {
+		t.Fatalf("Expecting %d entries, got %+v.", len(actions), log)
+	}
+	for i, action := range actions {
+		if log[i].Action != action {
+			t.Fatalf("Expecting action %s at %d, got %+v.", action, i, log[i])
+		}
+	}
+	result := log[3]
+	if result.Operator != "Judge" || result.PlayerId != 1 || result.Round != 1 || result.Time.IsZero() {
+		t.Fatalf("Expecting the judge's result for player 1, got %+v.", result)
+	}
+	if result.Before == result.After || log[0].Operator != "Scorekeeper" {
+		t.Fatalf("Expecting before and after values, got %+v.", log)
+	}
+	if log[5].Before != "Sam" || log[5].After != "Samuel" {
+		t.Fatalf("Expecting the rename from Sam to Samuel, got %+v.", log[5])
+	}
+
+	dump, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(dump)
+	if len(loaded.AuditLog()) != len(actions) {
+		t.Fatalf("Expecting the audit log in the dump, got %+v.", loaded.AuditLog())
+	}
+}
+
+func TestAuditLogDetails(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Finals: FinalsConfig{Size: 2, Format: FinalsSingleElimination}})
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.SetRating(1, Rating{Value: 1600})
+	tournament.SetExternalId(1, "dci-1")
+	tournament.SetDecklist(1, Decklist{Main: []Card{{Count: 60, Name: "Island"}}})
+	tournament.AddPlayerNote(1, "Late")
+	tournament.RemovePlayerNote(1, 0)
+	tournament.SetPlayerMeta(1, "discord", "dylan")
+	tournament.Pair()
+	tournament.AddTimeExtension(1, time.Minute, "deck check")
+	tournament.SetUnfinishedGames(1, 1)
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.AddResult(2, 2, 0, 0)
+	tournament.NextRound()
+	tournament.StartFinals()
+	final, _ := tournament.GetFinalsRound()
+	tournament.AddFinalsResult(final[0].playera, 2, 0, 0)
+	tournament.NextFinalsRound()
+	tournament.AddPhantom("Phantom")
+
+	actions := map[string]AuditEntry{}
+	for _, entry := range tournament.AuditLog() {
+		actions[entry.Action] = entry
+	}
+	for _, action := range []string{"add_phantom", "set_rating", "set_external_id", "set_decklist", "add_note", "remove_note", "set_meta", "time_extension", "unfinished_games", "start_finals", "finals_result", "next_finals_round"} {
+		if _, ok := actions[action]; !ok {
+			t.Fatalf("Expecting %s in the audit log, got %+v.", action, tournament.AuditLog())
+		}
+	}
+	if entry := actions["time_extension"]; entry.Reason != "deck check" || entry.After != "1m0s" {
+		t.Fatalf("Expecting the extension and its reason, got %+v.", entry)
+	}
+	if entry := actions["set_rating"]; entry.Before != "0" || entry.After != "1600" {
+		t.Fatalf("Expecting the rating change, got %+v.", entry)
+	}
+}
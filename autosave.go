@@ -0,0 +1,107 @@
+package swisstools
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Storage persists tournament dumps, as returned by DumpTournament, under an id.
+type Storage interface {
+	Save(id string, snapshot []byte) error
+	Load(id string) ([]byte, error)
+}
+
+// AutosavePolicy selects when EnableAutosave saves the tournament.
+type AutosavePolicy string
+
+const (
+	AutosaveChange AutosavePolicy = ""      // After every change, the default.
+	AutosaveRound  AutosavePolicy = "round" // When the round changes and when the tournament finishes.
+)
+
+type autosaver struct {
+	storage Storage
+	id      string
+	policy  AutosavePolicy
+	round   int // Current round at the last save.
+	err     error
+}
+
+// EnableAutosave saves the tournament to storage under id after changes, according to policy. It
+// saves once right away. Errors do not stop the change being made, see AutosaveError.
+func (t *Tournament) EnableAutosave(storage Storage, id string, policy AutosavePolicy) error {
+	if id == "" {
+		return ErrInvalidId
+	}
+	t.autosaver = &autosaver{storage: storage, id: id, policy: policy}
+	t.save()
+	return t.autosaver.err
+}
+
+// DisableAutosave stops saving the tournament after changes.
+func (t *Tournament) DisableAutosave() {
+	t.autosaver = nil
+}
+
+// AutosaveError returns the error of the last failed save, or nil if the last save succeeded.
+func (t *Tournament) AutosaveError() error {
+	if t.autosaver == nil {
+		return nil
+	}
+	return t.autosaver.err
+}
+
+// autosave saves the tournament after the logged change action if the policy asks for it.
+func (t *Tournament) autosave(action string) {
+	if t.autosaver == nil {
+		return
+	}
+	if t.autosaver.policy == AutosaveRound && t.currentRound == t.autosaver.round && action != "finish_tournament" {
+		return
+	}
+	t.save()
+}
+
+func (t *Tournament) save() {
+	data, err := t.DumpTournament()
+	if err == nil {
+		err = t.autosaver.storage.Save(t.autosaver.id, data)
+	}
+	t.autosaver.err = err
+	t.autosaver.round = t.currentRound
+}
+
+// LoadFromStorage loads the tournament saved under id.
+func LoadFromStorage(storage Storage, id string) (Tournament, error) {
+	data, err := storage.Load(id)
+	if err != nil {
+		return Tournament{}, err
+	}
+	return LoadTournament(data)
+}
+
+// FileStorage stores every tournament in Dir as <id>.json, like Store.Persist.
+type FileStorage struct {
+	Dir string
+}
+
+// Save writes the snapshot to a temporary file first so that a crash never leaves a partial file. Ids
+// that are not plain file names are refused with ErrInvalidId.
+func (s FileStorage) Save(id string, snapshot []byte) error {
+	if err := checkId(id); err != nil {
+		return err
+	}
+	return writeFile(s.Dir, id+".json", snapshot)
+}
+
+func (s FileStorage) Load(id string) ([]byte, error) {
+	if err := checkId(id); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(s.Dir, id+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTournamentNotFound
+	}
+	return data, err
+}
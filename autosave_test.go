@@ -0,0 +1,104 @@
+package swisstools
+
+import (
+	"errors"
+	"testing"
+)
+
+type memoryStorage struct {
+	saves     int
+	snapshots map[string][]byte
+}
+
+func (s *memoryStorage) Save(id string, snapshot []byte) error {
+	if s.snapshots == nil {
+		s.snapshots = map[string][]byte{}
+	}
+	s.saves++
+	s.snapshots[id] = snapshot
+	return nil
+}
+
+func (s *memoryStorage) Load(id string) ([]byte, error) {
+	snapshot, ok := s.snapshots[id]
+	if !ok {
+		return nil, ErrTournamentNotFound
+	}
+	return snapshot, nil
+}
+
+func TestAutosave(t *testing.T) {
+	storage := &memoryStorage{}
+	tournament := NewTournament()
+	if err := tournament.EnableAutosave(storage, "weekly", AutosaveChange); err != nil {
+		t.Fatalf("EnableAutosave returned an error: %s", err)
+	}
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	if storage.saves != 5 {
+		t.Fatalf("Expecting 5 saves, got %d.", storage.saves)
+	}
+	loaded, err := LoadFromStorage(storage, "weekly")
+	if err != nil || !loaded.IsRoundComplete() {
+		t.Fatalf("Expecting the saved tournament to have the result, got %v.", err)
+	}
+
+	tournament.EnableAutosave(storage, "weekly", AutosaveRound)
+	saves := storage.saves
+	tournament.NextRound()
+	tournament.Pair()
+	if storage.saves != saves+1 {
+		t.Fatalf("Expecting one save for the new round, got %d.", storage.saves-saves)
+	}
+
+	dir := t.TempDir()
+	tournament.EnableAutosave(FileStorage{Dir: dir}, "weekly", AutosaveChange)
+	tournament.AddResult(1, 2, 0, 0)
+	if err := tournament.AutosaveError(); err != nil {
+		t.Fatalf("AutosaveError returned an error: %s", err)
+	}
+	loaded, err = LoadFromStorage(FileStorage{Dir: dir}, "weekly")
+	if err != nil || loaded.CurrentRoundNumber() != 2 || !loaded.IsRoundComplete() {
+		t.Fatalf("Expecting the file to have round 2 complete, got %v.", err)
+	}
+	if _, err := LoadFromStorage(FileStorage{Dir: dir}, "casual"); !errors.Is(err, ErrTournamentNotFound) {
+		t.Fatalf("Expecting ErrTournamentNotFound, got %v.", err)
+	}
+}
+
+func TestAutosaveAfterChange(t *testing.T) {
+	storage := &memoryStorage{}
+	tournament := NewTournament()
+	tournament.EnableAutosave(storage, "weekly", AutosaveChange)
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayerNote(1, "Late")
+	saved := func() Player {
+		loaded, err := LoadFromStorage(storage, "weekly")
+		if err != nil {
+			t.Fatalf("LoadFromStorage returned an error: %s", err)
+		}
+		return loaded.players[1]
+	}
+	tournament.RenamePlayer(1, "Dylan S")
+	if player := saved(); player.name != "Dylan S" {
+		t.Fatalf("Expecting the saved name to be Dylan S, got %s.", player.name)
+	}
+	tournament.SetExternalId(1, "dci-1")
+	if player := saved(); player.externalId != "dci-1" {
+		t.Fatalf("Expecting the saved external id to be dci-1, got %s.", player.externalId)
+	}
+	tournament.RemovePlayerNote(1, 0)
+	if player := saved(); len(player.notes) != 0 {
+		t.Fatalf("Expecting the saved notes to be empty, got %v.", player.notes)
+	}
+	tournament.SetSeed(1, 7)
+	if player := saved(); player.seed != 7 {
+		t.Fatalf("Expecting the saved seed to be 7, got %d.", player.seed)
+	}
+	tournament.SetRating(1, Rating{Value: 1600})
+	if player := saved(); player.rating.Value != 1600 {
+		t.Fatalf("Expecting the saved rating to be 1600, got %v.", player.rating)
+	}
+}
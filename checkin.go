@@ -0,0 +1,64 @@
+package swisstools
+
+// CheckInPlayer records that a registered player is present for the start of the tournament.
+func (t *Tournament) CheckInPlayer(id int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if player.checkedIn {
+		return ErrAlreadyCheckedIn
+	}
+	t.recordChange(t.snapshot())
+	player.checkedIn = true
+	t.players[id] = player
+	t.logChange("check_in", 0, id, "", "checked in")
+	return nil
+}
+
+// CheckInCounts returns the number of registered players and how many of them checked in. Phantoms
+// are left out.
+func (t *Tournament) CheckInCounts() (int, int) {
+	registered, checkedIn := 0, 0
+	for _, player := range t.players {
+		if player.phantom {
+			continue
+		}
+		registered++
+		if player.checkedIn {
+			checkedIn++
+		}
+	}
+	return registered, checkedIn
+}
+
+// StartTournament closes check-in before the first round is paired. With
+// TournamentConfig.RequireCheckIn every player who did not check in is dropped.
+func (t *Tournament) StartTournament() error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if t.currentRound != 1 || len(t.rounds[1]) > 0 {
+		return ErrRoundAlreadyPaired
+	}
+	t.recordChange(t.snapshot())
+	t.logChange("start_tournament", 0, 0, "", "")
+	if !t.config.RequireCheckIn {
+		return nil
+	}
+	for _, id := range t.playerIdsByName() {
+		player := t.players[id]
+		if player.checkedIn || player.phantom || player.dropped {
+			continue
+		}
+		player.dropped = true
+		t.players[id] = player
+		t.logChange("drop_player", t.currentRound, id, "", "not checked in")
+		t.emit(Event{Type: PlayerDropped, Round: t.currentRound, PlayerId: id})
+	}
+	t.invalidateStandings()
+	return nil
+}
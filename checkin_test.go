@@ -0,0 +1,37 @@
+package swisstools
+
+import "testing"
+
+func TestCheckIn(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{RequireCheckIn: true})
+	for _, name := range []string{"Dylan", "Sam", "Alex"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.AddPhantom("Phantom")
+	tournament.CheckInPlayer(1)
+	tournament.CheckInPlayer(3)
+	if err := tournament.CheckInPlayer(3); err == nil {
+		t.Fatalf("Expecting an error checking in twice.")
+	}
+	if registered, checkedIn := tournament.CheckInCounts(); registered != 3 || checkedIn != 2 {
+		t.Fatalf("Expecting 3 registered and 2 checked in, got %d and %d.", registered, checkedIn)
+	}
+	data, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(data)
+	if view, _ := loaded.GetPlayerView(3); !view.CheckedIn {
+		t.Fatalf("Expecting the check-in to be kept in a dump.")
+	}
+	if err := loaded.StartTournament(); err != nil {
+		t.Fatalf("StartTournament returned an error: %s", err)
+	}
+	if view, _ := loaded.GetPlayerView(2); !view.Dropped {
+		t.Fatalf("Expecting Sam to be dropped for not checking in.")
+	}
+	if view, _ := loaded.GetPlayerView(1); view.Dropped {
+		t.Fatalf("Expecting Dylan to stay in.")
+	}
+	loaded.Pair()
+	if err := loaded.StartTournament(); err == nil {
+		t.Fatalf("Expecting an error starting a tournament after pairing.")
+	}
+}
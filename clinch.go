@@ -0,0 +1,325 @@
+package swisstools
+
+// ClinchedCut returns the ids of the players who finish in the top cutSize no matter the results of
+// the remaining rounds, assuming ties on points are broken against them. It requires
+// TournamentConfig.Rounds to be set.
+//
+// A player is clinched if, after losing every remaining match, there is no way for cutSize other
+// players to reach their points. The matches of a paired current round are known, so their players
+// cannot all win, while later rounds may be paired any way. See catchable for when this is exact.
+func (t *Tournament) ClinchedCut(cutSize int) ([]int, error) {
+	if t.config.Rounds == 0 {
+		return nil, ErrRoundsNotConfigured
+	}
+	current, later := t.clinchRounds()
+	clinched := []int{}
+	for _, standing := range t.GetStandings() {
+		target := standing.Points
+		decided := map[int]int{}
+		if current {
+			decided = t.settle(standing.Id, t.config.PointsLoss)
+			target += decided[standing.Id]
+		}
+		if !standing.Dropped {
+			target += later * t.config.PointsLoss
+		}
+		if !t.catchable(standing.Id, target, cutSize, decided, 0) {
+			clinched = append(clinched, standing.Id)
+		}
+	}
+	return clinched, nil
+}
+
+// catchUpCost returns the cheapest cost in half matches for a player to gain at least behind points in
+// the given number of rounds, and whether it is possible at all.
+func (t *Tournament) catchUpCost(behind int, rounds int) (int, bool) {
+	if behind <= 0 {
+		return 0, true
+	}
+	best := -1
+	for wins := 0; wins <= rounds; wins++ {
+		draws := 0
+		// Losses score too, so only the points over a loss help to catch up.
+		win, draw := t.config.PointsWin-t.config.PointsLoss, t.config.PointsDraw-t.config.PointsLoss
+		if short := behind - wins*win; short > 0 {
+			if draw <= 0 {
+				continue
+			}
+			draws = (short + draw - 1) / draw
+		}
+		if wins+draws > rounds {
+			continue
+		}
+		if cost := 2*wins + draws; best < 0 || cost < best {
+			best = cost
+		}
+	}
+	return best, best >= 0
+}
+
+// CutStatus tells whether a player is sure to make the cut, see CanClinchTopCut and DrawScenario.
+type CutStatus string
+
+const (
+	CutLocked CutStatus = "locked" // Makes the cut even losing every tie on points.
+	// CutOnTiebreakers means the player can only miss the cut on a tie on points, so it comes down to
+	// tiebreakers, which still change with the remaining results.
+	CutOnTiebreakers CutStatus = "tiebreakers"
+	CutAtRisk        CutStatus = "at_risk" // Enough players can pass them to keep them out.
+)
+
+// DrawScenario is the outlook of two paired players agreeing to an intentional draw, assuming they
+// lose any rounds after the current one.
+type DrawScenario struct {
+	PlayerA int
+	PlayerB int
+	PointsA int // Points after the draw.
+	PointsB int
+	StatusA CutStatus
+	StatusB CutStatus
+}
+
+// Safe reports whether the draw locks both players into the cut.
+func (s DrawScenario) Safe() bool {
+	return s.StatusA == CutLocked && s.StatusB == CutLocked
+}
+
+// remainingRounds returns the Swiss rounds left, the current round included.
+func (t *Tournament) remainingRounds() int {
+	if remaining := t.config.Rounds - (t.currentRound - 1); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// CanClinchTopCut reports whether the player is sure to finish in the top cutSize if they win every
+// remaining match, losing every tie on points. A result they already reported in the current round
+// stands. It requires TournamentConfig.Rounds to be set.
+func (t *Tournament) CanClinchTopCut(id int, cutSize int) (bool, error) {
+	if t.config.Rounds == 0 {
+		return false, ErrRoundsNotConfigured
+	}
+	if _, ok := t.players[id]; !ok {
+		return false, ErrPlayerNotFound
+	}
+	current, later := t.clinchRounds()
+	target := 0
+	for _, standing := range t.GetStandings() {
+		if standing.Id == id {
+			target = standing.Points
+			if standing.Dropped {
+				later = 0
+			}
+		}
+	}
+	decided := map[int]int{}
+	if current {
+		decided = t.settle(id, t.config.PointsWin)
+		target += decided[id]
+	}
+	// The player's own wins take a whole match from the later rounds.
+	return !t.catchable(id, target+later*t.config.PointsWin, cutSize, decided, 2*later), nil
+}
+
+// DrawScenario tells whether an intentional draw between two players paired in the current round
+// gets both of them into the top cutSize, whatever the other results. The match must not be
+// reported yet. It requires TournamentConfig.Rounds to be set.
+func (t *Tournament) DrawScenario(a int, b int, cutSize int) (DrawScenario, error) {
+	if t.config.Rounds == 0 {
+		return DrawScenario{}, ErrRoundsNotConfigured
+	}
+	if t.currentRound > t.config.Rounds {
+		return DrawScenario{}, ErrRoundOutOfRange
+	}
+	pairing, err := t.findPairing(a)
+	if err != nil {
+		return DrawScenario{}, err
+	}
+	if pairing.IsBye() || (pairing.playera != b && pairing.playerb != b) {
+		return DrawScenario{}, ErrPlayersNotPaired
+	}
+	if pairing.IsComplete() {
+		return DrawScenario{}, ErrResultReported
+	}
+	_, later := t.clinchRounds()
+	points := map[int]int{}
+	dropped := map[int]bool{}
+	for _, standing := range t.GetStandings() {
+		points[standing.Id], dropped[standing.Id] = standing.Points+t.config.PointsDraw, standing.Dropped
+	}
+	decided := map[int]int{a: t.config.PointsDraw, b: t.config.PointsDraw}
+	status := func(id int) CutStatus {
+		target := points[id]
+		if !dropped[id] {
+			target += later * t.config.PointsLoss
+		}
+		switch {
+		case !t.catchable(id, target, cutSize, decided, 0):
+			return CutLocked
+		case !t.catchable(id, target+1, cutSize, decided, 0):
+			return CutOnTiebreakers
+		}
+		return CutAtRisk
+	}
+	return DrawScenario{PlayerA: a, PlayerB: b, PointsA: points[a], PointsB: points[b], StatusA: status(a), StatusB: status(b)}, nil
+}
+
+// clinchRounds returns whether the results of the current round still count towards the cut, which
+// they do once it is paired, and how many Swiss rounds are left after it.
+func (t *Tournament) clinchRounds() (bool, int) {
+	remaining := t.remainingRounds()
+	if remaining > 0 && len(t.rounds[t.currentRound]) > 0 {
+		return true, remaining - 1
+	}
+	return false, remaining
+}
+
+// earned returns the points player id earns from a complete match.
+func (t *Tournament) earned(pairing Pairing, id int) int {
+	switch pairing.Winner() {
+	case id:
+		return t.config.PointsWin
+	case 0:
+		return t.config.PointsDraw
+	}
+	return t.config.PointsLoss
+}
+
+// settle returns the points the players of id's current round match earn from it, with id earning
+// points if the result is not reported yet. Unpaired players earn nothing.
+func (t *Tournament) settle(id int, points int) map[int]int {
+	decided := map[int]int{}
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		decided[id] = 0
+		return decided
+	}
+	opponent := pairing.playera + pairing.playerb - id
+	switch {
+	case pairing.IsComplete():
+		decided[id], decided[opponent] = t.earned(*pairing, id), t.earned(*pairing, opponent)
+	case points == t.config.PointsWin:
+		decided[id], decided[opponent] = points, t.config.PointsLoss
+	case points == t.config.PointsLoss:
+		decided[id], decided[opponent] = points, t.config.PointsWin
+	default:
+		decided[id], decided[opponent] = points, points
+	}
+	delete(decided, byeId)
+	return decided
+}
+
+// catchable reports whether count players other than id can finish on at least target points. In a
+// paired current round the players of id's match earn the points in decided, reported results stand
+// and every other match may end either way. Later rounds may be paired any way, with reserved of
+// their half matches taken by id's own results.
+//
+// Later results are counted in half matches: a win takes a whole match and a draw half of one, as the
+// other half can go to another player who needs a draw. Any choice of results that fits in the half
+// matches of the later rounds can be paired, as long as a win is worth at least two draws, as in every
+// preset, because then nobody needs more than one draw. With other scoring catchable may find results
+// the pairings cannot produce, so that it errs on the side of a player not being clinched.
+func (t *Tournament) catchable(id int, target int, count int, decided map[int]int, reserved int) bool {
+	if count <= 0 {
+		return true
+	}
+	current, later := t.clinchRounds()
+	active := 0
+	for _, player := range t.players {
+		if !player.dropped {
+			active++
+		}
+	}
+	capacity := later*2*((active+1)/2) - reserved
+	points := map[int]int{}
+	dropped := map[int]bool{}
+	for _, standing := range t.GetStandings() {
+		points[standing.Id], dropped[standing.Id] = standing.Points, standing.Dropped
+	}
+
+	// cost returns the half matches a player earning gained in the current round needs to reach the
+	// target, or -1 if they cannot. Dropped players play no later rounds, and phantoms and
+	// disqualified players are not in the standings at all.
+	cost := func(player int, gained int) int {
+		if _, ok := points[player]; !ok {
+			return -1
+		}
+		behind := target - points[player] - gained
+		if dropped[player] {
+			if behind > 0 {
+				return -1
+			}
+			return 0
+		}
+		if cost, ok := t.catchUpCost(behind-later*t.config.PointsLoss, later); ok {
+			return cost
+		}
+		return -1
+	}
+	// best[n] is the fewest half matches in which n of the players seen so far reach the target, or -1.
+	best := make([]int, count+1)
+	for n := range best {
+		best[n] = -1
+	}
+	best[0] = 0
+	// choose adds players who finish the current round with one of outcomes, given as their costs.
+	choose := func(outcomes ...[]int) {
+		next := make([]int, len(best))
+		for n := range next {
+			next[n] = -1
+		}
+		for _, costs := range outcomes {
+			for n, spent := range best {
+				if spent < 0 {
+					continue
+				}
+			subsets:
+				for subset := 0; subset < 1<<len(costs); subset++ {
+					m, total := n, spent
+					for i, cost := range costs {
+						if subset&(1<<i) == 0 {
+							continue
+						}
+						if cost < 0 {
+							continue subsets
+						}
+						m, total = m+1, total+cost
+					}
+					if m <= count && (next[m] < 0 || total < next[m]) {
+						next[m] = total
+					}
+				}
+			}
+		}
+		best = next
+	}
+
+	seen := map[int]bool{id: true}
+	if current {
+		for _, pairing := range t.rounds[t.currentRound] {
+			a, b := pairing.playera, pairing.playerb
+			switch {
+			case a == id || b == id:
+				for player, gained := range decided {
+					if !seen[player] {
+						choose([]int{cost(player, gained)})
+					}
+				}
+			case pairing.IsBye():
+				choose([]int{cost(a, t.earned(pairing, a))})
+			case pairing.IsComplete():
+				choose([]int{cost(a, t.earned(pairing, a)), cost(b, t.earned(pairing, b))})
+			default:
+				win, draw, loss := t.config.PointsWin, t.config.PointsDraw, t.config.PointsLoss
+				choose([]int{cost(a, win), cost(b, loss)}, []int{cost(a, loss), cost(b, win)}, []int{cost(a, draw), cost(b, draw)})
+			}
+			seen[a], seen[b] = true, true
+		}
+	}
+	for player := range points {
+		if !seen[player] {
+			choose([]int{cost(player, 0)})
+		}
+	}
+	return best[count] >= 0 && best[count] <= capacity
+}
@@ -0,0 +1,140 @@
+package swisstools
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestClinchedCut(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Rounds: 3})
+	for i := 1; i <= 8; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	for round := 1; round <= 2; round++ {
+		tournament.Pair()
+		for _, pairing := range tournament.GetRound() {
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+		tournament.NextRound()
+	}
+	// Two players on 6 points, four on 3 and two on 0 with one round to go. Losing the last round the
+	// 6 point players can be caught by at most five others.
+	clinched, _ := tournament.ClinchedCut(6)
+	if len(clinched) != 2 || tournament.players[clinched[0]].points != 6 || tournament.players[clinched[1]].points != 6 {
+		t.Fatalf("Expecting the two undefeated players to be clinched, got %v.", clinched)
+	}
+	clinched, _ = tournament.ClinchedCut(4)
+	if len(clinched) != 0 {
+		t.Fatalf("Expecting nobody to have clinched the top 4, got %v.", clinched)
+	}
+	// Players who dropped cannot catch up any more.
+	dropped := 0
+	for id, player := range tournament.players {
+		if player.points == 3 && dropped < 2 {
+			tournament.DropPlayer(id)
+			dropped++
+		}
+	}
+	clinched, _ = tournament.ClinchedCut(4)
+	if len(clinched) != 2 {
+		t.Fatalf("Expecting the undefeated players to clinch the top 4 after two players dropped, got %v.", clinched)
+	}
+}
+
+// pairedLastRound returns eight players with the last of three rounds paired. Players 1 and 2 are on
+// 6 points and play each other, 3 against 5 and 4 against 6 on 3 points, and 7 against 8 on 0.
+func pairedLastRound() Tournament {
+	tournament := NewTournamentWithConfig(TournamentConfig{Rounds: 3})
+	for i := 1; i <= 8; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	win := func(a int, b int) Pairing {
+		return Pairing{playera: a, playerb: b, playeraWins: 2, playerbWins: 0, draws: 0}
+	}
+	pending := func(a int, b int) Pairing {
+		return Pairing{playera: a, playerb: b, playeraWins: -1, playerbWins: -1, draws: -1}
+	}
+	tournament.rounds[1] = Round{win(1, 8), win(2, 7), win(3, 6), win(4, 5)}
+	tournament.NextRound()
+	tournament.rounds[2] = Round{win(1, 3), win(2, 4), win(5, 8), win(6, 7)}
+	tournament.NextRound()
+	tournament.rounds[3] = Round{pending(1, 2), pending(3, 5), pending(4, 6), pending(7, 8)}
+	return tournament
+}
+
+func TestClinchedCutPairedRound(t *testing.T) {
+	tournament := pairedLastRound()
+	// Only one player of each match on 3 points can reach 6, so with the other undefeated player at
+	// most three players can catch an undefeated player who loses.
+	clinched, _ := tournament.ClinchedCut(4)
+	if len(clinched) != 2 || tournament.players[clinched[0]].points != 6 || tournament.players[clinched[1]].points != 6 {
+		t.Fatalf("Expecting the two undefeated players to be clinched, got %v.", clinched)
+	}
+	if clinched, _ := tournament.ClinchedCut(3); len(clinched) != 0 {
+		t.Fatalf("Expecting nobody to have clinched the top 3, got %v.", clinched)
+	}
+	// A reported result counts.
+	for _, pairing := range tournament.GetRound() {
+		if tournament.players[pairing.playera].points == 6 {
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+	}
+	if clinched, _ := tournament.ClinchedCut(1); len(clinched) != 1 || tournament.players[clinched[0]].points != 6 {
+		t.Fatalf("Expecting the winner of the undefeated match to clinch first place, got %v.", clinched)
+	}
+}
+
+func TestClinchedCutRoundsNotConfigured(t *testing.T) {
+	tournament := NewTournament()
+	if _, err := tournament.ClinchedCut(4); err == nil {
+		t.Fatal("Rounds not configured but ClinchedCut did not return an error.")
+	}
+}
+
+func TestDrawScenario(t *testing.T) {
+	tournament := pairedLastRound()
+	leaders, chasers := tournament.GetRound()[0], tournament.GetRound()[1]
+
+	scenario, err := tournament.DrawScenario(leaders.playera, leaders.playerb, 2)
+	if err != nil || !scenario.Safe() || scenario.PointsA != 7 {
+		t.Fatalf("Expecting a safe draw into the top 2, got %+v (%v).", scenario, err)
+	}
+	if scenario, _ := tournament.DrawScenario(leaders.playera, leaders.playerb, 1); scenario.StatusA != CutOnTiebreakers {
+		t.Fatalf("Expecting first place to come down to tiebreakers, got %+v.", scenario)
+	}
+	// Only the winner of the other match on 3 points can pass them, but the draw in that match ties
+	// them on 4.
+	if scenario, _ := tournament.DrawScenario(chasers.playera, chasers.playerb, 4); scenario.StatusA != CutOnTiebreakers || scenario.Safe() {
+		t.Fatalf("Expecting a draw on 3 points to come down to tiebreakers for the top 4, got %+v.", scenario)
+	}
+	if scenario, _ := tournament.DrawScenario(chasers.playera, chasers.playerb, 3); scenario.StatusA != CutAtRisk {
+		t.Fatalf("Expecting a draw on 3 points to miss the top 3, got %+v.", scenario)
+	}
+	if _, err := tournament.DrawScenario(leaders.playera, chasers.playera, 2); err != ErrPlayersNotPaired {
+		t.Fatalf("Expecting ErrPlayersNotPaired, got %v.", err)
+	}
+
+	if ok, _ := tournament.CanClinchTopCut(leaders.playera, 4); !ok {
+		t.Fatalf("Expecting an undefeated player to clinch the top 4 by winning.")
+	}
+	// Winning, a player on 3 points can only be caught by both leaders and the winner of the other
+	// match on 3 points.
+	if ok, _ := tournament.CanClinchTopCut(chasers.playera, 4); !ok {
+		t.Fatalf("Expecting a player on 3 points to clinch the top 4 by winning.")
+	}
+	if ok, _ := tournament.CanClinchTopCut(chasers.playera, 3); ok {
+		t.Fatalf("Expecting a player on 3 points not to clinch the top 3.")
+	}
+
+	// Once the leaders' match is reported the loser cannot count on winning it.
+	if ok, _ := tournament.CanClinchTopCut(leaders.playera, 2); !ok {
+		t.Fatalf("Expecting an undefeated player to clinch the top 2 by winning.")
+	}
+	tournament.AddResult(leaders.playerb, 2, 0, 0)
+	if ok, _ := tournament.CanClinchTopCut(leaders.playera, 2); ok {
+		t.Fatalf("Expecting a player who lost their match not to clinch the top 2.")
+	}
+	if _, err := tournament.DrawScenario(leaders.playera, leaders.playerb, 2); err != ErrResultReported {
+		t.Fatalf("Expecting ErrResultReported for a reported match, got %v.", err)
+	}
+}
@@ -0,0 +1,342 @@
+// Command scorekeeper is a full-screen terminal scorekeeper for swisstools tournaments. The screen
+// is split into panes, redrawn after every command: the pairings of the current round with their
+// results, the standings, and the active and dropped players. Commands are typed one per line at the
+// bottom of the screen:
+//
+//	add NAME            register a player
+//	drop PLAYER         drop a player, by id or name
+//	pair                pair the current round
+//	result W-L[-D] PLAYER
+//	                    report the games won, lost and drawn by a player
+//	table N W-L[-D]     report the games won, lost and drawn by the first player at table N
+//	next                complete the round
+//	undo                undo the last change
+//	quit
+//
+// Results are validated by the tournament, so an impossible score or a match that was already
+// reported is refused with the reason shown below the panes. The screen is drawn with plain ANSI
+// escape codes and read line by line, so the scorekeeper runs in any terminal without further
+// dependencies; its width is taken from -width or $COLUMNS.
+//
+// The tournament is saved after every change to <dir>/<id>.json and loaded from there on start, so
+// the event survives a crash or a closed terminal.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dstathis/swisstools"
+)
+
+// defaultWidth is the screen width used when neither -width nor $COLUMNS is set.
+const defaultWidth = 100
+
+type app struct {
+	tournament *swisstools.Tournament
+	out        io.Writer
+	clear      bool   // Clear the screen before redrawing.
+	width      int    // Screen width in columns, defaultWidth if 0.
+	message    string // Outcome of the last command.
+}
+
+func main() {
+	dir := flag.String("dir", ".", "directory to save the tournament in")
+	id := flag.String("id", "tournament", "name of the tournament file, without .json")
+	rounds := flag.Int("rounds", 0, "number of Swiss rounds of a new tournament")
+	width := flag.Int("width", 0, "screen width in columns, $COLUMNS if not set")
+	flag.Parse()
+
+	storage := swisstools.FileStorage{Dir: *dir}
+	tournament, err := swisstools.LoadFromStorage(storage, *id)
+	if errors.Is(err, swisstools.ErrTournamentNotFound) {
+		tournament, err = swisstools.NewTournamentWithConfig(swisstools.TournamentConfig{Rounds: *rounds}), nil
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := tournament.EnableAutosave(storage, *id, swisstools.AutosaveChange); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *width == 0 {
+		*width, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+	}
+	a := &app{tournament: &tournament, out: os.Stdout, clear: true, width: *width}
+	a.run(os.Stdin)
+}
+
+// run reads commands until quit or the end of in, redrawing the screen after each one.
+func (a *app) run(in io.Reader) {
+	lines := bufio.NewScanner(in)
+	a.draw()
+	for lines.Scan() {
+		if strings.TrimSpace(lines.Text()) == "quit" {
+			return
+		}
+		a.message = a.execute(lines.Text())
+		if err := a.tournament.AutosaveError(); err != nil {
+			a.message += " (not saved: " + err.Error() + ")"
+		}
+		a.draw()
+	}
+}
+
+// draw redraws the screen: the pairings and the standings side by side if they fit, otherwise one
+// above the other, the players below them and the outcome of the last command with the prompt last.
+func (a *app) draw() {
+	if a.clear {
+		fmt.Fprint(a.out, "\033[H\033[2J")
+	}
+	width := a.width
+	if width <= 0 {
+		width = defaultWidth
+	}
+	pairings, standings := a.pairingsPane(), a.standingsPane()
+	if paneWidth(pairings)+paneWidth(standings) <= width {
+		for _, line := range beside(box(pairings), box(standings)) {
+			fmt.Fprintln(a.out, line)
+		}
+	} else {
+		for _, line := range append(box(pairings), box(standings)...) {
+			fmt.Fprintln(a.out, line)
+		}
+	}
+	for _, line := range box(a.playersPane(width - 4)) {
+		fmt.Fprintln(a.out, line)
+	}
+	fmt.Fprintf(a.out, "%s\n> ", a.message)
+}
+
+// pane is a titled block of lines drawn in a box.
+type pane struct {
+	title string
+	lines []string
+}
+
+func (a *app) pairingsPane() pane {
+	t := a.tournament
+	round := t.GetRound()
+	title := fmt.Sprintf("Round %d pairings", t.CurrentRoundNumber())
+	if len(round) == 0 {
+		return pane{title: title, lines: []string{"Not paired yet, type pair."}}
+	}
+	var buf bytes.Buffer
+	t.FormatPairings(&buf, swisstools.FormatOptions{})
+	reported := 0
+	for _, pairing := range round {
+		if pairing.IsComplete() {
+			reported++
+		}
+	}
+	title += fmt.Sprintf(", %d of %d reported", reported, len(round))
+	return pane{title: title, lines: tableLines(&buf)}
+}
+
+func (a *app) standingsPane() pane {
+	var buf bytes.Buffer
+	a.tournament.FormatStandings(&buf, swisstools.FormatOptions{})
+	return pane{title: "Standings", lines: tableLines(&buf)}
+}
+
+// playersPane lists the active and the dropped players with their ids, wrapped to width.
+func (a *app) playersPane(width int) pane {
+	active, dropped := []string{}, []string{}
+	for _, standing := range a.tournament.GetStandings() {
+		name := fmt.Sprintf("%s (%d)", standing.Name, standing.Id)
+		if standing.Dropped {
+			dropped = append(dropped, name)
+		} else {
+			active = append(active, name)
+		}
+	}
+	lines := wrap(fmt.Sprintf("Active %d: ", len(active)), active, width)
+	lines = append(lines, wrap(fmt.Sprintf("Dropped %d: ", len(dropped)), dropped, width)...)
+	return pane{title: "Players", lines: lines}
+}
+
+// tableLines splits a formatted table into lines.
+func tableLines(buf *bytes.Buffer) []string {
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
+
+// wrap joins names after prefix into lines of at most width columns.
+func wrap(prefix string, names []string, width int) []string {
+	if len(names) == 0 {
+		return []string{prefix + "none"}
+	}
+	lines := []string{}
+	line := prefix
+	for i, name := range names {
+		if i < len(names)-1 {
+			name += ","
+		}
+		if line != prefix && utf8.RuneCountInString(line)+1+utf8.RuneCountInString(name) > width {
+			lines = append(lines, line)
+			line = strings.Repeat(" ", utf8.RuneCountInString(prefix)) + name
+			continue
+		}
+		if line != prefix {
+			line += " "
+		}
+		line += name
+	}
+	return append(lines, line)
+}
+
+// paneWidth returns the width of the pane's box, borders included.
+func paneWidth(p pane) int {
+	width := utf8.RuneCountInString(p.title) + 2
+	for _, line := range p.lines {
+		if n := utf8.RuneCountInString(line); n > width {
+			width = n
+		}
+	}
+	return width + 4
+}
+
+// box draws a pane with a border and its title in the top border.
+func box(p pane) []string {
+	inner := paneWidth(p) - 4
+	title := " " + p.title + " "
+	lines := []string{"┌─" + title + strings.Repeat("─", inner-utf8.RuneCountInString(title)) + "─┐"}
+	for _, line := range p.lines {
+		lines = append(lines, "│ "+line+strings.Repeat(" ", inner-utf8.RuneCountInString(line))+" │")
+	}
+	return append(lines, "└"+strings.Repeat("─", inner+2)+"┘")
+}
+
+// beside puts two boxes next to each other, padding the shorter one.
+func beside(left []string, right []string) []string {
+	width := utf8.RuneCountInString(left[0])
+	lines := []string{}
+	for i := 0; i < len(left) || i < len(right); i++ {
+		line := strings.Repeat(" ", width)
+		if i < len(left) {
+			line = left[i]
+		}
+		if i < len(right) {
+			line += " " + right[i]
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// execute runs one command and describes the outcome.
+func (a *app) execute(line string) string {
+	command, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+	args = strings.TrimSpace(args)
+	t := a.tournament
+	var err error
+	switch command {
+	case "":
+		return ""
+	case "add":
+		if err = t.AddPlayer(args); err == nil {
+			return "Added " + args + "."
+		}
+	case "drop":
+		var id int
+		if id, err = a.player(args); err == nil {
+			err = t.DropPlayer(id)
+		}
+		if err == nil {
+			return "Dropped " + args + "."
+		}
+	case "pair":
+		if err = t.Pair(); err == nil {
+			return "Paired round " + strconv.Itoa(t.CurrentRoundNumber()) + "."
+		}
+	case "result":
+		score, player, _ := strings.Cut(args, " ")
+		var id, wins, losses, draws int
+		if wins, losses, draws, err = parseScore(score); err == nil {
+			id, err = a.player(strings.TrimSpace(player))
+		}
+		if err == nil {
+			err = t.AddResult(id, wins, losses, draws)
+		}
+		if err == nil {
+			return "Recorded " + score + " for " + strings.TrimSpace(player) + "."
+		}
+	case "table":
+		number, score, _ := strings.Cut(args, " ")
+		score = strings.TrimSpace(score)
+		var id, wins, losses, draws int
+		if wins, losses, draws, err = parseScore(score); err == nil {
+			id, err = a.table(number)
+		}
+		if err == nil {
+			err = t.AddResult(id, wins, losses, draws)
+		}
+		if err == nil {
+			return "Recorded " + score + " at table " + number + "."
+		}
+	case "next":
+		if !t.IsRoundComplete() {
+			return "Error: " + swisstools.ErrIncompleteMatch.Error()
+		}
+		if err = t.NextRound(); err == nil {
+			return "Started round " + strconv.Itoa(t.CurrentRoundNumber()) + "."
+		}
+	case "undo":
+		if err = t.Undo(); err == nil {
+			return "Undone."
+		}
+	default:
+		return "Unknown command " + command + ". Commands: add, drop, pair, result, table, next, undo, quit."
+	}
+	return "Error: " + err.Error()
+}
+
+// player finds a player by id or name.
+func (a *app) player(arg string) (int, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		if _, err := a.tournament.GetPlayerById(id); err != nil {
+			return 0, err
+		}
+		return id, nil
+	}
+	return a.tournament.GetPlayerID(arg)
+}
+
+// table finds the first player of the match at a table of the current round.
+func (a *app) table(arg string) (int, error) {
+	number, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("invalid table %q", arg)
+	}
+	for _, pairing := range a.tournament.GetRound() {
+		if !pairing.IsBye() && pairing.TableNumber() == number {
+			return pairing.PlayerA(), nil
+		}
+	}
+	return 0, fmt.Errorf("no match at table %d", number)
+}
+
+// parseScore parses games won, lost and drawn written as "2-1" or "1-1-1".
+func parseScore(score string) (int, int, int, error) {
+	parts := strings.Split(score, "-")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, 0, 0, fmt.Errorf("invalid score %q, expecting W-L or W-L-D", score)
+	}
+	games := [3]int{}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid score %q, expecting W-L or W-L-D", score)
+		}
+		games[i] = n
+	}
+	return games[0], games[1], games[2], nil
+}
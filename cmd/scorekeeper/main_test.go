@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dstathis/swisstools"
+)
+
+func TestScorekeeper(t *testing.T) {
+	tournament := swisstools.NewTournament()
+	var out bytes.Buffer
+	a := &app{tournament: &tournament, out: &out}
+	a.run(strings.NewReader("add Dylan\nadd Sam\nadd Dylan\npair\nresult 2-x Dylan\nnext\nresult 2-1 Dylan\nnext\ndrop Sam\nfly\nquit\nadd Alex\n"))
+	if tournament.CurrentRoundNumber() != 2 {
+		t.Fatalf("Expecting round 2, got %d.", tournament.CurrentRoundNumber())
+	}
+	if _, err := tournament.GetPlayerID("Alex"); err == nil {
+		t.Fatalf("Expecting commands after quit to be ignored.")
+	}
+	screen := out.String()
+	for _, expected := range []string{"Error: duplicate name", "Paired round 1.", `invalid score "2-x"`, "Error: round not complete", "Recorded 2-1 for Dylan.", "Started round 2.", "Dropped Sam.", "Unknown command fly."} {
+		if !strings.Contains(screen, expected) {
+			t.Fatalf("Expecting %q on the screen, got:\n%s", expected, screen)
+		}
+	}
+	view, _ := tournament.GetPlayerView(2)
+	if !view.Dropped {
+		t.Fatalf("Expecting Sam to be dropped.")
+	}
+}
+
+func TestScorekeeperPanes(t *testing.T) {
+	tournament := swisstools.NewTournament()
+	var out bytes.Buffer
+	a := &app{tournament: &tournament, out: &out, width: 200}
+	a.run(strings.NewReader("add Dylan\nadd Sam\nadd Alex\nadd Robin\npair\ntable 1 2-1\ntable 1 2-0\ntable 9 2-0\ndrop Robin\n"))
+	if tournament.GetRound()[0].Winner() != tournament.GetRound()[0].PlayerA() {
+		t.Fatalf("Expecting the first player at table 1 to win, got %+v.", tournament.GetRound()[0])
+	}
+	screen := out.String()
+	last := screen[strings.LastIndex(screen, "Round 1 pairings"):]
+	for _, expected := range []string{"Round 1 pairings, 1 of 2 reported", "Standings", "Players", "Active 3: ", "Dropped 1: Robin (4)", "Recorded 2-1 at table 1.", "match already reported", "no match at table 9"} {
+		if !strings.Contains(screen, expected) {
+			t.Fatalf("Expecting %q on the screen, got:\n%s", expected, screen)
+		}
+	}
+	if first := strings.SplitN(last, "\n", 2)[0]; !strings.Contains(first, "Standings") {
+		t.Fatalf("Expecting the pairings and standings side by side, got:\n%s", last)
+	}
+
+	out.Reset()
+	a.width = 40
+	a.draw()
+	if first := strings.SplitN(out.String(), "\n", 2)[0]; strings.Contains(first, "Standings") {
+		t.Fatalf("Expecting the panes above each other on a narrow screen, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,46 @@
+package swisstools
+
+import (
+	"sort"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// nameKey is the key of a name in the name index. Names which only differ in how their diacritics
+// are encoded, such as a precomposed "Å" and "A" followed by a combining ring, are the same name.
+func nameKey(name string) string {
+	return norm.NFC.String(name)
+}
+
+// collator returns a collator for TournamentConfig.Locale, falling back to the root collation order.
+func (t *Tournament) collator() *collate.Collator {
+	tag, err := language.Parse(t.config.Locale)
+	if err != nil {
+		tag = language.Und
+	}
+	return collate.New(tag)
+}
+
+// CompareNames compares two names by Unicode collation rules for the configured locale. It returns
+// a negative number if a sorts before b, a positive number if it sorts after and 0 if they are equal.
+func (t *Tournament) CompareNames(a string, b string) int {
+	return t.collator().CompareString(a, b)
+}
+
+// playerIdsByName returns the ids of all players ordered by name.
+func (t *Tournament) playerIdsByName() []int {
+	collator := t.collator()
+	ids := []int{}
+	for id := range t.players {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if c := collator.CompareString(t.players[ids[i]].name, t.players[ids[j]].name); c != 0 {
+			return c < 0
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
@@ -0,0 +1,28 @@
+package swisstools
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNameCollation(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Locale: "sv"})
+	tournament.AddPlayer("Zorn")
+	tournament.AddPlayer("Ågren")
+	tournament.AddPlayer("Berg")
+	// Swedish sorts Å after Z, the root collation sorts it with A.
+	var buf bytes.Buffer
+	tournament.FormatPlayers(&buf)
+	out := buf.String()
+	if !(strings.Index(out, "Berg") < strings.Index(out, "Zorn") && strings.Index(out, "Zorn") < strings.Index(out, "Ågren")) {
+		t.Fatalf("Expecting Berg, Zorn, Ågren in Swedish order, got:\n%s", out)
+	}
+	root := NewTournament()
+	if root.CompareNames("Ågren", "Berg") >= 0 {
+		t.Fatal("Expecting Ågren to sort before Berg in the root collation.")
+	}
+	if err := tournament.AddPlayer("A\u030agren"); err == nil {
+		t.Fatal("Decomposed duplicate of Ågren but AddPlayer did not return an error.")
+	}
+}
@@ -0,0 +1,196 @@
+package swisstools
+
+import "math/rand"
+
+const (
+	white = 1
+	black = -1
+)
+
+// Side is a player's side in a match: white or black in chess mode, or on the play or the draw in
+// the first game with TournamentConfig.PlayDraw.
+type Side int
+
+const (
+	SideNone   Side = 0
+	SideFirst  Side = white // White, or on the play.
+	SideSecond Side = black // Black, or on the draw.
+)
+
+// White returns the id of the player with the white pieces, or 0 outside of chess mode.
+func (p Pairing) White() int {
+	return p.white
+}
+
+// Black returns the id of the player with the black pieces, or 0 outside of chess mode.
+func (p Pairing) Black() int {
+	if p.white == 0 {
+		return 0
+	}
+	if p.white == p.playera {
+		return p.playerb
+	}
+	return p.playera
+}
+
+// First returns the id of the player with white in chess mode or on the play with
+// TournamentConfig.PlayDraw, or 0 if no side was assigned.
+func (p Pairing) First() int {
+	if p.white != 0 {
+		return p.white
+	}
+	return p.play
+}
+
+// Side returns the side of a player in the pairing, SideNone if no side was assigned.
+func (p Pairing) Side(id int) Side {
+	switch first := p.First(); {
+	case first == 0 || (p.playera != id && p.playerb != id):
+		return SideNone
+	case first == id:
+		return SideFirst
+	}
+	return SideSecond
+}
+
+// SideHistory returns the side a player had in every round from round 1 to the current round,
+// SideNone for rounds without a side.
+func (t *Tournament) SideHistory(id int) ([]Side, error) {
+	if _, ok := t.players[id]; !ok {
+		return nil, ErrPlayerNotFound
+	}
+	sides := []Side{}
+	for _, side := range t.sideHistory(id)[1:] {
+		sides = append(sides, Side(side))
+	}
+	return sides, nil
+}
+
+// sideHistory returns the side a player had in each round, 0 for rounds without a side.
+func (t *Tournament) sideHistory(id int) []int {
+	history := make([]int, len(t.rounds))
+	for r, round := range t.rounds {
+		for _, pairing := range round {
+			history[r] += int(pairing.Side(id))
+		}
+	}
+	return history
+}
+
+// allocateSides assigns white or the play in a new pairing as configured.
+func (t *Tournament) allocateSides(pairing *Pairing) {
+	switch {
+	case pairing.IsBye():
+	case t.config.Chess:
+		pairing.white = t.allocateColors(pairing.playera, pairing.playerb)
+	case t.config.PlayDraw:
+		pairing.play = t.allocatePlay(pairing.playera, pairing.playerb)
+	}
+}
+
+// allocatePlay returns the id of the player on the play: the one who was on the play less often
+// than on the draw, at random if both were equally often.
+func (t *Tournament) allocatePlay(a int, b int) int {
+	balance := func(id int) int {
+		sum := 0
+		for _, side := range t.sideHistory(id) {
+			sum += side
+		}
+		return sum
+	}
+	balanceA, balanceB := balance(a), balance(b)
+	switch {
+	case balanceA < balanceB:
+		return a
+	case balanceB < balanceA:
+		return b
+	case rand.Intn(2) == 0:
+		return a
+	}
+	return b
+}
+
+// colorPreference returns the color a player is due and how strongly: 3 for an absolute preference
+// (color difference above 1 or the same color twice in a row), 2 for a strong preference (color
+// difference of 1), 1 for a mild preference (alternation) and 0 if the player has no games yet.
+func (t *Tournament) colorPreference(id int) (int, int) {
+	difference := 0
+	played := []int{}
+	for _, color := range t.sideHistory(id) {
+		if color != 0 {
+			difference += color
+			played = append(played, color)
+		}
+	}
+	if len(played) == 0 {
+		return 0, 0
+	}
+	last := played[len(played)-1]
+	switch {
+	case difference > 1 || (len(played) > 1 && last == white && played[len(played)-2] == white):
+		return black, 3
+	case difference < -1 || (len(played) > 1 && last == black && played[len(played)-2] == black):
+		return white, 3
+	case difference == 1:
+		return black, 2
+	case difference == -1:
+		return white, 2
+	}
+	return -last, 1
+}
+
+// colorsCompatible reports whether two players can meet without either breaking an absolute color
+// preference. It always holds outside of chess mode.
+func (t *Tournament) colorsCompatible(a int, b int) bool {
+	if !t.config.Chess {
+		return true
+	}
+	colorA, strengthA := t.colorPreference(a)
+	colorB, strengthB := t.colorPreference(b)
+	return !(strengthA == 3 && strengthB == 3 && colorA == colorB)
+}
+
+// allocateColors returns the id of the player who gets white. Player a is expected to be the higher
+// ranked player and gets their preference when nothing else decides.
+func (t *Tournament) allocateColors(a int, b int) int {
+	colorA, strengthA := t.colorPreference(a)
+	colorB, strengthB := t.colorPreference(b)
+	switch {
+	case colorA == 0 && colorB == 0:
+		if rand.Intn(2) == 0 {
+			return a
+		}
+		return b
+	case colorA != colorB:
+		// At most one player has a preference or the preferences are complementary.
+		if colorA == white || colorB == black {
+			return a
+		}
+		return b
+	case strengthA > strengthB:
+		if colorA == white {
+			return a
+		}
+		return b
+	case strengthB > strengthA:
+		if colorB == white {
+			return b
+		}
+		return a
+	}
+	// Equal preferences: alternate from the most recent round in which the colors differed.
+	historyA := t.sideHistory(a)
+	historyB := t.sideHistory(b)
+	for r := len(historyA) - 1; r >= 0; r-- {
+		if historyA[r] != 0 && historyB[r] != 0 && historyA[r] != historyB[r] {
+			if historyA[r] == black {
+				return a
+			}
+			return b
+		}
+	}
+	if colorA == white {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,104 @@
+package swisstools
+
+import "fmt"
+
+// ResultReport is a result submitted for confirmation, see TournamentConfig.ConfirmResults.
+type ResultReport struct {
+	Result Result // Result.Player is the player whose score is given first.
+	Judge  bool   // Entered by a judge rather than by Result.Player.
+}
+
+// PendingResult is a current round match with result reports waiting for confirmation.
+type PendingResult struct {
+	Pairing Pairing
+	Reports []ResultReport
+	// Conflicting is set once reports from two sources disagree, to be resolved by the scorekeeper
+	// with AddResults and Result.Override.
+	Conflicting bool
+}
+
+// AddJudgeResult submits a result for the current round match of player id on behalf of a judge. With
+// TournamentConfig.ConfirmResults it confirms a report from either player, otherwise it is entered
+// like AddResult.
+func (t *Tournament) AddJudgeResult(id int, wins int, losses int, draws int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return err
+	}
+	result := Result{Player: id, Wins: wins, Losses: losses, Draws: draws}
+	if err := t.validateResult(*pairing, result); err != nil {
+		return err
+	}
+	t.recordChange(t.pairingSnapshot(t.currentRound, pairing))
+	if !t.config.ConfirmResults {
+		t.enterResult(pairing, result)
+		return nil
+	}
+	t.submitResult(pairing, ResultReport{Result: result, Judge: true})
+	return nil
+}
+
+// PendingResults returns the current round matches with submitted but unconfirmed results.
+func (t *Tournament) PendingResults() []PendingResult {
+	pending := []PendingResult{}
+	for _, pairing := range t.rounds[t.currentRound] {
+		if len(pairing.reports) == 0 {
+			continue
+		}
+		// Agreeing reports are entered right away.
+		pending = append(pending, PendingResult{Pairing: pairing, Reports: append([]ResultReport{}, pairing.reports...), Conflicting: len(pairing.reports) > 1})
+	}
+	return pending
+}
+
+// submitResult records a report and enters the result once reports from two sources agree. A new
+// report from the same source replaces the previous one.
+func (t *Tournament) submitResult(pairing *Pairing, report ResultReport) {
+	reports := []ResultReport{}
+	for _, other := range pairing.reports {
+		if reportSource(other) != reportSource(report) {
+			reports = append(reports, other)
+		}
+	}
+	reports = append(reports, report)
+	pairing.reports = reports
+	t.logChange("submit_result", t.currentRound, report.Result.Player, "", describeReport(*pairing, report))
+	for _, other := range reports[:len(reports)-1] {
+		if pairing.normalize(other.Result) == pairing.normalize(report.Result) {
+			before := describePairing(*pairing)
+			pairing.setResult(report.Result.Player, report.Result.Wins, report.Result.Losses, report.Result.Draws)
+			pairing.reports = nil
+			t.logChange("result", t.currentRound, report.Result.Player, before, describePairing(*pairing))
+			t.emit(Event{Type: ResultRecorded, Round: t.currentRound, PlayerId: report.Result.Player, Pairing: *pairing})
+			return
+		}
+	}
+}
+
+// reportSource is the player who submitted a report, or 0 for a judge.
+func reportSource(report ResultReport) int {
+	if report.Judge {
+		return 0
+	}
+	return report.Result.Player
+}
+
+// normalize returns a result as games won by player A, games won by player B and draws.
+func (p Pairing) normalize(result Result) [3]int {
+	if result.Player == p.playera {
+		return [3]int{result.Wins, result.Losses, result.Draws}
+	}
+	return [3]int{result.Losses, result.Wins, result.Draws}
+}
+
+func describeReport(p Pairing, report ResultReport) string {
+	score := p.normalize(report.Result)
+	source := "player"
+	if report.Judge {
+		source = "judge"
+	}
+	return fmt.Sprintf("%d vs %d %d-%d-%d by %s", p.playera, p.playerb, score[0], score[1], score[2], source)
+}
@@ -0,0 +1,43 @@
+package swisstools
+
+import "testing"
+
+func TestConfirmResults(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{ConfirmResults: true})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	first, second := tournament.GetRound()[0], tournament.GetRound()[1]
+
+	tournament.AddResult(first.playera, 2, 1, 0)
+	if pending := tournament.PendingResults(); len(pending) != 1 || pending[0].Conflicting || tournament.GetRound()[0].IsComplete() {
+		t.Fatalf("Expecting one unconfirmed report, got %+v.", pending)
+	}
+	tournament.AddResult(first.playerb, 1, 2, 0)
+	if wins, losses, _ := tournament.GetRound()[0].Result(); wins != 2 || losses != 1 {
+		t.Fatalf("Expecting the confirmed 2-1 result to be entered, got %d-%d.", wins, losses)
+	}
+
+	tournament.AddResult(second.playera, 2, 0, 0)
+	tournament.AddResult(second.playerb, 2, 0, 0)
+	pending := tournament.PendingResults()
+	if len(pending) != 1 || !pending[0].Conflicting || len(pending[0].Reports) != 2 {
+		t.Fatalf("Expecting a conflicting pair of reports, got %+v.", pending)
+	}
+	data, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(data)
+	if pending := loaded.PendingResults(); len(pending) != 1 || len(pending[0].Reports) != 2 {
+		t.Fatalf("Expecting the reports to be kept in a dump, got %+v.", pending)
+	}
+	// The judge sides with the second player.
+	tournament.AddJudgeResult(second.playera, 0, 2, 0)
+	if winner := tournament.GetRound()[1].Winner(); winner != second.playerb || len(tournament.PendingResults()) != 0 {
+		t.Fatalf("Expecting the judge to confirm the second player's report, got %+v.", tournament.GetRound()[1])
+	}
+
+	loaded.AddResults([]Result{{Player: second.playera, Wins: 2, Override: true}})
+	if winner := loaded.GetRound()[1].Winner(); winner != second.playera || len(loaded.PendingResults()) != 0 {
+		t.Fatalf("Expecting the scorekeeper to resolve the conflict, got %+v.", loaded.GetRound()[1])
+	}
+}
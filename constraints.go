@@ -0,0 +1,96 @@
+package swisstools
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConstraintPolicy decides how strictly a pairing constraint keeps two players apart.
+type ConstraintPolicy string
+
+const (
+	// AvoidPairing pairs the players only if the round cannot be paired otherwise without rematches.
+	AvoidPairing ConstraintPolicy = "avoid"
+	// NeverPair never pairs the players, Pair fails instead.
+	NeverPair ConstraintPolicy = "never"
+)
+
+// PairingConstraint keeps two players, such as teammates, club mates or family, apart in the Swiss
+// rounds.
+type PairingConstraint struct {
+	PlayerA int              `json:"player_a"`
+	PlayerB int              `json:"player_b"`
+	Policy  ConstraintPolicy `json:"policy"`
+}
+
+// constraintKey orders the players of a constraint so that it is found either way round.
+func constraintKey(a int, b int) [2]int {
+	if a > b {
+		return [2]int{b, a}
+	}
+	return [2]int{a, b}
+}
+
+// AddPairingConstraint keeps two players apart from the next time a round is paired, replacing any
+// earlier constraint between them.
+func (t *Tournament) AddPairingConstraint(a int, b int, policy ConstraintPolicy) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if _, ok := t.players[a]; !ok {
+		return ErrPlayerNotFound
+	}
+	if _, ok := t.players[b]; !ok {
+		return ErrPlayerNotFound
+	}
+	if a == b {
+		return fmt.Errorf("%w: needs two players", ErrInvalidConstraint)
+	}
+	if policy != AvoidPairing && policy != NeverPair {
+		return fmt.Errorf("%w: unknown policy %q", ErrInvalidConstraint, policy)
+	}
+	if t.constraints == nil {
+		t.constraints = map[[2]int]ConstraintPolicy{}
+	}
+	t.constraints[constraintKey(a, b)] = policy
+	t.logChange("add_pairing_constraint", 0, a, "", describeConstraint(a, b, policy))
+	return nil
+}
+
+// RemovePairingConstraint removes the constraint between two players.
+func (t *Tournament) RemovePairingConstraint(a int, b int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	policy, ok := t.constraints[constraintKey(a, b)]
+	if !ok {
+		return ErrConstraintNotFound
+	}
+	delete(t.constraints, constraintKey(a, b))
+	t.logChange("remove_pairing_constraint", 0, a, describeConstraint(a, b, policy), "")
+	return nil
+}
+
+// PairingConstraints returns every pairing constraint ordered by player ids.
+func (t *Tournament) PairingConstraints() []PairingConstraint {
+	constraints := []PairingConstraint{}
+	for key, policy := range t.constraints {
+		constraints = append(constraints, PairingConstraint{PlayerA: key[0], PlayerB: key[1], Policy: policy})
+	}
+	sort.Slice(constraints, func(i, j int) bool {
+		if constraints[i].PlayerA != constraints[j].PlayerA {
+			return constraints[i].PlayerA < constraints[j].PlayerA
+		}
+		return constraints[i].PlayerB < constraints[j].PlayerB
+	})
+	return constraints
+}
+
+// pairingConstraint returns the policy between two players, empty if there is none.
+func (t *Tournament) pairingConstraint(a int, b int) ConstraintPolicy {
+	return t.constraints[constraintKey(a, b)]
+}
+
+func describeConstraint(a int, b int, policy ConstraintPolicy) string {
+	return fmt.Sprintf("%s %d vs %d", policy, a, b)
+}
@@ -0,0 +1,52 @@
+package swisstools
+
+import "testing"
+
+func TestPairingConstraints(t *testing.T) {
+	for _, algorithm := range []PairingAlgorithm{PairingBacktracking, PairingWeighted} {
+		for run := 0; run < 10; run++ {
+			tournament := NewTournamentWithConfig(TournamentConfig{PairingAlgorithm: algorithm})
+			for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+				tournament.AddPlayer(name)
+			}
+			if err := tournament.AddPairingConstraint(1, 2, NeverPair); err != nil {
+				t.Fatalf("AddPairingConstraint returned an error: %s", err)
+			}
+			tournament.AddPairingConstraint(4, 3, AvoidPairing)
+			if err := tournament.AddPairingConstraint(1, 1, NeverPair); err == nil {
+				t.Fatalf("Expecting an error for a constraint on a single player.")
+			}
+			if constraints := tournament.PairingConstraints(); len(constraints) != 2 || constraints[1] != (PairingConstraint{3, 4, AvoidPairing}) {
+				t.Fatalf("Expecting two constraints, got %v.", constraints)
+			}
+			for round := 1; round <= 3; round++ {
+				if err := tournament.Pair(); err != nil {
+					t.Fatalf("Pair returned an error with %q pairing: %s", algorithm, err)
+				}
+				for _, pairing := range tournament.GetRound() {
+					if pairing.playera+pairing.playerb == 3 {
+						t.Fatalf("Expecting Dylan and Sam never to meet, got %+v.", pairing)
+					}
+					if pairing.playera+pairing.playerb == 7 && round < 3 {
+						t.Fatalf("Expecting Alex and Kim to meet only when unavoidable, got %+v in round %d.", pairing, round)
+					}
+					tournament.AddResult(pairing.playera, 2, 0, 0)
+				}
+				tournament.NextRound()
+			}
+
+			data, _ := tournament.DumpTournament()
+			loaded, err := LoadTournament(data)
+			if err != nil {
+				t.Fatalf("LoadTournament returned an error: %s", err)
+			}
+			if len(loaded.PairingConstraints()) != 2 {
+				t.Fatalf("Expecting constraints to survive a dump, got %v.", loaded.PairingConstraints())
+			}
+			loaded.RemovePairingConstraint(2, 1)
+			if len(loaded.PairingConstraints()) != 1 {
+				t.Fatalf("Expecting one constraint after removing one, got %v.", loaded.PairingConstraints())
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package swisstools
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportStandingsCSV writes the current standings with every tiebreaker, and the ratings when a
+// rating system is set, for spreadsheets.
+func (t *Tournament) ExportStandingsCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"rank", "player_id", "name", "points", "wins", "losses", "draws", "dropped"}
+	for _, tiebreaker := range allTiebreakers {
+		header = append(header, string(tiebreaker))
+	}
+	if t.ratingSystem != nil {
+		header = append(header, "initial_rating", "rating")
+	}
+	writer.Write(header)
+	for _, standing := range t.GetStandings() {
+		row := []string{
+			strconv.Itoa(standing.Rank),
+			strconv.Itoa(standing.Id),
+			standing.Name,
+			strconv.Itoa(standing.Points),
+			strconv.Itoa(standing.Wins),
+			strconv.Itoa(standing.Losses),
+			strconv.Itoa(standing.Draws),
+			strconv.FormatBool(standing.Dropped),
+		}
+		for _, tiebreaker := range allTiebreakers {
+			row = append(row, strconv.FormatFloat(standing.Tiebreakers.value(tiebreaker), 'f', -1, 64))
+		}
+		if t.ratingSystem != nil {
+			row = append(row, formatRating(standing.InitialRating), formatRating(standing.Rating))
+		}
+		writer.Write(row)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatRating rounds ratings to one decimal for exports.
+func formatRating(rating float64) string {
+	return strconv.FormatFloat(rating, 'f', 1, 64)
+}
+
+// ExportPairingsCSV writes the pairings of a round by table.
+func (t *Tournament) ExportPairingsCSV(w io.Writer, round int) error {
+	if round < 1 || round > t.currentRound {
+		return ErrRoundOutOfRange
+	}
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"table", "player_a_id", "player_a", "player_b_id", "player_b"})
+	for _, pairing := range t.rounds[round] {
+		b := ""
+		if !pairing.IsBye() {
+			b = t.players[pairing.playerb].name
+		}
+		writer.Write([]string{strconv.Itoa(pairing.table), strconv.Itoa(pairing.playera), t.players[pairing.playera].name, strconv.Itoa(pairing.playerb), b})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportResultsCSV writes every match of every round with its result. Unreported results are empty.
+func (t *Tournament) ExportResultsCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"round", "table", "player_a_id", "player_a", "player_b_id", "player_b", "player_a_wins", "player_b_wins", "draws", "bye", "forfeit", "intentional_draw", "created", "started", "reported"})
+	for round := 1; round <= t.currentRound; round++ {
+		for _, pairing := range t.rounds[round] {
+			b := ""
+			if !pairing.IsBye() {
+				b = t.players[pairing.playerb].name
+			}
+			aWins, bWins, draws := "", "", ""
+			if pairing.IsComplete() {
+				aWins, bWins, draws = strconv.Itoa(pairing.playeraWins), strconv.Itoa(pairing.playerbWins), strconv.Itoa(pairing.draws)
+			}
+			writer.Write([]string{
+				strconv.Itoa(round),
+				strconv.Itoa(pairing.table),
+				strconv.Itoa(pairing.playera),
+				t.players[pairing.playera].name,
+				strconv.Itoa(pairing.playerb),
+				b,
+				aWins,
+				bWins,
+				draws,
+				strconv.FormatBool(pairing.IsBye()),
+				strconv.FormatBool(pairing.IsForfeit()),
+				strconv.FormatBool(pairing.intentionalDraw),
+				formatTime(pairing.created),
+				formatTime(pairing.started),
+				formatTime(pairing.reported),
+			})
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportGamesCSV writes every recorded game, one row per game, for game level statistics.
+func (t *Tournament) ExportGamesCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"round", "table", "game", "player_a_id", "player_b_id", "first_id", "winner_id", "notes"})
+	for round := 1; round <= t.currentRound; round++ {
+		for _, pairing := range t.rounds[round] {
+			for i, game := range pairing.games {
+				writer.Write([]string{
+					strconv.Itoa(round),
+					strconv.Itoa(pairing.table),
+					strconv.Itoa(i + 1),
+					strconv.Itoa(pairing.playera),
+					strconv.Itoa(pairing.playerb),
+					strconv.Itoa(game.first),
+					strconv.Itoa(game.winner),
+					game.notes,
+				})
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatTime formats a timestamp as RFC 3339, leaving unknown times empty.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,58 @@
+package swisstools
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestCSVExports(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.AddPlayer("Alex")
+	tournament.Pair()
+	round := tournament.GetRound()
+	for _, pairing := range round {
+		if !pairing.IsBye() {
+			tournament.AddResult(pairing.playera, 2, 1, 0)
+		}
+	}
+	tournament.NextRound()
+	tournament.DropPlayer(1)
+
+	var buf bytes.Buffer
+	if err := tournament.ExportStandingsCSV(&buf); err != nil {
+		t.Fatalf("ExportStandingsCSV returned an error: %s", err)
+	}
+	rows, _ := csv.NewReader(&buf).ReadAll()
+	if len(rows) != 4 || len(rows[0]) != 8+len(allTiebreakers) || rows[0][8] != "omw" {
+		t.Fatalf("Expecting a header with every tiebreaker and 3 players, got %v.", rows)
+	}
+	for _, row := range rows[1:] {
+		if (row[1] == "1") != (row[7] == "true") {
+			t.Fatalf("Expecting only Dylan to be dropped, got %v.", row)
+		}
+	}
+
+	buf.Reset()
+	if err := tournament.ExportPairingsCSV(&buf, 1); err != nil {
+		t.Fatalf("ExportPairingsCSV returned an error: %s", err)
+	}
+	rows, _ = csv.NewReader(&buf).ReadAll()
+	if len(rows) != 3 {
+		t.Fatalf("Expecting a match and a bye, got %v.", rows)
+	}
+	if err := tournament.ExportPairingsCSV(&buf, 3); err == nil {
+		t.Fatalf("Expecting an error for a round out of range.")
+	}
+
+	buf.Reset()
+	tournament.ExportResultsCSV(&buf)
+	rows, _ = csv.NewReader(&buf).ReadAll()
+	for _, row := range rows[1:] {
+		if row[9] == "false" && (row[1] != "1" || row[6] != "2" || row[7] != "1" || row[8] != "0") {
+			t.Fatalf("Expecting the match at table 1 won 2-1-0, got %v.", row)
+		}
+	}
+}
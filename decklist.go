@@ -0,0 +1,240 @@
+package swisstools
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Card is a line of a decklist.
+type Card struct {
+	Count int    `json:"count"`
+	Name  string `json:"name"`
+}
+
+// Decklist is the deck a player registered.
+type Decklist struct {
+	Main      []Card `json:"main"`
+	Sideboard []Card `json:"sideboard,omitempty"`
+}
+
+// copy returns a deep copy of the decklist so that callers cannot modify a registered deck.
+func (d *Decklist) copy() *Decklist {
+	if d == nil {
+		return nil
+	}
+	return &Decklist{Main: append([]Card{}, d.Main...), Sideboard: append([]Card{}, d.Sideboard...)}
+}
+
+// SetDecklist registers a player's deck, replacing any deck registered before.
+func (t *Tournament) SetDecklist(id int, decklist Decklist) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	player.decklist = decklist.copy()
+	t.players[id] = player
+	t.logChange("set_decklist", 0, id, "", fmt.Sprintf("%d main, %d sideboard", len(decklist.Main), len(decklist.Sideboard)))
+	return nil
+}
+
+// DecklistFormat is a text format for decklists, see ParseDecklist and ExportDecklist.
+type DecklistFormat string
+
+const (
+	// DecklistText is one "4 Lightning Bolt" line per card, with the sideboard after a blank line or
+	// a "Sideboard" line. Lines starting with "SB:" also belong to the sideboard.
+	DecklistText DecklistFormat = "text"
+	// DecklistMTGO is the .dek XML file of Magic: The Gathering Online.
+	DecklistMTGO DecklistFormat = "mtgo"
+	// DecklistArena is the export of Magic: The Gathering Arena, with "Deck" and "Sideboard" sections
+	// and set codes after the card names, which are dropped.
+	DecklistArena DecklistFormat = "arena"
+)
+
+var (
+	cardLine      = regexp.MustCompile(`^(\d+)x?\s+(.+)$`)
+	arenaPrinting = regexp.MustCompile(`\s+\([A-Za-z0-9_]+\)(\s+\S+)?$`)
+)
+
+// mtgoDeck is the layout of an MTGO .dek file.
+type mtgoDeck struct {
+	XMLName xml.Name   `xml:"Deck"`
+	Cards   []mtgoCard `xml:"Cards"`
+}
+
+type mtgoCard struct {
+	Quantity  int    `xml:"Quantity,attr"`
+	Sideboard bool   `xml:"Sideboard,attr"`
+	Name      string `xml:"Name,attr"`
+}
+
+// ParseDecklist reads a decklist in the given format.
+func ParseDecklist(r io.Reader, format DecklistFormat) (Decklist, error) {
+	decklist := Decklist{Main: []Card{}}
+	if format == DecklistMTGO {
+		deck := mtgoDeck{}
+		if err := xml.NewDecoder(r).Decode(&deck); err != nil {
+			return Decklist{}, err
+		}
+		for _, card := range deck.Cards {
+			if card.Sideboard {
+				decklist.Sideboard = append(decklist.Sideboard, Card{Count: card.Quantity, Name: card.Name})
+			} else {
+				decklist.Main = append(decklist.Main, Card{Count: card.Quantity, Name: card.Name})
+			}
+		}
+		return decklist, nil
+	}
+	if format != DecklistText && format != DecklistArena {
+		return Decklist{}, fmt.Errorf("unknown decklist format %q", format)
+	}
+	sideboard := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch strings.ToLower(line) {
+		case "":
+			sideboard = sideboard || len(decklist.Main) > 0
+			continue
+		case "deck", "commander", "main", "maindeck":
+			sideboard = false
+			continue
+		case "sideboard", "companion":
+			sideboard = true
+			continue
+		}
+		if strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+		inSideboard := sideboard
+		if strings.HasPrefix(strings.ToUpper(line), "SB:") {
+			line, inSideboard = strings.TrimSpace(line[3:]), true
+		}
+		match := cardLine.FindStringSubmatch(line)
+		if match == nil && format == DecklistArena {
+			// Deck metadata such as the "About" section.
+			continue
+		}
+		if match == nil {
+			return Decklist{}, fmt.Errorf("invalid decklist line %q", line)
+		}
+		count, _ := strconv.Atoi(match[1])
+		name := match[2]
+		if format == DecklistArena {
+			name = arenaPrinting.ReplaceAllString(name, "")
+		}
+		if inSideboard {
+			decklist.Sideboard = append(decklist.Sideboard, Card{Count: count, Name: name})
+		} else {
+			decklist.Main = append(decklist.Main, Card{Count: count, Name: name})
+		}
+	}
+	return decklist, scanner.Err()
+}
+
+// ExportDecklist writes a decklist in the given format, to be read back with ParseDecklist.
+func ExportDecklist(w io.Writer, decklist Decklist, format DecklistFormat) error {
+	switch format {
+	case DecklistMTGO:
+		deck := mtgoDeck{}
+		for _, card := range decklist.Main {
+			deck.Cards = append(deck.Cards, mtgoCard{Quantity: card.Count, Name: card.Name})
+		}
+		for _, card := range decklist.Sideboard {
+			deck.Cards = append(deck.Cards, mtgoCard{Quantity: card.Count, Sideboard: true, Name: card.Name})
+		}
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+		encoder := xml.NewEncoder(w)
+		encoder.Indent("", "  ")
+		if err := encoder.Encode(deck); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w, "\n")
+		return err
+	case DecklistText, DecklistArena:
+		var out strings.Builder
+		if format == DecklistArena {
+			out.WriteString("Deck\n")
+		}
+		for _, card := range decklist.Main {
+			fmt.Fprintf(&out, "%d %s\n", card.Count, card.Name)
+		}
+		if len(decklist.Sideboard) > 0 {
+			out.WriteString("\n")
+			if format == DecklistArena {
+				out.WriteString("Sideboard\n")
+			}
+		}
+		for _, card := range decklist.Sideboard {
+			fmt.Fprintf(&out, "%d %s\n", card.Count, card.Name)
+		}
+		_, err := io.WriteString(w, out.String())
+		return err
+	}
+	return fmt.Errorf("unknown decklist format %q", format)
+}
+
+// ExportDeckChecks writes a deck check sheet for every player with a registered deck, sorted by name,
+// for judges to check decks against.
+func (t *Tournament) ExportDeckChecks(w io.Writer) error {
+	first := true
+	for _, id := range t.playerIdsByName() {
+		if t.players[id].decklist == nil {
+			continue
+		}
+		if !first {
+			if _, err := io.WriteString(w, "\n"+strings.Repeat("-", 40)+"\n\n"); err != nil {
+				return err
+			}
+		}
+		first = false
+		if err := t.ExportDeckCheck(w, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExportDeckCheck writes the deck check sheet of a single player: their name, external id, round 1
+// table and registered deck with card totals.
+func (t *Tournament) ExportDeckCheck(w io.Writer, id int) error {
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if player.decklist == nil {
+		return ErrNoDecklist
+	}
+	var out strings.Builder
+	out.WriteString("Player: " + player.name + "\n")
+	if player.externalId != "" {
+		out.WriteString("Id: " + player.externalId + "\n")
+	}
+	if pairing, err := findPairingIn(t.rounds[1], id); err == nil && pairing.table > 0 {
+		fmt.Fprintf(&out, "Round 1 table: %d\n", pairing.table)
+	}
+	section := func(title string, cards []Card) {
+		total := 0
+		for _, card := range cards {
+			total += card.Count
+		}
+		fmt.Fprintf(&out, "\n%s (%d)\n", title, total)
+		for _, card := range cards {
+			fmt.Fprintf(&out, "%3d %s\n", card.Count, card.Name)
+		}
+	}
+	section("Main deck", player.decklist.Main)
+	section("Sideboard", player.decklist.Sideboard)
+	_, err := io.WriteString(w, out.String())
+	return err
+}
@@ -0,0 +1,76 @@
+package swisstools
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDecklist(t *testing.T) {
+	expected := Decklist{
+		Main:      []Card{{Count: 4, Name: "Lightning Bolt"}, {Count: 20, Name: "Mountain"}},
+		Sideboard: []Card{{Count: 2, Name: "Smash to Smithereens"}},
+	}
+	for _, test := range []struct {
+		format DecklistFormat
+		input  string
+	}{
+		{DecklistText, "// Burn\n4 Lightning Bolt\n20x Mountain\n\n2 Smash to Smithereens\n"},
+		{DecklistText, "4 Lightning Bolt\n20 Mountain\nSB: 2 Smash to Smithereens\n"},
+		{DecklistArena, "About\nName Burn\n\nDeck\n4 Lightning Bolt (M11) 149\n20 Mountain (ZNR) 279\n\nSideboard\n2 Smash to Smithereens (ORI) 163\n"},
+		{DecklistMTGO, `<?xml version="1.0" encoding="utf-8"?>
+<Deck xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <NetDeckID>0</NetDeckID>
+  <PreconstructedDeckID>0</PreconstructedDeckID>
+  <Cards CatID="72857" Quantity="4" Sideboard="false" Name="Lightning Bolt" Annotation="0" />
+  <Cards CatID="82243" Quantity="20" Sideboard="false" Name="Mountain" Annotation="0" />
+  <Cards CatID="57932" Quantity="2" Sideboard="true" Name="Smash to Smithereens" Annotation="0" />
+</Deck>`},
+	} {
+		decklist, err := ParseDecklist(strings.NewReader(test.input), test.format)
+		if err != nil {
+			t.Fatalf("ParseDecklist returned an error for %s: %s", test.format, err)
+		}
+		if !reflect.DeepEqual(decklist, expected) {
+			t.Fatalf("Expecting %+v from %s, got %+v.", expected, test.format, decklist)
+		}
+		out := bytes.Buffer{}
+		if err := ExportDecklist(&out, decklist, test.format); err != nil {
+			t.Fatalf("ExportDecklist returned an error for %s: %s", test.format, err)
+		}
+		if again, _ := ParseDecklist(&out, test.format); !reflect.DeepEqual(again, expected) {
+			t.Fatalf("Expecting the %s export to round-trip, got %+v.", test.format, again)
+		}
+	}
+	if _, err := ParseDecklist(strings.NewReader("Lightning Bolt\n"), DecklistText); err == nil {
+		t.Fatalf("Expecting an error for a line without a count.")
+	}
+}
+
+func TestExportDeckChecks(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Sam")
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Alex")
+	tournament.SetExternalId(2, "DCI-42")
+	tournament.SetDecklist(1, Decklist{Main: []Card{{Count: 60, Name: "Relentless Rats"}}})
+	tournament.SetDecklist(2, Decklist{Main: []Card{{Count: 4, Name: "Lightning Bolt"}, {Count: 20, Name: "Mountain"}}, Sideboard: []Card{{Count: 2, Name: "Duress"}}})
+	tournament.Pair()
+	out := bytes.Buffer{}
+	if err := tournament.ExportDeckChecks(&out); err != nil {
+		t.Fatalf("ExportDeckChecks returned an error: %s", err)
+	}
+	sheets := out.String()
+	if strings.Contains(sheets, "Alex") || strings.Index(sheets, "Dylan") > strings.Index(sheets, "Sam") {
+		t.Fatalf("Expecting the sheets of Dylan and Sam in name order, got:\n%s", sheets)
+	}
+	for _, expected := range []string{"Id: DCI-42", "Round 1 table: ", "Main deck (24)", "Sideboard (2)", "  4 Lightning Bolt"} {
+		if !strings.Contains(sheets, expected) {
+			t.Fatalf("Expecting %q in the sheets, got:\n%s", expected, sheets)
+		}
+	}
+	if err := tournament.ExportDeckCheck(&out, 3); err == nil {
+		t.Fatalf("Expecting an error for a player without a decklist.")
+	}
+}
@@ -0,0 +1,151 @@
+package discord
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/dstathis/swisstools"
+)
+
+// Bot answers the slash commands of Commands. Set its address as the interactions endpoint URL of
+// the Discord application.
+//
+// /result is entered with AddResult, so with TournamentConfig.ConfirmResults it waits for the
+// opponent to report the same result.
+type Bot struct {
+	PublicKey  ed25519.PublicKey // Public key of the Discord application.
+	Tournament *swisstools.SyncTournament
+}
+
+// Interaction and response types.
+const (
+	interactionPing    = 1
+	interactionCommand = 2
+	responsePong       = 1
+	responseMessage    = 4
+	flagEphemeral      = 64
+)
+
+type interaction struct {
+	Type   int `json:"type"`
+	Member *struct {
+		User user `json:"user"`
+	} `json:"member"`
+	User *user `json:"user"`
+	Data struct {
+		Name    string `json:"name"`
+		Options []struct {
+			Name  string          `json:"name"`
+			Value json.RawMessage `json:"value"`
+		} `json:"options"`
+	} `json:"data"`
+}
+
+type user struct {
+	Id string `json:"id"`
+}
+
+type response struct {
+	Type int           `json:"type"`
+	Data *responseData `json:"data,omitempty"`
+}
+
+type responseData struct {
+	Content string `json:"content"`
+	Flags   int    `json:"flags,omitempty"`
+}
+
+func (b *Bot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	signature, err := hex.DecodeString(r.Header.Get("X-Signature-Ed25519"))
+	if err != nil || !ed25519.Verify(b.PublicKey, append([]byte(r.Header.Get("X-Signature-Timestamp")), body...), signature) {
+		http.Error(w, "invalid request signature", http.StatusUnauthorized)
+		return
+	}
+	in := interaction{}
+	if err := json.Unmarshal(body, &in); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+	out := response{Type: responsePong}
+	if in.Type == interactionCommand {
+		out = response{Type: responseMessage, Data: &responseData{Content: b.command(in), Flags: flagEphemeral}}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+// command runs a slash command and returns the reply for the player.
+func (b *Bot) command(in interaction) string {
+	userId := ""
+	if in.Member != nil {
+		userId = in.Member.User.Id
+	} else if in.User != nil {
+		userId = in.User.Id
+	}
+	options := map[string]json.RawMessage{}
+	for _, option := range in.Data.Options {
+		options[option.Name] = option.Value
+	}
+	var reply string
+	err := b.Tournament.Update(func(t *swisstools.Tournament) error {
+		id, ok := player(t, userId)
+		if !ok {
+			return fmt.Errorf("you are not registered in this tournament")
+		}
+		switch in.Data.Name {
+		case "result":
+			var wins, losses, draws int
+			json.Unmarshal(options["wins"], &wins)
+			json.Unmarshal(options["losses"], &losses)
+			json.Unmarshal(options["draws"], &draws)
+			if err := t.AddResult(id, wins, losses, draws); err != nil {
+				return err
+			}
+			if pairing, err := t.GetPairingForPlayer(id); err == nil && !pairing.IsComplete() {
+				reply = fmt.Sprintf("Reported %d-%d-%d, waiting for your opponent to confirm.", wins, losses, draws)
+			} else {
+				reply = fmt.Sprintf("Recorded %d-%d-%d.", wins, losses, draws)
+			}
+		case "drop":
+			var confirm bool
+			json.Unmarshal(options["confirm"], &confirm)
+			if !confirm {
+				reply = "You have not been dropped."
+				return nil
+			}
+			if err := t.DropPlayer(id); err != nil {
+				return err
+			}
+			reply = "You have been dropped. Thanks for playing!"
+		default:
+			return fmt.Errorf("unknown command %s", in.Data.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return "Sorry, " + err.Error() + "."
+	}
+	return reply
+}
+
+// player finds the player linked to a Discord user with MetaKey.
+func player(t *swisstools.Tournament, userId string) (int, bool) {
+	if userId == "" {
+		return 0, false
+	}
+	for _, view := range t.PlayerViews() {
+		if view.Meta[MetaKey] == userId {
+			return view.Id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,145 @@
+// Package discord runs a swisstools tournament from a Discord server: it posts pairings and
+// standings to a channel and lets players report results and drop with slash commands.
+//
+// Players are linked to their Discord accounts with the player metadata key MetaKey, e.g.
+// t.SetPlayerMeta(id, discord.MetaKey, "80351110224678912").
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dstathis/swisstools"
+)
+
+const DefaultBaseURL = "https://discord.com/api/v10"
+
+// MetaKey is the player metadata key holding a player's Discord user id.
+const MetaKey = "discord_id"
+
+// messageLimit is the most characters Discord accepts in one message.
+const messageLimit = 2000
+
+type Client struct {
+	BaseURL    string // Defaults to DefaultBaseURL.
+	Token      string // Bot token.
+	HTTPClient *http.Client
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return DefaultBaseURL
+	}
+	return strings.TrimRight(c.BaseURL, "/")
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL()+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bot "+c.Token)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("discord: %s %s: %s", method, path, resp.Status)
+	}
+	return nil
+}
+
+// PostPairings posts the pairings of the current round to a channel.
+func (c *Client) PostPairings(ctx context.Context, channelId string, t *swisstools.Tournament) error {
+	var table strings.Builder
+	if err := t.FormatPairings(&table, swisstools.FormatOptions{}); err != nil {
+		return err
+	}
+	return c.post(ctx, channelId, fmt.Sprintf("**Pairings for round %d**", t.CurrentRoundNumber()), table.String())
+}
+
+// PostStandings posts the standings after the last completed round to a channel.
+func (c *Client) PostStandings(ctx context.Context, channelId string, t *swisstools.Tournament) error {
+	var table strings.Builder
+	if err := t.FormatStandings(&table, swisstools.FormatOptions{}); err != nil {
+		return err
+	}
+	return c.post(ctx, channelId, fmt.Sprintf("**Standings after round %d**", t.CurrentRoundNumber()-1), table.String())
+}
+
+// post sends a title and a table in code blocks, split over as many messages as needed.
+func (c *Client) post(ctx context.Context, channelId string, title string, table string) error {
+	const fence = "```"
+	message := title + "\n" + fence + "\n"
+	for _, line := range strings.SplitAfter(strings.TrimSuffix(table, "\n"), "\n") {
+		if len(message)+len(line)+len(fence) > messageLimit {
+			if err := c.send(ctx, channelId, message+fence); err != nil {
+				return err
+			}
+			message = fence + "\n"
+		}
+		message += line
+	}
+	return c.send(ctx, channelId, strings.TrimSuffix(message, "\n")+"\n"+fence)
+}
+
+func (c *Client) send(ctx context.Context, channelId string, content string) error {
+	return c.do(ctx, http.MethodPost, "/channels/"+url.PathEscape(channelId)+"/messages", map[string]string{"content": content})
+}
+
+// Commands are the slash commands handled by Bot.
+var Commands = []Command{
+	{Name: "result", Description: "Report the games you won, lost and drew in your current match.", Options: []CommandOption{
+		{Type: optionInteger, Name: "wins", Description: "Games you won", Required: true},
+		{Type: optionInteger, Name: "losses", Description: "Games you lost", Required: true},
+		{Type: optionInteger, Name: "draws", Description: "Games drawn"},
+	}},
+	{Name: "drop", Description: "Drop from the tournament.", Options: []CommandOption{
+		{Type: optionBoolean, Name: "confirm", Description: "Yes, I want to drop", Required: true},
+	}},
+}
+
+// Application command option types.
+const (
+	optionInteger = 4
+	optionBoolean = 5
+)
+
+type Command struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Options     []CommandOption `json:"options,omitempty"`
+}
+
+type CommandOption struct {
+	Type        int    `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// RegisterCommands installs Commands in a Discord server, replacing the application's other
+// commands there.
+func (c *Client) RegisterCommands(ctx context.Context, applicationId string, guildId string) error {
+	return c.do(ctx, http.MethodPut, "/applications/"+url.PathEscape(applicationId)+"/guilds/"+url.PathEscape(guildId)+"/commands", Commands)
+}
@@ -0,0 +1,110 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dstathis/swisstools"
+)
+
+func TestPostPairings(t *testing.T) {
+	messages := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/channels/42/messages" || r.Header.Get("Authorization") != "Bot secret" {
+			http.NotFound(w, r)
+			return
+		}
+		var message struct {
+			Content string `json:"content"`
+		}
+		json.NewDecoder(r.Body).Decode(&message)
+		messages = append(messages, message.Content)
+	}))
+	defer server.Close()
+
+	tournament := swisstools.NewTournament()
+	for i := 0; i < 60; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	tournament.Pair()
+	client := Client{BaseURL: server.URL, Token: "secret"}
+	if err := client.PostPairings(context.Background(), "42", &tournament); err != nil {
+		t.Fatalf("PostPairings returned an error: %s", err)
+	}
+	if len(messages) < 2 || !strings.HasPrefix(messages[0], "**Pairings for round 1**\n```\n") {
+		t.Fatalf("Expecting the pairings split over several messages, got %q.", messages)
+	}
+	for _, message := range messages {
+		if len(message) > messageLimit || !strings.HasSuffix(message, "\n```") {
+			t.Fatalf("Expecting messages in code blocks within the limit, got %q.", message)
+		}
+	}
+	if err := client.PostStandings(context.Background(), "7", &tournament); err == nil {
+		t.Fatalf("Expecting an error for an unknown channel.")
+	}
+}
+
+func TestBot(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(nil)
+	tournament := swisstools.NewTournamentWithConfig(swisstools.TournamentConfig{ConfirmResults: true})
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.SetPlayerMeta(1, MetaKey, "100")
+	tournament.SetPlayerMeta(2, MetaKey, "200")
+	tournament.Pair()
+	synced := swisstools.NewSyncTournament(tournament)
+	bot := &Bot{PublicKey: public, Tournament: synced}
+	send := func(body string, sign bool) (int, string) {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		signature := ed25519.Sign(private, []byte("1700000000"+body))
+		if !sign {
+			signature[0] ^= 1
+		}
+		req.Header.Set("X-Signature-Timestamp", "1700000000")
+		req.Header.Set("X-Signature-Ed25519", hex.EncodeToString(signature))
+		rec := httptest.NewRecorder()
+		bot.ServeHTTP(rec, req)
+		var out response
+		json.NewDecoder(bytes.NewReader(rec.Body.Bytes())).Decode(&out)
+		if out.Data == nil {
+			return rec.Code, ""
+		}
+		return rec.Code, out.Data.Content
+	}
+	command := func(user string, name string, options string) string {
+		return fmt.Sprintf(`{"type": 2, "member": {"user": {"id": %q}}, "data": {"name": %q, "options": [%s]}}`, user, name, options)
+	}
+
+	if code, _ := send(`{"type": 1}`, false); code != http.StatusUnauthorized {
+		t.Fatalf("Expecting a bad signature to be refused, got %d.", code)
+	}
+	if code, _ := send(`{"type": 1}`, true); code != http.StatusOK {
+		t.Fatalf("Expecting a pong, got %d.", code)
+	}
+	result := `{"name": "wins", "value": 2}, {"name": "losses", "value": 1}`
+	if _, reply := send(command("100", "result", result), true); !strings.Contains(reply, "waiting for your opponent") {
+		t.Fatalf("Expecting the result to wait for confirmation, got %q.", reply)
+	}
+	if _, reply := send(command("200", "result", `{"name": "wins", "value": 1}, {"name": "losses", "value": 2}`), true); reply != "Recorded 1-2-0." {
+		t.Fatalf("Expecting the confirmed result to be recorded, got %q.", reply)
+	}
+	if _, reply := send(command("300", "drop", `{"name": "confirm", "value": true}`), true); !strings.Contains(reply, "not registered") {
+		t.Fatalf("Expecting an unknown user to be refused, got %q.", reply)
+	}
+	send(command("200", "drop", `{"name": "confirm", "value": true}`), true)
+	synced.View(func(t *swisstools.Tournament) {
+		tournament = *t
+	})
+	if view, _ := tournament.GetPlayerView(2); !view.Dropped || !tournament.IsRoundComplete() {
+		t.Fatalf("Expecting the result recorded and Sam dropped, got %+v.", view)
+	}
+}
@@ -0,0 +1,111 @@
+package swisstools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Registration is what is known about a player entering the tournament. Rules only see what the
+// organizer provides, fields left empty are zero.
+type Registration struct {
+	Name       string
+	ExternalId string // Membership number or other id in an external system.
+	Rating     int
+	BirthDate  time.Time
+}
+
+// Rejection is the reason an eligibility rule refused a registration.
+type Rejection struct {
+	Rule   string // Short name of the rule, e.g. "rating_cap".
+	Reason string
+}
+
+// EligibilityRule checks whether a player may enter. It returns nil if they may.
+type EligibilityRule func(Registration) *Rejection
+
+// EligibilityError is returned when a registration is refused. It holds the reasons of every rule that
+// refused it.
+type EligibilityError struct {
+	Name       string
+	Rejections []Rejection
+}
+
+func (e *EligibilityError) Error() string {
+	reasons := []string{}
+	for _, rejection := range e.Rejections {
+		reasons = append(reasons, rejection.Reason)
+	}
+	return fmt.Sprintf("%s is not eligible: %s", e.Name, strings.Join(reasons, ", "))
+}
+
+// AddEligibilityRule adds a rule checked for every player registered from now on. Rules are not part
+// of a dump and have to be added again after LoadTournament.
+func (t *Tournament) AddEligibilityRule(rule EligibilityRule) {
+	t.eligibility = append(t.eligibility, rule)
+}
+
+// Register adds a player after checking them against the eligibility rules and links them to
+// registration.ExternalId if it is set.
+func (t *Tournament) Register(registration Registration) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	rejections := []Rejection{}
+	for _, rule := range t.eligibility {
+		if rejection := rule(registration); rejection != nil {
+			rejections = append(rejections, *rejection)
+		}
+	}
+	if len(rejections) > 0 {
+		return &EligibilityError{Name: registration.Name, Rejections: rejections}
+	}
+	if t.hasTeams() {
+		return ErrTeamTournament
+	}
+	if _, ok := t.externalIds[registration.ExternalId]; ok && registration.ExternalId != "" {
+		return ErrDuplicateExternalId
+	}
+	player := Player{name: registration.Name, externalId: registration.ExternalId, seed: registration.Rating, rating: Rating{Value: float64(registration.Rating)}}
+	return t.addPlayer(player, "add_player")
+}
+
+// RatingCap refuses players rated above max, as in rating capped events.
+func RatingCap(max int) EligibilityRule {
+	return func(registration Registration) *Rejection {
+		if registration.Rating > max {
+			return &Rejection{Rule: "rating_cap", Reason: fmt.Sprintf("rating %d is above %d", registration.Rating, max)}
+		}
+		return nil
+	}
+}
+
+// AgeDivision refuses players who are not between min and max years old, inclusive, on the given
+// date. A max of 0 means no upper limit.
+func AgeDivision(min int, max int, on time.Time) EligibilityRule {
+	return func(registration Registration) *Rejection {
+		if registration.BirthDate.IsZero() {
+			return &Rejection{Rule: "age_division", Reason: "birth date unknown"}
+		}
+		birth := registration.BirthDate
+		age := on.Year() - birth.Year()
+		if on.Month() < birth.Month() || (on.Month() == birth.Month() && on.Day() < birth.Day()) {
+			age--
+		}
+		if age < min || (max > 0 && age > max) {
+			return &Rejection{Rule: "age_division", Reason: fmt.Sprintf("age %d is outside the division", age)}
+		}
+		return nil
+	}
+}
+
+// MembershipRequired refuses players whose external id is missing or not a member according to
+// isMember, for example a lookup in a membership database.
+func MembershipRequired(isMember func(externalId string) bool) EligibilityRule {
+	return func(registration Registration) *Rejection {
+		if registration.ExternalId == "" || !isMember(registration.ExternalId) {
+			return &Rejection{Rule: "membership", Reason: "not a member"}
+		}
+		return nil
+	}
+}
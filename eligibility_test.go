@@ -0,0 +1,37 @@
+package swisstools
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEligibilityRules(t *testing.T) {
+	tournament := NewTournament()
+	members := map[string]bool{"M-1": true, "M-2": true}
+	tournament.AddEligibilityRule(RatingCap(1800))
+	tournament.AddEligibilityRule(AgeDivision(0, 17, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)))
+	tournament.AddEligibilityRule(MembershipRequired(func(externalId string) bool { return members[externalId] }))
+
+	err := tournament.Register(Registration{Name: "Dylan", ExternalId: "M-1", Rating: 1500, BirthDate: time.Date(2008, 6, 1, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Register returned an error: %s", err)
+	}
+	if id, _ := tournament.GetPlayerIDByExternalId("M-1"); id != 1 {
+		t.Fatalf("Expecting Dylan to be linked to M-1, got %d.", id)
+	}
+	err = tournament.Register(Registration{Name: "Sam", ExternalId: "M-3", Rating: 1900, BirthDate: time.Date(2006, 5, 31, 0, 0, 0, 0, time.UTC)})
+	var eligibility *EligibilityError
+	if !errors.As(err, &eligibility) || len(eligibility.Rejections) != 3 {
+		t.Fatalf("Expecting three rejections, got %v.", err)
+	}
+	if eligibility.Rejections[0].Rule != "rating_cap" || eligibility.Rejections[1].Rule != "age_division" || eligibility.Rejections[2].Rule != "membership" {
+		t.Fatalf("Expecting rating, age and membership rejections, got %+v.", eligibility.Rejections)
+	}
+	if err := tournament.AddPlayer("Alex"); err == nil {
+		t.Fatalf("Expecting AddPlayer to apply the rules.")
+	}
+	if len(tournament.players) != 1 {
+		t.Fatalf("Expecting rejected players not to be added, got %d players.", len(tournament.players))
+	}
+}
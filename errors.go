@@ -0,0 +1,96 @@
+package swisstools
+
+import "errors"
+
+// Errors returned by the tournament methods, to be checked with errors.Is. Errors that carry details,
+// such as RematchError, BracketDistanceError and EligibilityError, are types to be checked with
+// errors.As.
+var (
+	ErrPlayerNotFound       = errors.New("player not found")
+	ErrDuplicatePlayer      = errors.New("duplicate name")
+	ErrDuplicateExternalId  = errors.New("duplicate external id")
+	ErrEmptyName            = errors.New("empty name")
+	ErrPlayerDropped        = errors.New("player already dropped")
+	ErrPlayerDisqualified   = errors.New("player already disqualified")
+	ErrPlayerAlreadyPaired  = errors.New("player already paired")
+	ErrPlayersNotPaired     = errors.New("players not paired")
+	ErrRoundOutOfRange      = errors.New("round out of range")
+	ErrRoundNotPaired       = errors.New("round not paired")
+	ErrRoundAlreadyPaired   = errors.New("round already paired")
+	ErrPairingsNotPublished = errors.New("pairings not published")
+	ErrPairingsPublished    = errors.New("pairings already published")
+	ErrIncompleteMatch      = errors.New("round not complete")
+	ErrRoundLimitReached    = errors.New("round limit reached")
+	ErrRoundsNotConfigured  = errors.New("number of rounds not configured")
+	ErrNoValidPairing       = errors.New("no pairing satisfies the pairing constraints")
+	ErrByeResult            = errors.New("cannot report a bye")
+	ErrResultReported       = errors.New("match already reported")
+	ErrNegativeScore        = errors.New("negative score")
+	ErrNoGames              = errors.New("no games played")
+	ErrTooManyGames         = errors.New("too many games")
+	ErrResultLocked         = errors.New("result locked")
+	ErrReasonRequired       = errors.New("reason required")
+	ErrWinnerNotInPairing   = errors.New("winner not in pairing")
+	ErrFinalsNotStarted     = errors.New("finals not started")
+	ErrFinalsAlreadyStarted = errors.New("finals already started")
+	ErrFinalsFinished       = errors.New("finals finished")
+	ErrTournamentFinished   = errors.New("tournament finished")
+	ErrNothingToUndo        = errors.New("nothing to undo")
+	ErrNothingToRedo        = errors.New("nothing to redo")
+	ErrUnsupportedVersion   = errors.New("unsupported dump version")
+	ErrChecksumMismatch     = errors.New("dump checksum mismatch")
+	ErrTournamentNotFound   = errors.New("tournament not found")
+
+	// Players, teams and their registration details.
+	ErrAlreadyCheckedIn     = errors.New("player already checked in")
+	ErrEmptyNote            = errors.New("empty note")
+	ErrNoteNotFound         = errors.New("note not found")
+	ErrEmptyKey             = errors.New("empty key")
+	ErrNoDecklist           = errors.New("no decklist registered")
+	ErrTeamTournament       = errors.New("tournament has teams")
+	ErrIndividualTournament = errors.New("tournament has players")
+	ErrTeamTooSmall         = errors.New("team needs at least two members")
+	ErrAlreadyOnTeam        = errors.New("player already on a team")
+	ErrNotATeam             = errors.New("not a team")
+
+	// Pairing, seating and matches.
+	ErrInvalidConstraint  = errors.New("invalid pairing constraint")
+	ErrConstraintNotFound = errors.New("constraint not found")
+	ErrInvalidState       = errors.New("tournament state without tournament")
+	ErrInvalidTable       = errors.New("invalid table number")
+	ErrTableReserved      = errors.New("table already reserved")
+	ErrTableNotReserved   = errors.New("table not reserved")
+	ErrPlayerNotInPairing = errors.New("player not in pairing")
+	ErrGameOutOfRange     = errors.New("game number out of range")
+	ErrByeMatch           = errors.New("pairing is a bye")
+	ErrInvalidExtension   = errors.New("extension must be positive")
+	ErrTimerNotRunning    = errors.New("round timer not running")
+	ErrMatchStarted       = errors.New("match already started")
+
+	// Stages, finals and the end of the tournament.
+	ErrStageFinished            = errors.New("stage finished")
+	ErrStageNotFinished         = errors.New("stage not finished")
+	ErrNoNextStage              = errors.New("no next stage")
+	ErrInvalidFinalsSize        = errors.New("invalid finals size")
+	ErrUnknownFinalsFormat      = errors.New("unknown finals format")
+	ErrEliminationDraw          = errors.New("elimination match drawn")
+	ErrFinalsNotFinished        = errors.New("finals not finished")
+	ErrNoRoundsPlayed           = errors.New("no rounds played")
+	ErrTournamentNotFinished    = errors.New("tournament not finished")
+	ErrRoundLengthNotConfigured = errors.New("round length not configured")
+	ErrInvalidIterations        = errors.New("iterations must be positive")
+	ErrUnknownPreset            = errors.New("unknown preset")
+
+	// Combining, importing and storing tournaments.
+	ErrTooFewFlights        = errors.New("at least two flights needed")
+	ErrConfigurationsDiffer = errors.New("configurations differ")
+	ErrPlayersDiffer        = errors.New("players differ")
+	ErrPairingsDiffer       = errors.New("pairings differ")
+	ErrPatchMismatch        = errors.New("patch does not apply")
+	ErrEmptyImport          = errors.New("empty export")
+	ErrMissingColumn        = errors.New("missing column")
+	ErrInvalidScore         = errors.New("invalid score")
+	ErrDuplicateTournament  = errors.New("duplicate tournament id")
+	ErrInvalidId            = errors.New("invalid tournament id")
+	ErrEmptyURL             = errors.New("empty webhook url")
+)
@@ -0,0 +1,47 @@
+package swisstools
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	if err := tournament.AddPlayer("Dylan"); !errors.Is(err, ErrDuplicatePlayer) {
+		t.Fatalf("Expecting ErrDuplicatePlayer, got %v.", err)
+	}
+	if err := tournament.DropPlayer(7); !errors.Is(err, ErrPlayerNotFound) {
+		t.Fatalf("Expecting ErrPlayerNotFound, got %v.", err)
+	}
+	if err := tournament.VoidRound("misprint"); !errors.Is(err, ErrRoundNotPaired) {
+		t.Fatalf("Expecting ErrRoundNotPaired, got %v.", err)
+	}
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	if err := tournament.FinishTournament(); !errors.Is(err, ErrIncompleteMatch) {
+		t.Fatalf("Expecting ErrIncompleteMatch, got %v.", err)
+	}
+	err := tournament.AddResults([]Result{{Player: 1, Wins: -2}})
+	if !errors.Is(err, ErrNegativeScore) {
+		t.Fatalf("Expecting a wrapped ErrNegativeScore, got %v.", err)
+	}
+	if err := tournament.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Fatalf("Expecting ErrNothingToRedo, got %v.", err)
+	}
+	if err := tournament.AddPairingConstraint(1, 1, NeverPair); !errors.Is(err, ErrInvalidConstraint) {
+		t.Fatalf("Expecting a wrapped ErrInvalidConstraint, got %v.", err)
+	}
+	if err := tournament.ReleaseTable(3); !errors.Is(err, ErrTableNotReserved) {
+		t.Fatalf("Expecting ErrTableNotReserved, got %v.", err)
+	}
+	if err := tournament.StartFinals(); !errors.Is(err, ErrInvalidFinalsSize) {
+		t.Fatalf("Expecting ErrInvalidFinalsSize, got %v.", err)
+	}
+	if _, err := tournament.GetFinalStandings(); !errors.Is(err, ErrTournamentNotFinished) {
+		t.Fatalf("Expecting ErrTournamentNotFinished, got %v.", err)
+	}
+	if _, err := Preset("bridge"); !errors.Is(err, ErrUnknownPreset) {
+		t.Fatalf("Expecting ErrUnknownPreset, got %v.", err)
+	}
+}
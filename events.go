@@ -0,0 +1,37 @@
+package swisstools
+
+// EventType names something that happened in a tournament.
+type EventType string
+
+const (
+	PlayerAdded      EventType = "player_added"
+	PlayerDropped    EventType = "player_dropped"
+	RoundPaired      EventType = "round_paired" // On Pair, or PublishPairings with TournamentConfig.DraftPairings.
+	ResultRecorded   EventType = "result_recorded"
+	RoundCompleted   EventType = "round_completed"
+	StandingsUpdated EventType = "standings_updated"
+	// TournamentFinished follows the last Swiss round if there are no finals, or the last finals
+	// round otherwise. It is emitted by FinishTournament instead if the tournament ends early.
+	TournamentFinished EventType = "tournament_finished"
+)
+
+// Event is passed to the handlers registered with OnEvent.
+type Event struct {
+	Type     EventType
+	Round    int     // Round the event belongs to, 0 for events outside rounds such as PlayerAdded.
+	PlayerId int     // Player the event is about, 0 if none.
+	Pairing  Pairing // The match of a ResultRecorded event.
+}
+
+// OnEvent registers a handler called synchronously after every change of the tournament, for
+// integrations that react to changes without polling. Handlers are not part of a dump.
+func (t *Tournament) OnEvent(handler func(Event)) {
+	t.handlers = append(t.handlers, handler)
+}
+
+func (t *Tournament) emit(event Event) {
+	for _, handler := range t.handlers {
+		handler(event)
+	}
+	t.notifyWebhooks(event)
+}
@@ -0,0 +1,33 @@
+package swisstools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOnEvent(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Rounds: 1})
+	events := []EventType{}
+	results := 0
+	tournament.OnEvent(func(event Event) {
+		events = append(events, event.Type)
+		if event.Type == ResultRecorded && event.Round == 1 && event.Pairing.Winner() == event.PlayerId {
+			results++
+		}
+	})
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.AddPlayer("Alex")
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		if !pairing.IsBye() {
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+	}
+	tournament.DropPlayer(1)
+	tournament.NextRound()
+	expected := []EventType{PlayerAdded, PlayerAdded, PlayerAdded, RoundPaired, ResultRecorded, PlayerDropped, RoundCompleted, StandingsUpdated, TournamentFinished}
+	if !reflect.DeepEqual(events, expected) || results != 1 {
+		t.Fatalf("Expecting events %v, got %v.", expected, events)
+	}
+}
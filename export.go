@@ -0,0 +1,470 @@
+package swisstools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// exportVersion is the version of the dump format written by DumpTournament.
+const exportVersion = "2.0.0"
+
+// migration upgrades a decoded dump of version from to version to.
+type migration struct {
+	from    string
+	to      string
+	migrate func(dump map[string]json.RawMessage) error
+}
+
+// migrations upgrade older dumps step by step, oldest first. A change to the dump format bumps
+// exportVersion and adds a migration from the previous version.
+var migrations = []migration{
+	// Rounds and round times no longer start with an empty entry for round 0.
+	{from: "1.0.0", to: "2.0.0", migrate: func(dump map[string]json.RawMessage) error {
+		for _, key := range []string{"rounds", "round_times"} {
+			if dump[key] == nil {
+				continue
+			}
+			entries := []json.RawMessage{}
+			if err := json.Unmarshal(dump[key], &entries); err != nil {
+				return err
+			}
+			if len(entries) > 0 {
+				entries = entries[1:]
+			}
+			data, err := json.Marshal(entries)
+			if err != nil {
+				return err
+			}
+			dump[key] = data
+		}
+		return nil
+	}},
+}
+
+// decodeExport decodes a dump written by DumpTournament, upgrading it from older versions.
+func decodeExport(data []byte) (exportTournament, error) {
+	export := exportTournament{}
+	dump := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return export, err
+	}
+	version := ""
+	if dump["version"] != nil {
+		if err := json.Unmarshal(dump["version"], &version); err != nil {
+			return export, err
+		}
+	}
+	if version != exportVersion {
+		for _, m := range migrations {
+			if m.from != version {
+				continue
+			}
+			if err := m.migrate(dump); err != nil {
+				return export, fmt.Errorf("migrating from version %s: %w", version, err)
+			}
+			version = m.to
+		}
+		if version != exportVersion {
+			return export, ErrUnsupportedVersion
+		}
+		// The checksum was of the dump before migration.
+		delete(dump, "checksum")
+		dump["version"], _ = json.Marshal(version)
+		migrated, err := json.Marshal(dump)
+		if err != nil {
+			return export, err
+		}
+		data = migrated
+	}
+	err := json.Unmarshal(data, &export)
+	return export, err
+}
+
+type exportTournament struct {
+	Version      string              `json:"version"`
+	Config       TournamentConfig    `json:"config"`
+	LastId       int                 `json:"last_id"`
+	CurrentRound int                 `json:"current_round"`
+	Players      []exportPlayer      `json:"players"`
+	Rounds       [][]exportPairing   `json:"rounds"`
+	LastRanks    map[int]int         `json:"last_ranks,omitempty"`
+	Voided       []exportVoided      `json:"voided,omitempty"`
+	RoundTimes   []exportRoundTimes  `json:"round_times,omitempty"`
+	Finals       *exportFinals       `json:"finals,omitempty"`
+	Audit        []AuditEntry        `json:"audit,omitempty"`
+	Reservations map[int]int         `json:"table_reservations,omitempty"`
+	Constraints  []PairingConstraint `json:"pairing_constraints,omitempty"`
+	StageStarts  []int               `json:"stage_starts,omitempty"`
+	Final        []int               `json:"final_order,omitempty"`
+	// Standings after each completed round, see DumpTournamentWithStandings. They are not read back.
+	Standings [][]exportStanding `json:"standings,omitempty"`
+	// Checksum of the dump without it, see DumpOptions.
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// DumpOptions changes the output of DumpTournamentWithOptions. Players are always ordered by id and
+// map keys sorted, so dumps of the same state are identical.
+type DumpOptions struct {
+	// Indent writes one field per line, so that dumps kept in version control diff well.
+	Indent bool
+	// Checksum adds a checksum of the dump. LoadTournament refuses a dump whose checksum does not
+	// match with ErrChecksumMismatch, so that hand edits are noticed. Remove the checksum to load an
+	// edited dump anyway.
+	Checksum bool
+}
+
+type exportStanding struct {
+	Rank        int                    `json:"rank"`
+	PlayerId    int                    `json:"player_id"`
+	Name        string                 `json:"name"`
+	Points      int                    `json:"points"`
+	Record      string                 `json:"record"`
+	Dropped     bool                   `json:"dropped,omitempty"`
+	Tiebreakers map[Tiebreaker]float64 `json:"tiebreakers"`
+}
+
+type exportFinals struct {
+	Seeds        []int             `json:"seeds"`
+	Rounds       [][]exportPairing `json:"rounds"`
+	CurrentRound int               `json:"current_round"`
+}
+
+type exportRoundTimes struct {
+	Paired    time.Time  `json:"paired"`
+	Published *time.Time `json:"published,omitempty"`
+	Finished  time.Time  `json:"finished"`
+}
+
+type exportVoided struct {
+	Round    int             `json:"round"`
+	Reason   string          `json:"reason"`
+	Pairings []exportPairing `json:"pairings"`
+}
+
+type exportPlayer struct {
+	Id                 int               `json:"id"`
+	Name               string            `json:"name"`
+	ExternalId         string            `json:"external_id,omitempty"`
+	Phantom            bool              `json:"phantom,omitempty"`
+	Dropped            bool              `json:"dropped,omitempty"`
+	Disqualified       bool              `json:"disqualified,omitempty"`
+	DisqualifiedReason string            `json:"disqualified_reason,omitempty"`
+	Notes              []string          `json:"notes"`
+	Decklist           *Decklist         `json:"decklist,omitempty"`
+	Seed               int               `json:"seed,omitempty"`
+	Rating             *Rating           `json:"rating,omitempty"`
+	Members            []string          `json:"members,omitempty"`
+	Joined             int               `json:"joined,omitempty"`
+	CheckedIn          bool              `json:"checked_in,omitempty"`
+	Meta               map[string]string `json:"meta,omitempty"`
+}
+
+type exportPairing struct {
+	PlayerA     int               `json:"player_a"`
+	PlayerB     int               `json:"player_b"`
+	PlayerAWins int               `json:"player_a_wins"`
+	PlayerBWins int               `json:"player_b_wins"`
+	Draws       int               `json:"draws"`
+	Unfinished  int               `json:"unfinished,omitempty"`
+	DieRoll     int               `json:"die_roll,omitempty"`
+	White       int               `json:"white,omitempty"`
+	Play        int               `json:"play,omitempty"`
+	Table       int               `json:"table,omitempty"`
+	Intentional bool              `json:"intentional_draw,omitempty"`
+	ForfeitA    bool              `json:"forfeit_a,omitempty"`
+	ForfeitB    bool              `json:"forfeit_b,omitempty"`
+	Games       []exportGame      `json:"games,omitempty"`
+	Extensions  []exportExtension `json:"extensions,omitempty"`
+	Created     *time.Time        `json:"created,omitempty"`
+	Started     *time.Time        `json:"started,omitempty"`
+	Reported    *time.Time        `json:"reported,omitempty"`
+	Reports     []exportReport    `json:"reports,omitempty"`
+}
+
+type exportReport struct {
+	Player int  `json:"player"`
+	Wins   int  `json:"wins"`
+	Losses int  `json:"losses"`
+	Draws  int  `json:"draws"`
+	Judge  bool `json:"judge,omitempty"`
+}
+
+type exportExtension struct {
+	Duration time.Duration `json:"duration"`
+	Reason   string        `json:"reason"`
+}
+
+type exportGame struct {
+	First  int    `json:"first"`
+	Winner int    `json:"winner"`
+	Notes  string `json:"notes,omitempty"`
+}
+
+// DumpTournament serializes the tournament to JSON so that it can be restored with LoadTournament.
+func (t *Tournament) DumpTournament() ([]byte, error) {
+	return json.Marshal(t.export())
+}
+
+// DumpTournamentWithOptions serializes the tournament like DumpTournament, formatted as asked.
+func (t *Tournament) DumpTournamentWithOptions(opts DumpOptions) ([]byte, error) {
+	export := t.export()
+	if opts.Checksum {
+		checksum, err := export.checksum()
+		if err != nil {
+			return nil, err
+		}
+		export.Checksum = checksum
+	}
+	if opts.Indent {
+		return json.MarshalIndent(export, "", "  ")
+	}
+	return json.Marshal(export)
+}
+
+// DumpTournamentTo writes the tournament like DumpTournament, followed by a newline, without
+// holding the serialized dump in memory.
+func (t *Tournament) DumpTournamentTo(w io.Writer) error {
+	return json.NewEncoder(w).Encode(t.export())
+}
+
+// DumpTournamentWithStandings serializes the tournament like DumpTournament, adding the standings
+// after every completed round for historical display. LoadTournament ignores them.
+func (t *Tournament) DumpTournamentWithStandings() ([]byte, error) {
+	export := t.export()
+	for round := 1; round < t.currentRound; round++ {
+		export.Standings = append(export.Standings, exportStandings(t.standingsAfter(round)))
+	}
+	return json.Marshal(export)
+}
+
+func exportStandings(standings []PlayerStanding) []exportStanding {
+	exported := []exportStanding{}
+	for _, standing := range standings {
+		tiebreakers := map[Tiebreaker]float64{}
+		for _, tiebreaker := range allTiebreakers {
+			tiebreakers[tiebreaker] = standing.Tiebreakers.value(tiebreaker)
+		}
+		exported = append(exported, exportStanding{Rank: standing.Rank, PlayerId: standing.Id, Name: standing.Name, Points: standing.Points, Record: standing.Record, Dropped: standing.Dropped, Tiebreakers: tiebreakers})
+	}
+	return exported
+}
+
+func (t *Tournament) export() exportTournament {
+	export := exportTournament{
+		Version:      exportVersion,
+		Config:       t.config,
+		LastId:       t.lastId,
+		CurrentRound: t.currentRound,
+		Players:      []exportPlayer{},
+		Rounds:       [][]exportPairing{},
+		LastRanks:    t.lastRanks,
+		Audit:        t.audit,
+		Reservations: t.reservations,
+		StageStarts:  t.stageStarts,
+		Final:        t.final,
+	}
+	if len(t.constraints) > 0 {
+		export.Constraints = t.PairingConstraints()
+	}
+	for id, player := range t.players {
+		p := exportPlayer{Id: id, Name: player.name, ExternalId: player.externalId, Phantom: player.phantom, Dropped: player.dropped, Disqualified: player.disqualified, DisqualifiedReason: player.disqualifiedReason, Notes: player.notes, Decklist: player.decklist, Seed: player.seed, Members: player.members, Joined: player.joined, CheckedIn: player.checkedIn, Meta: player.meta}
+		if player.rating != (Rating{}) {
+			rating := player.rating
+			p.Rating = &rating
+		}
+		export.Players = append(export.Players, p)
+	}
+	sort.Slice(export.Players, func(i, j int) bool { return export.Players[i].Id < export.Players[j].Id })
+	for _, round := range t.rounds[1:] {
+		export.Rounds = append(export.Rounds, exportPairings(round))
+	}
+	for _, times := range t.times[1:] {
+		export.RoundTimes = append(export.RoundTimes, exportRoundTimes{Paired: times.paired, Published: exportTime(times.published), Finished: times.finished})
+	}
+	for _, voided := range t.voided {
+		export.Voided = append(export.Voided, exportVoided{Round: voided.Round, Reason: voided.Reason, Pairings: exportPairings(voided.Pairings)})
+	}
+	if t.finals != nil {
+		export.Finals = &exportFinals{Seeds: t.finals.seeds, Rounds: [][]exportPairing{}, CurrentRound: t.finals.currentRound}
+		for _, round := range t.finals.rounds {
+			export.Finals.Rounds = append(export.Finals.Rounds, exportPairings(round))
+		}
+	}
+	return export
+}
+
+// LoadTournamentFrom restores a tournament from a dump written to r by DumpTournamentTo or
+// DumpTournament.
+func LoadTournamentFrom(r io.Reader) (Tournament, error) {
+	var data json.RawMessage
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return Tournament{}, err
+	}
+	return LoadTournament(data)
+}
+
+// LoadTournament restores a tournament serialized by DumpTournament, including dumps written by
+// older versions of this package.
+func LoadTournament(data []byte) (Tournament, error) {
+	export, err := decodeExport(data)
+	if err != nil {
+		return Tournament{}, err
+	}
+	if export.Checksum != "" {
+		expected := export.Checksum
+		export.Checksum = ""
+		if checksum, err := export.checksum(); err != nil || checksum != expected {
+			return Tournament{}, ErrChecksumMismatch
+		}
+	}
+	t := NewTournamentWithConfig(export.Config)
+	t.lastId = export.LastId
+	t.currentRound = export.CurrentRound
+	t.lastRanks = export.LastRanks
+	t.audit = export.Audit
+	t.reservations = export.Reservations
+	t.stageStarts = export.StageStarts
+	t.final = export.Final
+	for _, constraint := range export.Constraints {
+		if t.constraints == nil {
+			t.constraints = map[[2]int]ConstraintPolicy{}
+		}
+		t.constraints[constraintKey(constraint.PlayerA, constraint.PlayerB)] = constraint.Policy
+	}
+	for _, p := range export.Players {
+		player := Player{name: p.Name, externalId: p.ExternalId, phantom: p.Phantom, dropped: p.Dropped, disqualified: p.Disqualified, disqualifiedReason: p.DisqualifiedReason, notes: p.Notes, decklist: p.Decklist, seed: p.Seed, members: p.Members, joined: p.Joined, checkedIn: p.CheckedIn, meta: p.Meta}
+		if p.Rating != nil {
+			player.rating = *p.Rating
+		}
+		if player.notes == nil {
+			player.notes = []string{}
+		}
+		t.players[p.Id] = player
+		t.nameIndex[nameKey(p.Name)] = p.Id
+		if p.ExternalId != "" {
+			t.externalIds[p.ExternalId] = p.Id
+		}
+	}
+	t.rounds = []Round{{}}
+	for _, pairings := range export.Rounds {
+		t.rounds = append(t.rounds, importPairings(pairings))
+	}
+	for r := 1; r < t.currentRound && r < len(t.rounds); r++ {
+		lock(t.rounds[r])
+	}
+	t.times = make([]roundTimes, len(t.rounds))
+	for i, times := range export.RoundTimes {
+		if i+1 < len(t.times) {
+			t.times[i+1] = roundTimes{paired: times.Paired, finished: times.Finished}
+			if times.Published != nil {
+				t.times[i+1].published = *times.Published
+			}
+		}
+	}
+	for _, voided := range export.Voided {
+		t.voided = append(t.voided, VoidedRound{Round: voided.Round, Reason: voided.Reason, Pairings: importPairings(voided.Pairings)})
+	}
+	if export.Finals != nil {
+		t.finals = &finals{seeds: export.Finals.Seeds, currentRound: export.Finals.CurrentRound}
+		for _, pairings := range export.Finals.Rounds {
+			t.finals.rounds = append(t.finals.rounds, importPairings(pairings))
+		}
+	}
+	if t.currentRound < 1 || t.currentRound >= len(t.rounds) {
+		return Tournament{}, fmt.Errorf("current round: %w", ErrRoundOutOfRange)
+	}
+	t.updatePlayerStandings()
+	return t, nil
+}
+
+func exportPairings(round Round) []exportPairing {
+	pairings := []exportPairing{}
+	for _, pairing := range round {
+		games := []exportGame{}
+		for _, game := range pairing.games {
+			games = append(games, exportGame{First: game.first, Winner: game.winner, Notes: game.notes})
+		}
+		reports := []exportReport{}
+		for _, report := range pairing.reports {
+			reports = append(reports, exportReport{Player: report.Result.Player, Wins: report.Result.Wins, Losses: report.Result.Losses, Draws: report.Result.Draws, Judge: report.Judge})
+		}
+		extensions := []exportExtension{}
+		for _, extension := range pairing.extensions {
+			extensions = append(extensions, exportExtension{Duration: extension.Duration, Reason: extension.Reason})
+		}
+		pairings = append(pairings, exportPairing{
+			PlayerA:     pairing.playera,
+			PlayerB:     pairing.playerb,
+			PlayerAWins: pairing.playeraWins,
+			PlayerBWins: pairing.playerbWins,
+			Draws:       pairing.draws,
+			Unfinished:  pairing.unfinished,
+			DieRoll:     pairing.dieRoll,
+			White:       pairing.white,
+			Play:        pairing.play,
+			Table:       pairing.table,
+			Intentional: pairing.intentionalDraw,
+			ForfeitA:    pairing.forfeitA,
+			ForfeitB:    pairing.forfeitB,
+			Games:       games,
+			Extensions:  extensions,
+			Created:     exportTime(pairing.created),
+			Started:     exportTime(pairing.started),
+			Reported:    exportTime(pairing.reported),
+			Reports:     reports,
+		})
+	}
+	return pairings
+}
+
+func importPairings(pairings []exportPairing) Round {
+	round := Round{}
+	for _, p := range pairings {
+		pairing := Pairing{
+			playera:         p.PlayerA,
+			playerb:         p.PlayerB,
+			playeraWins:     p.PlayerAWins,
+			playerbWins:     p.PlayerBWins,
+			draws:           p.Draws,
+			unfinished:      p.Unfinished,
+			dieRoll:         p.DieRoll,
+			white:           p.White,
+			play:            p.Play,
+			table:           p.Table,
+			intentionalDraw: p.Intentional,
+			forfeitA:        p.ForfeitA,
+			forfeitB:        p.ForfeitB,
+		}
+		if p.Created != nil {
+			pairing.created = *p.Created
+		}
+		if p.Started != nil {
+			pairing.started = *p.Started
+		}
+		for _, report := range p.Reports {
+			pairing.reports = append(pairing.reports, ResultReport{Result: Result{Player: report.Player, Wins: report.Wins, Losses: report.Losses, Draws: report.Draws}, Judge: report.Judge})
+		}
+		if p.Reported != nil {
+			pairing.reported = *p.Reported
+		}
+		for _, game := range p.Games {
+			pairing.games = append(pairing.games, Game{first: game.First, winner: game.Winner, notes: game.Notes})
+		}
+		for _, extension := range p.Extensions {
+			pairing.extensions = append(pairing.extensions, Extension{Duration: extension.Duration, Reason: extension.Reason})
+		}
+		round = append(round, pairing)
+	}
+	return round
+}
+
+// exportTime returns nil for the zero time so that unknown timestamps are left out of dumps.
+func exportTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,91 @@
+package swisstools
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoadVersion1(t *testing.T) {
+	data, err := os.ReadFile("testdata/tournament-1.0.0.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tournament, err := LoadTournament(data)
+	if err != nil {
+		t.Fatalf("LoadTournament returned an error: %s", err)
+	}
+	if tournament.CurrentRoundNumber() != 2 || len(tournament.rounds) != 3 || len(tournament.rounds[0]) != 0 {
+		t.Fatalf("Expecting round 2 of 2 with an empty round 0, got round %d of %d.", tournament.CurrentRoundNumber(), len(tournament.rounds)-1)
+	}
+	if pairing, _ := findPairingIn(tournament.rounds[1], 1); pairing.playera != 2 || pairing.Winner() != 2 || !pairing.IsLocked() {
+		t.Fatalf("Expecting Sam to have beaten Dylan in round 1, got %+v.", *pairing)
+	}
+	if paired := tournament.times[1].paired; !paired.Equal(time.Date(2024, 3, 9, 11, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Expecting round 1 to have been paired at 11:00, got %s.", paired)
+	}
+	if standings := tournament.GetStandings(); standings[0].Name != "Alex" || standings[0].Points != 3 || !standings[0].Dropped {
+		t.Fatalf("Expecting Alex first with a bye, got %+v.", standings)
+	}
+
+	dump, _ := tournament.DumpTournament()
+	if !bytes.HasPrefix(dump, []byte(`{"version":"`+exportVersion+`"`)) || !bytes.Contains(dump, []byte(`"rounds":[[{`)) {
+		t.Fatalf("Expecting the dump to be upgraded, got %s.", dump)
+	}
+	if _, _, err := MergeDumps(data, dump); err != nil {
+		t.Fatalf("MergeDumps returned an error: %s", err)
+	}
+	if _, err := LoadTournament([]byte(`{"version":"0.9.0"}`)); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("Expecting ErrUnsupportedVersion, got %v.", err)
+	}
+}
+
+func TestDumpTournamentTo(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	var buf bytes.Buffer
+	if err := tournament.DumpTournamentTo(&buf); err != nil {
+		t.Fatalf("DumpTournamentTo returned an error: %s", err)
+	}
+	dump, _ := tournament.DumpTournament()
+	if !bytes.Equal(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), dump) {
+		t.Fatalf("Expecting the same dump as DumpTournament, got %s.", buf.String())
+	}
+	loaded, err := LoadTournamentFrom(&buf)
+	if err != nil || !loaded.IsRoundComplete() {
+		t.Fatalf("Expecting the result to be restored, got %v.", err)
+	}
+	if _, err := LoadTournamentFrom(bytes.NewReader(nil)); err == nil {
+		t.Fatalf("Expecting an error for an empty reader.")
+	}
+}
+
+func TestDumpTournamentWithOptions(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.SetPlayerMeta(1, "pronouns", "they/them")
+	tournament.SetPlayerMeta(1, "dci", "123")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	data, err := tournament.DumpTournamentWithOptions(DumpOptions{Indent: true, Checksum: true})
+	if err != nil {
+		t.Fatalf("DumpTournamentWithOptions returned an error: %s", err)
+	}
+	again, _ := tournament.DumpTournamentWithOptions(DumpOptions{Indent: true, Checksum: true})
+	if !bytes.Equal(data, again) || !bytes.Contains(data, []byte("\n  \"checksum\": \"")) {
+		t.Fatalf("Expecting identical indented dumps with a checksum, got:\n%s", data)
+	}
+	if _, err := LoadTournament(data); err != nil {
+		t.Fatalf("LoadTournament returned an error: %s", err)
+	}
+	edited := bytes.Replace(data, []byte(`"Sam"`), []byte(`"Samuel"`), 1)
+	if _, err := LoadTournament(edited); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Expecting ErrChecksumMismatch for an edited dump, got %v.", err)
+	}
+}
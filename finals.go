@@ -0,0 +1,326 @@
+package swisstools
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// FinalsFormat is how the players who make the cut play for the title.
+type FinalsFormat string
+
+const (
+	// FinalsRoundRobin has every finalist play every other finalist once. The champion is decided by
+	// the finals standings.
+	FinalsRoundRobin FinalsFormat = "round_robin"
+	// FinalsSingleElimination is a knockout bracket. If the number of finalists is not a power of two
+	// the top seeds get a bye in the first round.
+	FinalsSingleElimination FinalsFormat = "single_elimination"
+)
+
+type FinalsConfig struct {
+	Size   int          `json:"size"` // Number of players making the cut.
+	Format FinalsFormat `json:"format"`
+	// Reseed re-pairs a single elimination bracket after every round so that the highest remaining
+	// seed plays the lowest. Otherwise the bracket is fixed, with 1 against 8 meeting the winner of 4
+	// against 5 and so on.
+	Reseed bool `json:"reseed"`
+}
+
+type finals struct {
+	seeds        []int // Finalists, best Swiss standing first.
+	rounds       []Round
+	currentRound int // Index into rounds.
+}
+
+// StartFinals takes the top TournamentConfig.Finals.Size players of the standings into the finals and
+// pairs the first finals round. Dropped players are passed over. The Swiss rounds are over once the
+// finals start, so a paired Swiss round has to be complete.
+func (t *Tournament) StartFinals() error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if t.finals != nil {
+		return ErrFinalsAlreadyStarted
+	}
+	size := t.config.Finals.Size
+	seeds := []int{}
+	for _, standing := range t.GetStandings() {
+		if !standing.Dropped && len(seeds) < size {
+			seeds = append(seeds, standing.Id)
+		}
+	}
+	if size < 2 || size > len(seeds) {
+		return ErrInvalidFinalsSize
+	}
+	if len(t.rounds[t.currentRound]) > 0 && !t.IsRoundComplete() {
+		return ErrIncompleteMatch
+	}
+	var rounds []Round
+	switch t.config.Finals.Format {
+	case FinalsRoundRobin:
+		rounds = roundRobin(seeds)
+	case FinalsSingleElimination:
+		rounds = []Round{bracket(seeds)}
+	default:
+		return ErrUnknownFinalsFormat
+	}
+	t.recordChange(t.snapshot())
+	t.finals = &finals{seeds: seeds, rounds: rounds}
+	stamp(t.finals.rounds[0], time.Now())
+	t.logChange("start_finals", t.currentRound, 0, "", fmt.Sprintf("%d players", size))
+	return nil
+}
+
+// roundRobin schedules every player against every other player once with the circle method.
+func roundRobin(seeds []int) []Round {
+	players := append([]int{}, seeds...)
+	if len(players)%2 == 1 {
+		players = append(players, byeId)
+	}
+	n := len(players)
+	rounds := []Round{}
+	for r := 0; r < n-1; r++ {
+		round := Round{}
+		for i := 0; i < n/2; i++ {
+			a, b := players[i], players[n-1-i]
+			switch {
+			case a == byeId:
+				round = append(round, Pairing{playera: b, playerb: byeId, playeraWins: 0, playerbWins: 0, draws: 0})
+			case b == byeId:
+				round = append(round, Pairing{playera: a, playerb: byeId, playeraWins: 0, playerbWins: 0, draws: 0})
+			default:
+				round = append(round, Pairing{playera: a, playerb: b, playeraWins: -1, playerbWins: -1, draws: -1})
+			}
+		}
+		rounds = append(rounds, round)
+		// Keep the first player fixed and rotate the rest.
+		players = append([]int{players[0], players[n-1]}, players[1:n-1]...)
+	}
+	return rounds
+}
+
+// bracket pairs the first round of a single elimination bracket in standard seeding order, so that the
+// top two seeds can only meet in the final.
+func bracket(seeds []int) Round {
+	size := 1
+	for size < len(seeds) {
+		size *= 2
+	}
+	order := []int{0}
+	for n := 1; n < size; n *= 2 {
+		next := []int{}
+		for _, seed := range order {
+			next = append(next, seed, 2*n-1-seed)
+		}
+		order = next
+	}
+	round := Round{}
+	for i := 0; i < size; i += 2 {
+		a, b := order[i], order[i+1]
+		if b >= len(seeds) {
+			round = append(round, Pairing{playera: seeds[a], playerb: byeId, playeraWins: 2, playerbWins: 0, draws: 0})
+		} else {
+			round = append(round, Pairing{playera: seeds[a], playerb: seeds[b], playeraWins: -1, playerbWins: -1, draws: -1})
+		}
+	}
+	return round
+}
+
+// nextBracketRound pairs the winners of a single elimination round. It returns an empty round once a
+// single player is left.
+func (t *Tournament) nextBracketRound(round Round) Round {
+	winners := []int{}
+	for _, pairing := range round {
+		winners = append(winners, pairing.Winner())
+	}
+	next := Round{}
+	if len(winners) < 2 {
+		return next
+	}
+	if t.config.Finals.Reseed {
+		seed := map[int]int{}
+		for i, id := range t.finals.seeds {
+			seed[id] = i
+		}
+		sort.Slice(winners, func(i, j int) bool { return seed[winners[i]] < seed[winners[j]] })
+		for i := 0; i < len(winners)/2; i++ {
+			next = append(next, Pairing{playera: winners[i], playerb: winners[len(winners)-1-i], playeraWins: -1, playerbWins: -1, draws: -1})
+		}
+		return next
+	}
+	for i := 0; i+1 < len(winners); i += 2 {
+		next = append(next, Pairing{playera: winners[i], playerb: winners[i+1], playeraWins: -1, playerbWins: -1, draws: -1})
+	}
+	return next
+}
+
+// GetFinalsRound returns the pairings of the current finals round.
+func (t *Tournament) GetFinalsRound() ([]Pairing, error) {
+	if t.finals == nil {
+		return nil, ErrFinalsNotStarted
+	}
+	if t.finals.currentRound >= len(t.finals.rounds) {
+		return []Pairing{}, nil
+	}
+	return t.finals.rounds[t.finals.currentRound], nil
+}
+
+// AddFinalsResult records the result of a player's match in the current finals round. A result which
+// was already reported is replaced with CorrectFinalsResult.
+func (t *Tournament) AddFinalsResult(id int, wins int, losses int, draws int) error {
+	return t.setFinalsResult(id, wins, losses, draws, false)
+}
+
+// CorrectFinalsResult replaces the reported result of a player's match in the current finals round.
+// The correction is recorded in the audit log with the old and the new result.
+func (t *Tournament) CorrectFinalsResult(id int, wins int, losses int, draws int) error {
+	return t.setFinalsResult(id, wins, losses, draws, true)
+}
+
+// setFinalsResult records a finals result, replacing a reported one only if correct is set.
+func (t *Tournament) setFinalsResult(id int, wins int, losses int, draws int, correct bool) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	round, err := t.GetFinalsRound()
+	if err != nil {
+		return err
+	}
+	pairing, err := findPairingIn(round, id)
+	if err != nil {
+		return err
+	}
+	if pairing.IsBye() {
+		return ErrByeResult
+	}
+	if pairing.IsComplete() && !correct {
+		return ErrResultReported
+	}
+	if err := t.validateScore(wins, losses, draws); err != nil {
+		return err
+	}
+	t.recordChange(t.snapshot())
+	before := describePairing(*pairing)
+	pairing.setResult(id, wins, losses, draws)
+	action := "finals_result"
+	if correct {
+		action = "correct_finals_result"
+	}
+	t.logChange(action, t.currentRound, id, before, describePairing(*pairing))
+	return nil
+}
+
+// NextFinalsRound moves on to the next finals round once every result of the current one is in.
+func (t *Tournament) NextFinalsRound() error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	round, err := t.GetFinalsRound()
+	if err != nil {
+		return err
+	}
+	if len(round) == 0 {
+		return ErrFinalsFinished
+	}
+	for _, pairing := range round {
+		if !pairing.IsComplete() {
+			return ErrIncompleteMatch
+		}
+		if t.config.Finals.Format == FinalsSingleElimination && pairing.Winner() == 0 {
+			return ErrEliminationDraw
+		}
+	}
+	t.recordChange(t.snapshot())
+	if t.config.Finals.Format == FinalsSingleElimination {
+		if next := t.nextBracketRound(round); len(next) > 0 {
+			t.finals.rounds = append(t.finals.rounds, next)
+		}
+	}
+	t.finals.currentRound++
+	if t.finals.currentRound < len(t.finals.rounds) {
+		stamp(t.finals.rounds[t.finals.currentRound], time.Now())
+	}
+	t.logChange("next_finals_round", t.currentRound, 0, "", fmt.Sprintf("finals round %d", t.finals.currentRound+1))
+	if t.finals.currentRound >= len(t.finals.rounds) {
+		t.emit(Event{Type: TournamentFinished})
+	}
+	return nil
+}
+
+// GetFinalsStandings ranks the finalists by points earned in completed finals matches, then by game win
+// percentage in the finals and then by Swiss seed. In a single elimination bracket this ranks players
+// by how far they got.
+func (t *Tournament) GetFinalsStandings() ([]PlayerStanding, error) {
+	if t.finals == nil {
+		return nil, ErrFinalsNotStarted
+	}
+	records := map[int]*playerRecord{}
+	seed := map[int]int{}
+	for i, id := range t.finals.seeds {
+		records[id] = &playerRecord{standing: PlayerStanding{Id: id, Name: t.players[id].name}}
+		seed[id] = i
+	}
+	for _, round := range t.finals.rounds {
+		for _, pairing := range round {
+			if pairing.IsBye() || !pairing.IsComplete() {
+				continue
+			}
+			t.recordFinalsMatch(records[pairing.playera], pairing.playeraWins, pairing.playerbWins, pairing.draws)
+			t.recordFinalsMatch(records[pairing.playerb], pairing.playerbWins, pairing.playeraWins, pairing.draws)
+		}
+	}
+	standings := []PlayerStanding{}
+	for _, id := range t.finals.seeds {
+		standing := records[id].standing
+		standing.Tiebreakers.GameWinPercentage = t.gameWinPercentage(records[id])
+		standings = append(standings, standing)
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		if standings[i].Tiebreakers.GameWinPercentage != standings[j].Tiebreakers.GameWinPercentage {
+			return standings[i].Tiebreakers.GameWinPercentage > standings[j].Tiebreakers.GameWinPercentage
+		}
+		return seed[standings[i].Id] < seed[standings[j].Id]
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings, nil
+}
+
+func (t *Tournament) recordFinalsMatch(r *playerRecord, wins int, losses int, draws int) {
+	switch {
+	case wins > losses:
+		r.standing.Wins++
+		r.standing.Points += t.config.PointsWin
+	case wins < losses:
+		r.standing.Losses++
+		r.standing.Points += t.config.PointsLoss
+	default:
+		r.standing.Draws++
+		r.standing.Points += t.config.PointsDraw
+	}
+	r.matches++
+	r.gamePoints += wins*t.config.PointsWin + draws*t.config.PointsDraw + losses*t.config.PointsLoss
+	r.games += wins + losses + draws
+}
+
+// Champion returns the winner of the finals once every finals round has been played.
+func (t *Tournament) Champion() (int, error) {
+	round, err := t.GetFinalsRound()
+	if err != nil {
+		return 0, err
+	}
+	if len(round) != 0 {
+		return 0, ErrFinalsNotFinished
+	}
+	if t.config.Finals.Format == FinalsSingleElimination {
+		final := t.finals.rounds[len(t.finals.rounds)-1]
+		return final[0].Winner(), nil
+	}
+	standings, _ := t.GetFinalsStandings()
+	return standings[0].Id, nil
+}
@@ -0,0 +1,142 @@
+package swisstools
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRoundRobinFinals(t *testing.T) {
+	config, _ := Preset("mtg")
+	config.Finals = FinalsConfig{Size: 4, Format: FinalsRoundRobin}
+	tournament := NewTournamentWithConfig(config)
+	for i := 1; i <= 6; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	tournament.rounds[1] = Round{
+		{playera: 1, playerb: 2, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 3, playerb: 4, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 5, playerb: 6, playeraWins: 2, playerbWins: 1, draws: 0},
+	}
+	tournament.NextRound()
+	if err := tournament.StartFinals(); err != nil {
+		t.Fatalf("StartFinals returned an error: %s", err)
+	}
+	met := map[[2]int]bool{}
+	for round := 1; round <= 3; round++ {
+		pairings, _ := tournament.GetFinalsRound()
+		if len(pairings) != 2 {
+			t.Fatalf("Expecting 2 finals matches in round %d, got %d.", round, len(pairings))
+		}
+		for _, pairing := range pairings {
+			met[[2]int{pairing.playera, pairing.playerb}] = true
+			met[[2]int{pairing.playerb, pairing.playera}] = true
+			// The lower id always wins, so player 1 wins every match.
+			winner := pairing.playera
+			if pairing.playerb < winner {
+				winner = pairing.playerb
+			}
+			tournament.AddFinalsResult(winner, 2, 0, 0)
+		}
+		if _, err := tournament.Champion(); err == nil {
+			t.Fatal("Finals not finished but Champion did not return an error.")
+		}
+		if err := tournament.NextFinalsRound(); err != nil {
+			t.Fatalf("NextFinalsRound returned an error: %s", err)
+		}
+	}
+	if len(met) != 12 {
+		t.Fatalf("Expecting every finalist to meet every other finalist, got %v.", met)
+	}
+	champion, err := tournament.Champion()
+	if err != nil || champion != 1 {
+		t.Fatalf("Expecting player 1 to be champion, got %d (%v).", champion, err)
+	}
+	standings, _ := tournament.GetFinalsStandings()
+	if standings[0].Points != 9 || standings[3].Points != 0 {
+		t.Fatalf("Unexpected finals standings %+v.", standings)
+	}
+}
+
+func TestSingleEliminationReseed(t *testing.T) {
+	for _, reseed := range []bool{false, true} {
+		config, _ := Preset("mtg")
+		config.Finals = FinalsConfig{Size: 8, Format: FinalsSingleElimination, Reseed: reseed}
+		tournament := NewTournamentWithConfig(config)
+		tournament.finals = &finals{seeds: []int{1, 2, 3, 4, 5, 6, 7, 8}}
+		for i := 1; i <= 8; i++ {
+			tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+		}
+		tournament.finals.rounds = []Round{bracket(tournament.finals.seeds)}
+		quarterfinals, _ := tournament.GetFinalsRound()
+		if quarterfinals[0].playera != 1 || quarterfinals[0].playerb != 8 || quarterfinals[1].playera != 4 || quarterfinals[1].playerb != 5 {
+			t.Fatalf("Expecting 1 against 8 and 4 against 5 first, got %+v.", quarterfinals)
+		}
+		// Seeds 8 and 7 pull off upsets.
+		for _, winner := range []int{8, 4, 7, 3} {
+			tournament.AddFinalsResult(winner, 2, 1, 0)
+		}
+		tournament.NextFinalsRound()
+		semifinals, _ := tournament.GetFinalsRound()
+		if reseed && (semifinals[0].playera != 3 || semifinals[0].playerb != 8 || semifinals[1].playera != 4 || semifinals[1].playerb != 7) {
+			t.Fatalf("Expecting reseeded semifinals 3-8 and 4-7, got %+v.", semifinals)
+		}
+		if !reseed && (semifinals[0].playera != 8 || semifinals[0].playerb != 4 || semifinals[1].playera != 7 || semifinals[1].playerb != 3) {
+			t.Fatalf("Expecting fixed semifinals 8-4 and 7-3, got %+v.", semifinals)
+		}
+		tournament.AddFinalsResult(semifinals[0].playera, 2, 0, 0)
+		tournament.AddFinalsResult(semifinals[1].playera, 2, 0, 0)
+		tournament.NextFinalsRound()
+		final, _ := tournament.GetFinalsRound()
+		tournament.AddFinalsResult(final[0].playerb, 2, 0, 0)
+		tournament.NextFinalsRound()
+		if champion, err := tournament.Champion(); err != nil || champion != final[0].playerb {
+			t.Fatalf("Expecting player %d to be champion, got %d (%v).", final[0].playerb, champion, err)
+		}
+	}
+}
+
+func TestBracketByes(t *testing.T) {
+	round := bracket([]int{11, 12, 13, 14, 15, 16})
+	if len(round) != 4 || !round[0].IsBye() || round[0].playera != 11 || !round[2].IsBye() || round[2].playera != 12 {
+		t.Fatalf("Expecting byes for the top two seeds, got %+v.", round)
+	}
+}
+
+func TestStartFinalsChecks(t *testing.T) {
+	config, _ := Preset("mtg")
+	config.Finals = FinalsConfig{Size: 2, Format: FinalsSingleElimination}
+	tournament := NewTournamentWithConfig(config)
+	for i := 1; i <= 6; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	tournament.rounds[1] = Round{
+		{playera: 1, playerb: 2, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 3, playerb: 4, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 5, playerb: 6, playeraWins: -1, playerbWins: -1, draws: -1},
+	}
+	if err := tournament.StartFinals(); !errors.Is(err, ErrIncompleteMatch) {
+		t.Fatalf("Expecting ErrIncompleteMatch with the Swiss round unfinished, got %v.", err)
+	}
+	tournament.AddResult(5, 2, 1, 0)
+	tournament.NextRound()
+	tournament.DropPlayer(1)
+	if err := tournament.StartFinals(); err != nil {
+		t.Fatalf("StartFinals returned an error: %s", err)
+	}
+	if seeds := tournament.finals.seeds; len(seeds) != 2 || seeds[0] == 1 || seeds[1] == 1 {
+		t.Fatalf("Expecting the dropped player left out of the finals, got %v.", seeds)
+	}
+
+	final, _ := tournament.GetFinalsRound()
+	tournament.AddFinalsResult(final[0].playera, 2, 0, 0)
+	if err := tournament.AddFinalsResult(final[0].playerb, 2, 0, 0); !errors.Is(err, ErrResultReported) {
+		t.Fatalf("Expecting ErrResultReported, got %v.", err)
+	}
+	if err := tournament.CorrectFinalsResult(final[0].playerb, 2, 0, 0); err != nil {
+		t.Fatalf("CorrectFinalsResult returned an error: %s", err)
+	}
+	if final, _ := tournament.GetFinalsRound(); final[0].Winner() != final[0].playerb {
+		t.Fatalf("Expecting the corrected result, got %+v.", final[0])
+	}
+}
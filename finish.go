@@ -0,0 +1,104 @@
+package swisstools
+
+// FinishTournament ends the tournament once the last round is complete. A complete but unfinished
+// Swiss round is completed as with NextRound, and if the finals started they must be finished. The
+// final standings are frozen, see GetFinalStandings, and the tournament can no longer be changed
+// except by Undo, which reverts finishing and the completed round together.
+func (t *Tournament) FinishTournament() error {
+	if t.final != nil {
+		return ErrTournamentFinished
+	}
+	complete := false
+	if t.finals != nil {
+		if _, err := t.Champion(); err != nil {
+			return err
+		}
+	} else if len(t.rounds[t.currentRound]) > 0 {
+		if !t.IsRoundComplete() {
+			return ErrIncompleteMatch
+		}
+		if t.roundLimitReached() {
+			return ErrRoundLimitReached
+		}
+		complete = true
+	}
+	if t.currentRound == 1 && !complete {
+		return ErrNoRoundsPlayed
+	}
+	t.recordChange(t.snapshot())
+	if complete {
+		t.completeRound()
+	}
+	t.final = t.finalOrder()
+	t.logChange("finish_tournament", t.currentRound-1, 0, "", "")
+	// Otherwise TournamentFinished was emitted when the last round was completed.
+	if !t.finishedPlay() {
+		t.emit(Event{Type: TournamentFinished, Round: t.currentRound - 1})
+	}
+	return nil
+}
+
+// finishedPlay reports whether the last round was played: the last finals round, or the last Swiss
+// round if there are no finals.
+func (t *Tournament) finishedPlay() bool {
+	if t.finals != nil {
+		return t.finals.currentRound >= len(t.finals.rounds)
+	}
+	return t.config.Finals.Size == 0 && t.currentRound-1 == t.config.Rounds
+}
+
+// IsFinished returns whether FinishTournament was called.
+func (t *Tournament) IsFinished() bool {
+	return t.final != nil
+}
+
+// checkOpen returns an error once the tournament is finished, for methods which change it.
+func (t *Tournament) checkOpen() error {
+	if t.final != nil {
+		return ErrTournamentFinished
+	}
+	return nil
+}
+
+// finalOrder returns the player ids in final order: the finalists as placed in the finals, the
+// champion first, followed by everyone else as placed in the Swiss standings.
+func (t *Tournament) finalOrder() []int {
+	order := []int{}
+	placed := map[int]bool{}
+	if t.finals != nil {
+		champion, _ := t.Champion()
+		order = append(order, champion)
+		placed[champion] = true
+		standings, _ := t.GetFinalsStandings()
+		for _, standing := range standings {
+			if !placed[standing.Id] {
+				order = append(order, standing.Id)
+				placed[standing.Id] = true
+			}
+		}
+	}
+	for _, standing := range t.GetStandings() {
+		if !placed[standing.Id] {
+			order = append(order, standing.Id)
+		}
+	}
+	return order
+}
+
+// GetFinalStandings returns the standings frozen by FinishTournament, ranked by final placing with the
+// finalists first.
+func (t *Tournament) GetFinalStandings() ([]PlayerStanding, error) {
+	if t.final == nil {
+		return nil, ErrTournamentNotFinished
+	}
+	standings := []PlayerStanding{}
+	for i, id := range t.final {
+		standing, err := t.GetStandingForPlayer(id)
+		if err != nil {
+			continue
+		}
+		standing.Rank = i + 1
+		standings = append(standings, standing)
+	}
+	return standings, nil
+}
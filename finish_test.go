@@ -0,0 +1,92 @@
+package swisstools
+
+import "testing"
+
+func TestFinishTournament(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Finals: FinalsConfig{Size: 2, Format: FinalsSingleElimination}})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	if err := tournament.FinishTournament(); err == nil {
+		t.Fatalf("Expecting an error finishing a tournament without rounds.")
+	}
+	tournament.Pair()
+	if err := tournament.FinishTournament(); err == nil {
+		t.Fatalf("Expecting an error finishing with an incomplete round.")
+	}
+	for _, pairing := range tournament.GetRound() {
+		tournament.AddResult(pairing.playera, 2, 0, 0)
+	}
+	tournament.NextRound()
+	tournament.StartFinals()
+	if err := tournament.FinishTournament(); err == nil {
+		t.Fatalf("Expecting an error finishing before the finals are over.")
+	}
+	final, _ := tournament.GetFinalsRound()
+	// The second seed wins the final.
+	tournament.AddFinalsResult(final[0].playerb, 2, 0, 0)
+	tournament.NextFinalsRound()
+	if err := tournament.FinishTournament(); err != nil {
+		t.Fatalf("FinishTournament returned an error: %s", err)
+	}
+	if !tournament.IsFinished() {
+		t.Fatalf("Expecting the tournament to be finished.")
+	}
+	if err := tournament.AddPlayer("Robin"); err == nil {
+		t.Fatalf("Expecting an error adding a player to a finished tournament.")
+	}
+	if err := tournament.Pair(); err == nil {
+		t.Fatalf("Expecting an error pairing a finished tournament.")
+	}
+	for name, change := range map[string]func() error{
+		"Reset":                tournament.Reset,
+		"RenamePlayer":         func() error { return tournament.RenamePlayer(1, "Robin") },
+		"SetDecklist":          func() error { return tournament.SetDecklist(1, Decklist{}) },
+		"AddPlayerNote":        func() error { return tournament.AddPlayerNote(1, "late") },
+		"SetPlayerMeta":        func() error { return tournament.SetPlayerMeta(1, "dci", "1") },
+		"ReserveTable":         func() error { return tournament.ReserveTable(1, 1) },
+		"AddPairingConstraint": func() error { return tournament.AddPairingConstraint(1, 2, NeverPair) },
+	} {
+		if err := change(); err != ErrTournamentFinished {
+			t.Fatalf("Expecting %s to refuse a finished tournament, got %v.", name, err)
+		}
+	}
+
+	data, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(data)
+	for _, tournament := range []Tournament{tournament, loaded} {
+		standings, err := tournament.GetFinalStandings()
+		if err != nil {
+			t.Fatalf("GetFinalStandings returned an error: %s", err)
+		}
+		if len(standings) != 4 || standings[0].Id != final[0].playerb || standings[1].Id != final[0].playera || standings[0].Rank != 1 {
+			t.Fatalf("Expecting the champion first and the runner-up second, got %+v.", standings)
+		}
+	}
+	if err := tournament.Undo(); err != nil || tournament.IsFinished() {
+		t.Fatalf("Expecting Undo to reopen the tournament, got %v.", err)
+	}
+}
+
+func TestFinishTournamentCompletesRound(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Rounds: 1})
+	finished := 0
+	tournament.OnEvent(func(event Event) {
+		if event.Type == TournamentFinished {
+			finished++
+		}
+	})
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	if err := tournament.FinishTournament(); err != nil {
+		t.Fatalf("FinishTournament returned an error: %s", err)
+	}
+	if finished != 1 {
+		t.Fatalf("Expecting TournamentFinished once, got %d.", finished)
+	}
+	if err := tournament.Undo(); err != nil || tournament.IsFinished() || tournament.currentRound != 1 || !tournament.IsRoundComplete() {
+		t.Fatalf("Expecting a single Undo to reopen round 1, got round %d, %v.", tournament.currentRound, err)
+	}
+}
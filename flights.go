@@ -0,0 +1,293 @@
+package swisstools
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// FlightSeeding decides how SplitIntoFlights distributes the players.
+type FlightSeeding string
+
+const (
+	// FlightsRandom deals the players to the flights at random.
+	FlightsRandom FlightSeeding = ""
+	// FlightsSnake deals the players to the flights in seed order, see SetSeed, reversing direction
+	// after every pass so that the flights are of equal strength: 1, 2, 3, 3, 2, 1 and so on.
+	FlightsSnake FlightSeeding = "snake"
+)
+
+// SplitIntoFlights divides the registered players into n flights before the first round, each a
+// tournament with the configuration of t to be run in parallel. Players keep their ids and details.
+// Dropped players and phantoms are left out. The flights can be combined again with MergeFlights.
+func (t *Tournament) SplitIntoFlights(n int, seeding FlightSeeding) ([]Tournament, error) {
+	if t.currentRound != 1 || len(t.rounds[1]) > 0 {
+		return nil, ErrRoundAlreadyPaired
+	}
+	players := []int{}
+	for _, id := range t.playerIdsByName() {
+		if player := t.players[id]; !player.dropped && !player.phantom {
+			players = append(players, id)
+		}
+	}
+	if n < 2 || n > len(players) {
+		return nil, fmt.Errorf("cannot split %d players into %d flights", len(players), n)
+	}
+	rand.Shuffle(len(players), func(i, j int) { players[i], players[j] = players[j], players[i] })
+	if seeding == FlightsSnake {
+		sort.SliceStable(players, func(i, j int) bool { return t.players[players[i]].seed > t.players[players[j]].seed })
+	}
+	flightOf := map[int]int{}
+	for i, id := range players {
+		flight := i % n
+		if seeding == FlightsSnake && (i/n)%2 == 1 {
+			flight = n - 1 - flight
+		}
+		flightOf[id] = flight
+	}
+
+	export := t.export()
+	flights := []Tournament{}
+	for flight := 0; flight < n; flight++ {
+		split := exportTournament{Version: exportVersion, Config: export.Config, LastId: export.LastId, CurrentRound: 1, Players: []exportPlayer{}, Rounds: [][]exportPairing{{}}}
+		for _, player := range export.Players {
+			if f, ok := flightOf[player.Id]; ok && f == flight {
+				split.Players = append(split.Players, player)
+			}
+		}
+		for _, constraint := range export.Constraints {
+			a, okA := flightOf[constraint.PlayerA]
+			b, okB := flightOf[constraint.PlayerB]
+			if okA && okB && a == flight && b == flight {
+				split.Constraints = append(split.Constraints, constraint)
+			}
+		}
+		for table, id := range export.Reservations {
+			if f, ok := flightOf[id]; ok && f == flight {
+				if split.Reservations == nil {
+					split.Reservations = map[int]int{}
+				}
+				split.Reservations[table] = id
+			}
+		}
+		data, err := json.Marshal(split)
+		if err != nil {
+			return nil, err
+		}
+		tournament, err := LoadTournament(data)
+		if err != nil {
+			return nil, err
+		}
+		tournament.logChange("split_flights", 1, 0, "", fmt.Sprintf("flight %d of %d", flight+1, n))
+		flights = append(flights, tournament)
+	}
+	return flights, nil
+}
+
+// exportFlights is the bundle written by DumpFlights.
+type exportFlights struct {
+	Version string            `json:"version"`
+	Flights []json.RawMessage `json:"flights"`
+}
+
+// DumpFlights serializes the flights of an event to JSON together, so that they can be restored
+// with LoadFlights and merged.
+func DumpFlights(flights []*Tournament) ([]byte, error) {
+	bundle := exportFlights{Version: exportVersion, Flights: []json.RawMessage{}}
+	for _, flight := range flights {
+		data, err := flight.DumpTournament()
+		if err != nil {
+			return nil, err
+		}
+		bundle.Flights = append(bundle.Flights, data)
+	}
+	return json.Marshal(bundle)
+}
+
+// LoadFlights restores the flights serialized by DumpFlights.
+func LoadFlights(data []byte) ([]Tournament, error) {
+	bundle := exportFlights{}
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, err
+	}
+	flights := []Tournament{}
+	for i, data := range bundle.Flights {
+		flight, err := LoadTournament(data)
+		if err != nil {
+			return nil, fmt.Errorf("flight %d: %w", i+1, err)
+		}
+		flights = append(flights, flight)
+	}
+	return flights, nil
+}
+
+// MergeFlights combines any number of flights into one tournament, merging them in order with
+// MergeTournaments.
+func MergeFlights(flights []*Tournament, policy FlightMergePolicy) (Tournament, error) {
+	if len(flights) < 2 {
+		return Tournament{}, ErrTooFewFlights
+	}
+	merged, err := MergeTournaments(flights[0], flights[1], policy)
+	for _, flight := range flights[2:] {
+		if err != nil {
+			break
+		}
+		merged, err = MergeTournaments(&merged, flight, policy)
+	}
+	return merged, err
+}
+
+// FlightMergePolicy decides what MergeTournaments does with a player registered in both flights.
+type FlightMergePolicy string
+
+const (
+	FlightMergeReject FlightMergePolicy = ""     // Refuse to merge, the default.
+	FlightMergeBest   FlightMergePolicy = "best" // Keep the flight the player scored more points in.
+)
+
+// MergeTournaments combines two flights of an event into one tournament to continue the Swiss rounds
+// in, for example for day 2. Both flights must use the same scoring and have their last round
+// completed. Players keep their points and opponents, and the merged tournament continues with the
+// round after the longest flight.
+//
+// Players are the same in both flights if they have the same external id. With FlightMergeBest the
+// entry in the other flight is kept as a disqualified player, so that it still counts for their
+// opponents' tiebreakers without appearing in the standings. Different players with the same name
+// have to be renamed before merging, as do table reservations for the same table.
+func MergeTournaments(a *Tournament, b *Tournament, policy FlightMergePolicy) (Tournament, error) {
+	for _, flight := range []*Tournament{a, b} {
+		if err := flight.checkOpen(); err != nil {
+			return Tournament{}, err
+		}
+		if flight.finals != nil {
+			return Tournament{}, ErrFinalsAlreadyStarted
+		}
+		if len(flight.rounds[flight.currentRound]) > 0 {
+			return Tournament{}, ErrRoundAlreadyPaired
+		}
+	}
+	if a.config.PointsWin != b.config.PointsWin || a.config.PointsDraw != b.config.PointsDraw || a.config.PointsLoss != b.config.PointsLoss {
+		return Tournament{}, fmt.Errorf("flights are scored differently: %w", ErrConfigurationsDiffer)
+	}
+	exports := [2]exportTournament{a.export(), b.export()}
+
+	// Pick the entry to keep of players in both flights.
+	duplicate := [2]map[int]bool{{}, {}}
+	for _, player := range exports[0].Players {
+		other, ok := b.externalIds[player.ExternalId]
+		if player.ExternalId == "" || !ok {
+			continue
+		}
+		if policy != FlightMergeBest {
+			return Tournament{}, fmt.Errorf("%s in both flights: %w", player.Name, ErrDuplicateExternalId)
+		}
+		if a.players[player.Id].points >= b.players[other].points {
+			duplicate[1][other] = true
+		} else {
+			duplicate[0][player.Id] = true
+		}
+	}
+
+	merged := exportTournament{Version: exportVersion, Config: a.config, Players: []exportPlayer{}, Rounds: [][]exportPairing{}}
+	names := map[string]bool{}
+	ids := [2]map[int]int{{}, {}}
+	for flight, export := range exports {
+		for _, player := range export.Players {
+			if duplicate[flight][player.Id] {
+				player.Name = fmt.Sprintf("%s (flight %d)", player.Name, flight+1)
+				player.ExternalId = ""
+				player.Dropped, player.Disqualified, player.DisqualifiedReason = true, true, "merged with the entry in the other flight"
+			}
+			if names[nameKey(player.Name)] {
+				return Tournament{}, fmt.Errorf("%s in both flights: %w", player.Name, ErrDuplicatePlayer)
+			}
+			names[nameKey(player.Name)] = true
+			merged.LastId++
+			ids[flight][player.Id] = merged.LastId
+			player.Id = merged.LastId
+			merged.Players = append(merged.Players, player)
+		}
+		for _, constraint := range export.Constraints {
+			constraint.PlayerA, constraint.PlayerB = ids[flight][constraint.PlayerA], ids[flight][constraint.PlayerB]
+			merged.Constraints = append(merged.Constraints, constraint)
+		}
+		for table, id := range export.Reservations {
+			if merged.Reservations == nil {
+				merged.Reservations = map[int]int{}
+			}
+			if _, ok := merged.Reservations[table]; ok {
+				return Tournament{}, fmt.Errorf("table %d in both flights: %w", table, ErrTableReserved)
+			}
+			merged.Reservations[table] = ids[flight][id]
+		}
+	}
+
+	// Each flight's last round is empty, so the merged tournament continues after the longest flight.
+	merged.CurrentRound = max(a.currentRound, b.currentRound)
+	for round := 0; round < merged.CurrentRound; round++ {
+		pairings := []exportPairing{}
+		times := exportRoundTimes{}
+		tables := 0
+		for flight, export := range exports {
+			if round >= len(export.Rounds) {
+				continue
+			}
+			// Number the tables of the second flight after those of the first.
+			offset := tables
+			for _, pairing := range export.Rounds[round] {
+				pairing = remapPairing(pairing, ids[flight])
+				if pairing.Table > 0 {
+					pairing.Table += offset
+					tables = max(tables, pairing.Table)
+				}
+				pairings = append(pairings, pairing)
+			}
+			// The round runs from the first flight pairing it until the last one finishing it.
+			flightTimes := export.RoundTimes[round]
+			if times.Paired.IsZero() || flightTimes.Paired.Before(times.Paired) {
+				times.Paired = flightTimes.Paired
+			}
+			if flightTimes.Finished.After(times.Finished) {
+				times.Finished = flightTimes.Finished
+			}
+		}
+		merged.Rounds = append(merged.Rounds, pairings)
+		merged.RoundTimes = append(merged.RoundTimes, times)
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return Tournament{}, err
+	}
+	t, err := LoadTournament(data)
+	if err != nil {
+		return Tournament{}, err
+	}
+	t.logChange("merge_flights", t.currentRound, 0, "", fmt.Sprintf("%d players", len(t.players)))
+	return t, nil
+}
+
+// remapPairing renumbers the players of a pairing with ids.
+func remapPairing(pairing exportPairing, ids map[int]int) exportPairing {
+	remap := func(id int) int {
+		if mapped, ok := ids[id]; ok {
+			return mapped
+		}
+		return id // Byes and unset ids.
+	}
+	pairing.PlayerA, pairing.PlayerB = remap(pairing.PlayerA), remap(pairing.PlayerB)
+	pairing.DieRoll, pairing.White, pairing.Play = remap(pairing.DieRoll), remap(pairing.White), remap(pairing.Play)
+	games := []exportGame{}
+	for _, game := range pairing.Games {
+		game.First, game.Winner = remap(game.First), remap(game.Winner)
+		games = append(games, game)
+	}
+	pairing.Games = games
+	reports := []exportReport{}
+	for _, report := range pairing.Reports {
+		report.Player = remap(report.Player)
+		reports = append(reports, report)
+	}
+	pairing.Reports = reports
+	return pairing
+}
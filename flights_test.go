@@ -0,0 +1,185 @@
+package swisstools
+
+import (
+	"errors"
+	"testing"
+)
+
+// playFlight returns a tournament of the named players after rounds rounds, the first player of
+// every pairing winning.
+func playFlight(names []string, rounds int) Tournament {
+	tournament := NewTournament()
+	for _, name := range names {
+		tournament.AddPlayer(name)
+	}
+	for round := 0; round < rounds; round++ {
+		tournament.Pair()
+		for _, pairing := range tournament.GetRound() {
+			if !pairing.IsBye() {
+				tournament.AddResult(pairing.playera, 2, 0, 0)
+			}
+		}
+		tournament.NextRound()
+	}
+	return tournament
+}
+
+func TestMergeTournaments(t *testing.T) {
+	a := playFlight([]string{"Dylan", "Sam", "Alex", "Kim"}, 2)
+	b := playFlight([]string{"Lee", "Max", "Ray", "Zoe"}, 2)
+	merged, err := MergeTournaments(&a, &b, FlightMergeReject)
+	if err != nil {
+		t.Fatalf("MergeTournaments returned an error: %s", err)
+	}
+	if merged.currentRound != 3 || len(merged.players) != 8 || len(merged.rounds[1]) != 4 {
+		t.Fatalf("Expecting 8 players starting round 3 with both flights' pairings, got round %d with %d players.", merged.currentRound, len(merged.players))
+	}
+	for _, name := range []string{"Dylan", "Zoe"} {
+		before := a
+		if name == "Zoe" {
+			before = b
+		}
+		old, _ := before.GetPlayerByName(name)
+		player, _ := merged.GetPlayerByName(name)
+		if player.points != old.points {
+			t.Fatalf("Expecting %s to keep %d points, got %d.", name, old.points, player.points)
+		}
+	}
+	for _, pairing := range b.rounds[1] {
+		if pairing.IsBye() {
+			continue
+		}
+		playera, _ := merged.GetPlayerID(b.players[pairing.playera].name)
+		playerb, _ := merged.GetPlayerID(b.players[pairing.playerb].name)
+		if !merged.havePlayedBefore(playera, playerb) {
+			t.Fatalf("Expecting the opponents of the second flight to carry over, got %+v.", merged.rounds[1])
+		}
+	}
+	if err := merged.Pair(); err != nil {
+		t.Fatalf("Pair returned an error: %s", err)
+	}
+}
+
+func TestMergeTournamentsDuplicates(t *testing.T) {
+	a := playFlight([]string{"Dylan", "Sam"}, 1)
+	b := playFlight([]string{"Dylan B", "Lee"}, 1)
+	a.SetExternalId(1, "dci-1")
+	b.SetExternalId(1, "dci-1")
+	if _, err := MergeTournaments(&a, &b, FlightMergeReject); !errors.Is(err, ErrDuplicateExternalId) {
+		t.Fatalf("Expecting ErrDuplicateExternalId, got %v.", err)
+	}
+	merged, err := MergeTournaments(&a, &b, FlightMergeBest)
+	if err != nil {
+		t.Fatalf("MergeTournaments returned an error: %s", err)
+	}
+	kept, _ := merged.GetPlayerIDByExternalId("dci-1")
+	standings := merged.GetStandings()
+	if len(standings) != 3 {
+		t.Fatalf("Expecting the other entry left out of the standings, got %+v.", standings)
+	}
+	for _, standing := range standings {
+		if standing.Id == kept && standing.Points != merged.players[kept].points {
+			t.Fatalf("Expecting the kept entry in the standings, got %+v.", standing)
+		}
+	}
+
+	c := playFlight([]string{"Sam", "Kim"}, 1)
+	if _, err := MergeTournaments(&a, &c, FlightMergeBest); !errors.Is(err, ErrDuplicatePlayer) {
+		t.Fatalf("Expecting ErrDuplicatePlayer for two players named Sam, got %v.", err)
+	}
+	c.Pair()
+	if _, err := MergeTournaments(&a, &c, FlightMergeBest); !errors.Is(err, ErrRoundAlreadyPaired) {
+		t.Fatalf("Expecting ErrRoundAlreadyPaired, got %v.", err)
+	}
+}
+
+func TestSplitIntoFlights(t *testing.T) {
+	tournament := NewTournament()
+	for i, name := range []string{"Alex", "Bo", "Cy", "Dee", "Eve", "Fay"} {
+		tournament.AddPlayer(name)
+		tournament.SetSeed(i+1, 6-i)
+	}
+	flights, err := tournament.SplitIntoFlights(2, FlightsSnake)
+	if err != nil {
+		t.Fatalf("SplitIntoFlights returned an error: %s", err)
+	}
+	// Seeds 6 and 3 and 2 go to the first flight, 5 and 4 and 1 to the second.
+	for flight, names := range [][]string{{"Alex", "Dee", "Eve"}, {"Bo", "Cy", "Fay"}} {
+		if len(flights[flight].players) != 3 {
+			t.Fatalf("Expecting 3 players in flight %d, got %d.", flight+1, len(flights[flight].players))
+		}
+		for _, name := range names {
+			id, err := flights[flight].GetPlayerID(name)
+			if original, _ := tournament.GetPlayerID(name); err != nil || id != original {
+				t.Fatalf("Expecting %s in flight %d with their id, got %d, %v.", name, flight+1, id, err)
+			}
+		}
+	}
+	if _, err := tournament.SplitIntoFlights(7, FlightsRandom); err == nil {
+		t.Fatal("Expecting an error for more flights than players.")
+	}
+
+	for i := range flights {
+		flights[i].Pair()
+		for _, pairing := range flights[i].GetRound() {
+			if !pairing.IsBye() {
+				flights[i].AddResult(pairing.playera, 2, 0, 0)
+			}
+		}
+		flights[i].NextRound()
+	}
+	data, err := DumpFlights([]*Tournament{&flights[0], &flights[1]})
+	if err != nil {
+		t.Fatalf("DumpFlights returned an error: %s", err)
+	}
+	loaded, err := LoadFlights(data)
+	if err != nil || len(loaded) != 2 {
+		t.Fatalf("Expecting two flights from LoadFlights, got %d, %v.", len(loaded), err)
+	}
+	merged, err := MergeFlights([]*Tournament{&loaded[0], &loaded[1]}, FlightMergeReject)
+	if err != nil {
+		t.Fatalf("MergeFlights returned an error: %s", err)
+	}
+	if len(merged.players) != 6 || merged.currentRound != 2 || len(merged.rounds[1]) != 4 {
+		t.Fatalf("Expecting the flights merged after round 1, got %d players in round %d.", len(merged.players), merged.currentRound)
+	}
+}
+
+func TestSplitIntoFlightsReservations(t *testing.T) {
+	tournament := NewTournament()
+	for i, name := range []string{"Alex", "Bo", "Cy", "Dee"} {
+		tournament.AddPlayer(name)
+		tournament.SetSeed(i+1, 4-i)
+	}
+	tournament.ReserveTable(5, 2)
+	flights, err := tournament.SplitIntoFlights(2, FlightsSnake)
+	if err != nil {
+		t.Fatalf("SplitIntoFlights returned an error: %s", err)
+	}
+	if reservations := flights[1].TableReservations(); len(reservations) != 1 || reservations[5] != 2 {
+		t.Fatalf("Expecting table 5 reserved for Bo in the second flight, got %v.", reservations)
+	}
+	if reservations := flights[0].TableReservations(); len(reservations) != 0 {
+		t.Fatalf("Expecting no reservations in the first flight, got %v.", reservations)
+	}
+}
+
+func TestMergeTournamentsReservations(t *testing.T) {
+	a := playFlight([]string{"Dylan", "Sam"}, 1)
+	b := playFlight([]string{"Lee", "Max"}, 1)
+	a.ReserveTable(5, 2)
+	b.ReserveTable(6, 1)
+	merged, err := MergeTournaments(&a, &b, FlightMergeReject)
+	if err != nil {
+		t.Fatalf("MergeTournaments returned an error: %s", err)
+	}
+	sam, _ := merged.GetPlayerID("Sam")
+	lee, _ := merged.GetPlayerID("Lee")
+	if reservations := merged.TableReservations(); len(reservations) != 2 || reservations[5] != sam || reservations[6] != lee {
+		t.Fatalf("Expecting table 5 reserved for Sam and 6 for Lee, got %v.", reservations)
+	}
+	b.ReserveTable(5, 2)
+	if _, err := MergeTournaments(&a, &b, FlightMergeReject); !errors.Is(err, ErrTableReserved) {
+		t.Fatalf("Expecting ErrTableReserved for table 5 in both flights, got %v.", err)
+	}
+}
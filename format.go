@@ -0,0 +1,101 @@
+package swisstools
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// OutputFormat selects how FormatPairings and FormatStandings render their tables.
+type OutputFormat string
+
+const (
+	FormatText     OutputFormat = "text" // Aligned text table, the default.
+	FormatMarkdown OutputFormat = "markdown"
+	FormatHTML     OutputFormat = "html" // A bare <table> element to embed in a page or print.
+)
+
+type FormatOptions struct {
+	Format OutputFormat
+	Round  int // Round to show the pairings of, the current round if 0.
+	// Dropped places dropped players in FormatStandings.
+	Dropped DroppedPlacement
+}
+
+// renderTable writes a table in the given format.
+func renderTable(w io.Writer, format OutputFormat, header []string, rows [][]string) error {
+	switch format {
+	case FormatMarkdown:
+		escape := func(cells []string) string {
+			escaped := []string{}
+			for _, cell := range cells {
+				escaped = append(escaped, strings.ReplaceAll(cell, "|", "\\|"))
+			}
+			return "| " + strings.Join(escaped, " | ") + " |\n"
+		}
+		separator := []string{}
+		for range header {
+			separator = append(separator, "---")
+		}
+		out := escape(header) + "| " + strings.Join(separator, " | ") + " |\n"
+		for _, row := range rows {
+			out += escape(row)
+		}
+		_, err := io.WriteString(w, out)
+		return err
+	case FormatHTML:
+		var out strings.Builder
+		out.WriteString("<table>\n<thead>\n<tr>")
+		for _, cell := range header {
+			out.WriteString("<th>" + html.EscapeString(cell) + "</th>")
+		}
+		out.WriteString("</tr>\n</thead>\n<tbody>\n")
+		for _, row := range rows {
+			out.WriteString("<tr>")
+			for _, cell := range row {
+				out.WriteString("<td>" + html.EscapeString(cell) + "</td>")
+			}
+			out.WriteString("</tr>\n")
+		}
+		out.WriteString("</tbody>\n</table>\n")
+		_, err := io.WriteString(w, out.String())
+		return err
+	case FormatText, "":
+		table := tablewriter.NewWriter(w)
+		table.SetHeader(header)
+		table.AppendBulk(rows)
+		table.Render()
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+// FormatPairings renders the pairings of a round by table, for posting them at the venue. Byes are
+// listed last.
+func (t *Tournament) FormatPairings(w io.Writer, opts FormatOptions) error {
+	round := opts.Round
+	if round == 0 {
+		round = t.currentRound
+	}
+	if round < 1 || round > t.currentRound {
+		return fmt.Errorf("round %d: %w", round, ErrRoundOutOfRange)
+	}
+	rows := [][]string{}
+	for _, pairing := range pairingsByTable(t.rounds[round]) {
+		a := t.players[pairing.playera]
+		table, opponent, points, result := "", "BYE", "", ""
+		if !pairing.IsBye() {
+			b := t.players[pairing.playerb]
+			table, opponent, points = strconv.Itoa(pairing.table), b.name, strconv.Itoa(b.points)
+		}
+		if pairing.IsComplete() && !pairing.IsBye() {
+			result = fmt.Sprintf("%d-%d-%d", pairing.playeraWins, pairing.playerbWins, pairing.draws)
+		}
+		rows = append(rows, []string{table, a.name, strconv.Itoa(a.points), opponent, points, result})
+	}
+	return renderTable(w, opts.Format, []string{"Table", "Player", "Points", "Opponent", "Points", "Result"}, rows)
+}
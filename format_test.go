@@ -0,0 +1,38 @@
+package swisstools
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormatPairings(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam <3")
+	tournament.AddPlayer("Alex|B")
+	tournament.Pair()
+	var buf bytes.Buffer
+	if err := tournament.FormatPairings(&buf, FormatOptions{Format: FormatMarkdown}); err != nil {
+		t.Fatalf("FormatPairings returned an error: %s", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || lines[0] != "| Table | Player | Points | Opponent | Points | Result |" || !strings.HasPrefix(lines[2], "| 1 |") || !strings.Contains(lines[3], "| BYE |") {
+		t.Fatalf("Expecting a markdown table with the bye last, got:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), "Alex|B") {
+		t.Fatalf("Expecting pipes to be escaped, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	tournament.FormatPairings(&buf, FormatOptions{Format: FormatHTML})
+	if !strings.HasPrefix(buf.String(), "<table>") || !strings.Contains(buf.String(), "Sam &lt;3") {
+		t.Fatalf("Expecting an escaped HTML table, got:\n%s", buf.String())
+	}
+	if err := tournament.FormatStandings(&buf, FormatOptions{Format: "pdf"}); err == nil {
+		t.Fatalf("Expecting an error for an unknown format.")
+	}
+	if err := tournament.FormatPairings(&buf, FormatOptions{Round: 2}); err == nil {
+		t.Fatalf("Expecting an error for a round that was not paired.")
+	}
+}
@@ -0,0 +1,73 @@
+package swisstools
+
+// AddGameResult records game number, counting from 1, of the current round match of player id. first
+// is the id of the player who played first and winner the id of the player who won the game, or 0 if
+// it was drawn. A new game must follow the games already recorded, while an existing number replaces
+// that game, for example to correct it. The match result is derived from the games recorded so far,
+// which must not exceed TournamentConfig.MaxGames, and entered as with AddResult, so that it waits
+// for confirmation with TournamentConfig.ConfirmResults. A result reported otherwise, for example
+// with AddResult or as a forfeit, is not replaced, see AddResults and Result.Override.
+func (t *Tournament) AddGameResult(id int, number int, first int, winner int, notes string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if err := t.checkPublished(); err != nil {
+		return err
+	}
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case pairing.IsBye():
+		return ErrByeResult
+	case pairing.IsComplete() && (len(pairing.games) == 0 || pairing.IsForfeit() || pairing.intentionalDraw):
+		return ErrResultReported
+	case first != pairing.playera && first != pairing.playerb:
+		return ErrPlayerNotInPairing
+	case winner != 0 && winner != pairing.playera && winner != pairing.playerb:
+		return ErrWinnerNotInPairing
+	case number < 1 || number > len(pairing.games)+1:
+		return ErrGameOutOfRange
+	}
+	game := Game{first: first, winner: winner, notes: notes}
+	games := append([]Game(nil), pairing.games...)
+	if number > len(games) {
+		games = append(games, game)
+	} else {
+		games[number-1] = game
+	}
+	wins, losses, draws := pairing.gameScore(games)
+	if id == pairing.playerb {
+		wins, losses = losses, wins
+	}
+	// A result derived from the earlier games is replaced.
+	result := Result{Player: id, Wins: wins, Losses: losses, Draws: draws, Override: pairing.IsComplete()}
+	if err := t.validateResult(*pairing, result); err != nil {
+		return err
+	}
+	t.recordChange(t.pairingSnapshot(t.currentRound, pairing))
+	pairing.games = games
+	t.enterResult(pairing, result)
+	return nil
+}
+
+// Games returns the games recorded for the match in the order they were played.
+func (p Pairing) Games() []Game {
+	return append([]Game{}, p.games...)
+}
+
+// First returns the id of the player who played first.
+func (g Game) First() int {
+	return g.first
+}
+
+// Winner returns the id of the player who won the game, or 0 if it was drawn.
+func (g Game) Winner() int {
+	return g.winner
+}
+
+// Notes returns the notes recorded with the game, such as a game loss penalty.
+func (g Game) Notes() string {
+	return g.notes
+}
@@ -0,0 +1,135 @@
+package swisstools
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestAddGameResult(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	pairing := tournament.GetRound()[0]
+	a, b := pairing.playera, pairing.playerb
+	if err := tournament.AddGameResult(a, 2, a, a, ""); err == nil {
+		t.Fatalf("Expecting an error recording game 2 before game 1.")
+	}
+	if err := tournament.AddGameResult(a, 1, a, 5, ""); err == nil {
+		t.Fatalf("Expecting an error for a winner outside of the pairing.")
+	}
+	tournament.AddGameResult(a, 1, a, a, "")
+	tournament.AddGameResult(b, 2, a, b, "game loss for tardiness")
+	tournament.AddGameResult(a, 3, b, 0, "")
+	if wins, losses, draws := tournament.GetRound()[0].Result(); wins != 1 || losses != 1 || draws != 1 {
+		t.Fatalf("Expecting a 1-1-1 result, got %d-%d-%d.", wins, losses, draws)
+	}
+	// Correct game 3.
+	if err := tournament.AddGameResult(a, 3, b, a, ""); err != nil {
+		t.Fatalf("AddGameResult returned an error: %s", err)
+	}
+	games := tournament.GetRound()[0].Games()
+	if len(games) != 3 || games[1].Notes() != "game loss for tardiness" || games[2].First() != b || games[2].Winner() != a {
+		t.Fatalf("Expecting three games with the corrected last game, got %+v.", games)
+	}
+	if wins, losses, draws := tournament.GetRound()[0].Result(); wins != 2 || losses != 1 || draws != 0 {
+		t.Fatalf("Expecting a 2-1-0 result, got %d-%d-%d.", wins, losses, draws)
+	}
+
+	data, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(data)
+	if games := loaded.GetRound()[0].Games(); len(games) != 3 || games[1].Notes() != "game loss for tardiness" {
+		t.Fatalf("Expecting the games to be kept in a dump, got %+v.", games)
+	}
+	out := bytes.Buffer{}
+	if err := tournament.ExportGamesCSV(&out); err != nil {
+		t.Fatalf("ExportGamesCSV returned an error: %s", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); len(lines) != 4 || !strings.HasSuffix(lines[2], "game loss for tardiness") {
+		t.Fatalf("Expecting a header and three games, got %q.", out.String())
+	}
+}
+
+func TestOverrideClearsGames(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	pairing := tournament.GetRound()[0]
+	a, b := pairing.playera, pairing.playerb
+	tournament.AddGameResult(a, 1, a, b, "")
+	tournament.AddGameResult(a, 2, b, b, "")
+	tournament.AddResults([]Result{{Player: b, Wins: 2, Override: true}})
+	if games := tournament.GetRound()[0].Games(); len(games) != 2 {
+		t.Fatalf("Expecting games matching the override to be kept, got %+v.", games)
+	}
+	tournament.AddResults([]Result{{Player: a, Wins: 2, Override: true}})
+	if games := tournament.GetRound()[0].Games(); len(games) != 0 {
+		t.Fatalf("Expecting the games contradicting the override to be dropped, got %+v.", games)
+	}
+}
+
+func TestAddGameResultValidation(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{MaxGames: 3})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	round := tournament.GetRound()
+	a, b := round[0].playera, round[0].playerb
+	tournament.AddResult(a, 2, 1, 0)
+	if err := tournament.AddGameResult(a, 1, a, b, ""); err != ErrResultReported {
+		t.Fatalf("Expecting ErrResultReported, got %v.", err)
+	}
+	if wins, losses, _ := tournament.GetRound()[0].Result(); wins != 2 || losses != 1 {
+		t.Fatalf("Expecting the reported result to be kept, got %d-%d.", wins, losses)
+	}
+	c := round[1].playera
+	for number := 1; number <= 3; number++ {
+		if err := tournament.AddGameResult(c, number, c, 0, ""); err != nil {
+			t.Fatalf("AddGameResult returned an error: %s", err)
+		}
+	}
+	if err := tournament.AddGameResult(c, 4, c, c, ""); err != ErrTooManyGames {
+		t.Fatalf("Expecting ErrTooManyGames, got %v.", err)
+	}
+	if games := tournament.GetRound()[1].Games(); len(games) != 3 {
+		t.Fatalf("Expecting the fourth game to be refused, got %+v.", games)
+	}
+}
+
+func TestAddGameResultConfirmation(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{ConfirmResults: true})
+	for _, name := range []string{"Dylan", "Sam", "Alex"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	var match, bye Pairing
+	current := func() Pairing {
+		pairing, _ := tournament.GetPairingForPlayer(match.playera)
+		return pairing
+	}
+	for _, pairing := range tournament.GetRound() {
+		if pairing.IsBye() {
+			bye = pairing
+		} else {
+			match = pairing
+		}
+	}
+	if err := tournament.AddGame(bye.playera, bye.playera); err != ErrByeResult {
+		t.Fatalf("Expecting ErrByeResult for a game in a bye, got %v.", err)
+	}
+	a, b := match.playera, match.playerb
+	tournament.AddGameResult(a, 1, a, a, "")
+	if pending := tournament.PendingResults(); len(pending) != 1 || current().IsComplete() {
+		t.Fatalf("Expecting the game result to wait for confirmation, got %+v.", pending)
+	}
+	tournament.AddResult(b, 0, 1, 0)
+	if winner := current().Winner(); winner != a || len(current().Games()) != 1 {
+		t.Fatalf("Expecting the confirmed result with its game, got %+v.", current())
+	}
+	if err := tournament.Undo(); err != nil || current().IsComplete() {
+		t.Fatalf("Expecting Undo to take back the confirmation, got %v.", err)
+	}
+}
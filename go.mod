@@ -2,6 +2,9 @@ module github.com/dstathis/swisstools
 
 go 1.21.6
 
-require github.com/olekukonko/tablewriter v0.0.5
+require (
+	github.com/olekukonko/tablewriter v0.0.5
+	golang.org/x/text v0.14.0
+)
 
 require github.com/mattn/go-runewidth v0.0.9 // indirect
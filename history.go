@@ -0,0 +1,101 @@
+package swisstools
+
+// MatchOutcome is the result of a match for one of its players.
+type MatchOutcome string
+
+const (
+	OutcomePending MatchOutcome = "" // Not reported yet.
+	OutcomeWin     MatchOutcome = "win"
+	OutcomeLoss    MatchOutcome = "loss"
+	OutcomeDraw    MatchOutcome = "draw"
+)
+
+// Opponent is a player's opponent in one round, as returned by GetOpponents.
+type Opponent struct {
+	Round   int
+	Id      int // -1 for a bye.
+	Name    string
+	Bye     bool
+	Outcome MatchOutcome
+}
+
+// MatchRecord is one of a player's matches from their side, as returned by GetMatchHistory.
+type MatchRecord struct {
+	Round      int
+	Table      int // 0 for a bye.
+	OpponentId int // -1 for a bye.
+	Opponent   string
+	Bye        bool
+	Side       Side // White or on the play, if sides are assigned.
+	Outcome    MatchOutcome
+	// Games won, lost and drawn by the player, -1 while the result has not been reported.
+	Wins            int
+	Losses          int
+	Draws           int
+	Forfeit         bool
+	IntentionalDraw bool
+}
+
+// GetMatchHistory returns every match of a player in round order, including an unreported match in
+// the current round.
+func (t *Tournament) GetMatchHistory(id int) ([]MatchRecord, error) {
+	if _, ok := t.players[id]; !ok {
+		return nil, ErrPlayerNotFound
+	}
+	history := []MatchRecord{}
+	for round := 1; round <= t.currentRound; round++ {
+		pairing, err := findPairingIn(t.rounds[round], id)
+		if err != nil {
+			continue
+		}
+		record := MatchRecord{
+			Round:           round,
+			Table:           pairing.table,
+			OpponentId:      byeId,
+			Bye:             pairing.IsBye(),
+			Side:            pairing.Side(id),
+			Outcome:         pairing.outcome(id),
+			Wins:            pairing.playeraWins,
+			Losses:          pairing.playerbWins,
+			Draws:           pairing.draws,
+			Forfeit:         pairing.IsForfeit(),
+			IntentionalDraw: pairing.intentionalDraw,
+		}
+		if pairing.playerb == id {
+			record.Wins, record.Losses = pairing.playerbWins, pairing.playeraWins
+		}
+		if !pairing.IsBye() {
+			record.OpponentId = pairing.playera + pairing.playerb - id
+			record.Opponent = t.players[record.OpponentId].name
+		}
+		history = append(history, record)
+	}
+	return history, nil
+}
+
+// GetOpponents returns a player's opponents in round order, with byes marked.
+func (t *Tournament) GetOpponents(id int) ([]Opponent, error) {
+	history, err := t.GetMatchHistory(id)
+	if err != nil {
+		return nil, err
+	}
+	opponents := []Opponent{}
+	for _, match := range history {
+		opponents = append(opponents, Opponent{Round: match.Round, Id: match.OpponentId, Name: match.Opponent, Bye: match.Bye, Outcome: match.Outcome})
+	}
+	return opponents, nil
+}
+
+// outcome returns the result of the match for player id.
+func (p Pairing) outcome(id int) MatchOutcome {
+	switch p.Winner() {
+	case id:
+		return OutcomeWin
+	case 0:
+		if p.IsComplete() {
+			return OutcomeDraw
+		}
+		return OutcomePending
+	}
+	return OutcomeLoss
+}
@@ -0,0 +1,38 @@
+package swisstools
+
+import "testing"
+
+func TestMatchHistory(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.AssignBye(1, 1)
+	tournament.Pair()
+	tournament.AddResult(2, 2, 1, 0)
+	tournament.NextRound()
+	tournament.Pair()
+	pairing, _ := tournament.findPairing(1)
+	if pairing.IsBye() {
+		t.Fatalf("Expecting Dylan to play in round 2, got %+v.", pairing)
+	}
+	tournament.AddResult(1, 0, 2, 1)
+
+	history, err := tournament.GetMatchHistory(1)
+	if err != nil {
+		t.Fatalf("GetMatchHistory returned an error: %s", err)
+	}
+	if len(history) != 2 || !history[0].Bye || history[0].OpponentId != byeId || history[0].Outcome != OutcomeWin {
+		t.Fatalf("Expecting a bye in round 1, got %+v.", history)
+	}
+	if match := history[1]; match.Round != 2 || match.Wins != 0 || match.Losses != 2 || match.Draws != 1 || match.Outcome != OutcomeLoss || match.Table != pairing.table {
+		t.Fatalf("Expecting a 0-2-1 loss in round 2, got %+v.", match)
+	}
+	opponents, _ := tournament.GetOpponents(2)
+	if len(opponents) != 2 || opponents[0].Name != "Alex" || opponents[0].Outcome != OutcomeWin || opponents[1].Id != 1 {
+		t.Fatalf("Expecting Sam to have beaten Alex and Dylan, got %+v.", opponents)
+	}
+	if _, err := tournament.GetOpponents(9); err == nil {
+		t.Fatalf("Expecting an error for an unknown player.")
+	}
+}
@@ -0,0 +1,155 @@
+package swisstools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Importer reads a tournament run with other software, so that an event can be continued with this
+// library mid-way. Results of the last imported round are left open if some are missing.
+type Importer interface {
+	Import(r io.Reader, config TournamentConfig) (Tournament, error)
+}
+
+// importRounds installs imported rounds 1 to last. Every round but the last is completed, and the
+// last one as well if all its results are in, so that the next round can be paired.
+func (t *Tournament) importRounds(rounds map[int]Round, last int) {
+	for number := 1; number <= last; number++ {
+		t.rounds[t.currentRound] = rounds[number]
+		if number < last || t.IsRoundComplete() {
+			t.NextRound()
+		}
+	}
+}
+
+// ChallongeImporter imports a Swiss tournament from the Challonge API, as returned by
+// GET /tournaments/{id}.json with include_participants and include_matches set. Participants are
+// registered under their display names, with their Challonge id as the "challonge_id" player metadata.
+// Challonge does not record byes, so a single participant without a match in a round is given one.
+type ChallongeImporter struct{}
+
+type challongeExport struct {
+	Tournament struct {
+		Participants []struct {
+			Participant challongeParticipant `json:"participant"`
+		} `json:"participants"`
+		Matches []struct {
+			Match challongeMatch `json:"match"`
+		} `json:"matches"`
+	} `json:"tournament"`
+}
+
+type challongeParticipant struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Active      bool   `json:"active"`
+}
+
+type challongeMatch struct {
+	Round     int    `json:"round"`
+	State     string `json:"state"`
+	Player1   int    `json:"player1_id"`
+	Player2   int    `json:"player2_id"`
+	Winner    int    `json:"winner_id"`
+	ScoresCsv string `json:"scores_csv"`
+}
+
+func (ChallongeImporter) Import(r io.Reader, config TournamentConfig) (Tournament, error) {
+	export := challongeExport{}
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return Tournament{}, err
+	}
+	t := NewTournamentWithConfig(config)
+	ids := map[int]int{}
+	for _, entry := range export.Tournament.Participants {
+		participant := entry.Participant
+		name := participant.DisplayName
+		if name == "" {
+			name = participant.Name
+		}
+		if err := t.AddPlayer(name); err != nil {
+			return Tournament{}, err
+		}
+		ids[participant.Id] = t.lastId
+		t.SetPlayerMeta(t.lastId, "challonge_id", strconv.Itoa(participant.Id))
+		if !participant.Active {
+			player := t.players[t.lastId]
+			player.dropped = true
+			t.players[t.lastId] = player
+		}
+	}
+	rounds := map[int]Round{}
+	last := 0
+	for _, entry := range export.Tournament.Matches {
+		match := entry.Match
+		a, okA := ids[match.Player1]
+		b, okB := ids[match.Player2]
+		if match.Round < 1 {
+			return Tournament{}, ErrRoundOutOfRange
+		}
+		if !okA || !okB {
+			return Tournament{}, fmt.Errorf("match with unknown participant: %w", ErrPlayerNotFound)
+		}
+		pairing := Pairing{playera: a, playerb: b, playeraWins: -1, playerbWins: -1, draws: -1}
+		if match.State == "complete" {
+			wins, losses, err := challongeScores(match.ScoresCsv)
+			if err != nil {
+				return Tournament{}, err
+			}
+			switch {
+			case match.Winner == match.Player1 && wins <= losses:
+				wins, losses = 1, 0
+			case match.Winner == match.Player2 && losses <= wins:
+				wins, losses = 0, 1
+			}
+			pairing.playeraWins, pairing.playerbWins, pairing.draws = wins, losses, 0
+			if wins+losses == 0 {
+				pairing.draws = 1
+			}
+		}
+		rounds[match.Round] = append(rounds[match.Round], pairing)
+		if match.Round > last {
+			last = match.Round
+		}
+	}
+	for number := 1; number <= last; number++ {
+		unpaired := []int{}
+		for _, id := range ids {
+			if _, err := findPairingIn(rounds[number], id); err != nil && !t.players[id].dropped {
+				unpaired = append(unpaired, id)
+			}
+		}
+		if len(unpaired) == 1 {
+			rounds[number] = append(rounds[number], Pairing{playera: unpaired[0], playerb: byeId, playeraWins: 2, playerbWins: 0, draws: 0})
+		}
+	}
+	t.importRounds(rounds, last)
+	return t, nil
+}
+
+// challongeScores sums the games won by each player over the comma separated sets of a Challonge
+// score such as "2-1".
+func challongeScores(scores string) (int, int, error) {
+	wins, losses := 0, 0
+	if scores == "" {
+		return 0, 0, nil
+	}
+	for _, set := range strings.Split(scores, ",") {
+		// A leading minus sign is a negative score, which is not supported.
+		parts := strings.SplitN(set, "-", 2)
+		if len(parts) != 2 {
+			return 0, 0, fmt.Errorf("%w %s", ErrInvalidScore, set)
+		}
+		a, errA := strconv.Atoi(strings.TrimSpace(parts[0]))
+		b, errB := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if errA != nil || errB != nil || a < 0 || b < 0 {
+			return 0, 0, fmt.Errorf("%w %s", ErrInvalidScore, set)
+		}
+		wins, losses = wins+a, losses+b
+	}
+	return wins, losses, nil
+}
@@ -0,0 +1,49 @@
+package swisstools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChallongeImporter(t *testing.T) {
+	export := `{"tournament": {"name": "Weekly", "tournament_type": "swiss",
+  "participants": [
+    {"participant": {"id": 11, "name": "Dylan", "display_name": "Dylan", "active": true}},
+    {"participant": {"id": 12, "name": "Sam", "display_name": "Sam", "active": true}},
+    {"participant": {"id": 13, "name": "Alex", "display_name": "Alex", "active": true}}
+  ],
+  "matches": [
+    {"match": {"round": 1, "state": "complete", "player1_id": 11, "player2_id": 12, "winner_id": 11, "scores_csv": "2-1"}},
+    {"match": {"round": 2, "state": "open", "player1_id": 11, "player2_id": 13, "winner_id": null, "scores_csv": ""}}
+  ]}}`
+	var importer Importer = ChallongeImporter{}
+	tournament, err := importer.Import(strings.NewReader(export), TournamentConfig{})
+	if err != nil {
+		t.Fatalf("Import returned an error: %s", err)
+	}
+	if tournament.CurrentRoundNumber() != 2 {
+		t.Fatalf("Expecting to continue in round 2, got %d.", tournament.CurrentRoundNumber())
+	}
+	meta, _ := tournament.GetPlayerMeta(3)
+	if meta["challonge_id"] != "13" {
+		t.Fatalf("Expecting Alex to have challonge_id 13, got %v.", meta)
+	}
+	standings := tournament.GetStandings()
+	if standings[0].Points != 3 || standings[1].Points != 3 || standings[2].Name != "Sam" {
+		t.Fatalf("Expecting Dylan and Alex (bye) on 3 points, got %+v.", standings)
+	}
+	if round := tournament.GetRound(); len(round) != 2 || !round[1].IsBye() || round[1].playera != 2 {
+		t.Fatalf("Expecting Sam to have a bye in round 2, got %+v.", round)
+	}
+	if err := tournament.AddResultByName("Alex", 2, 0, 0); err != nil {
+		t.Fatalf("AddResultByName returned an error: %s", err)
+	}
+
+	bad := `{"tournament": {"participants": [], "matches": [{"match": {"round": 1, "player1_id": 1, "player2_id": 2}}]}}`
+	if _, err := importer.Import(strings.NewReader(bad), TournamentConfig{}); err == nil {
+		t.Fatalf("Expecting an error for unknown participants.")
+	}
+	if _, _, err := challongeScores("2-x"); err == nil {
+		t.Fatalf("Expecting an error for an invalid score.")
+	}
+}
@@ -0,0 +1,32 @@
+package swisstools
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// KonamiTiebreaker formats a standing as the combined tiebreaker number printed on Konami standings:
+// two digits of points, three of opponents' win percentage and three of opponents' opponents' win
+// percentage, e.g. "09667556".
+func KonamiTiebreaker(standing PlayerStanding) string {
+	per := func(percentage float64) int {
+		return int(math.Min(999, math.Round(percentage*1000)))
+	}
+	return fmt.Sprintf("%02d%03d%03d", standing.Points, per(standing.Tiebreakers.OpponentMatchWinPercentage), per(standing.Tiebreakers.OpponentOpponentMatchWinPercentage))
+}
+
+// ExportKonamiStandings writes the standings as CSV for Konami event reporting, with each player's
+// external id as their Konami (COSSY) id.
+func (t *Tournament) ExportKonamiStandings(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"Rank", "Name", "KonamiId", "Points", "Record", "Tiebreaker"})
+	for _, standing := range t.GetStandings() {
+		record := fmt.Sprintf("%d-%d-%d", standing.Wins, standing.Losses, standing.Draws)
+		writer.Write([]string{strconv.Itoa(standing.Rank), standing.Name, t.players[standing.Id].externalId, strconv.Itoa(standing.Points), record, KonamiTiebreaker(standing)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
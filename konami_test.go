@@ -0,0 +1,31 @@
+package swisstools
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestKonamiStandings(t *testing.T) {
+	config, _ := Preset("yugioh")
+	tournament := NewTournamentWithConfig(config)
+	tournament.AddPlayer("Yugi")
+	tournament.AddPlayer("Kaiba")
+	tournament.SetExternalId(1, "0012345678")
+	tournament.rounds[1] = Round{{playera: 1, playerb: 2, playeraWins: 2, playerbWins: 1, draws: 0}}
+	tournament.NextRound()
+	standings := tournament.GetStandings()
+	if code := KonamiTiebreaker(standings[0]); code != "03000999" {
+		t.Fatalf("Expecting tiebreaker 03000999 for Yugi, got %s.", code)
+	}
+	if code := KonamiTiebreaker(standings[1]); code != "00999000" {
+		t.Fatalf("Expecting tiebreaker 00999000 for Kaiba, got %s.", code)
+	}
+	var buf bytes.Buffer
+	if err := tournament.ExportKonamiStandings(&buf); err != nil {
+		t.Fatalf("ExportKonamiStandings returned an error: %s", err)
+	}
+	if !strings.Contains(buf.String(), "1,Yugi,0012345678,3,1-0-0,03000999") {
+		t.Fatalf("Unexpected export:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,69 @@
+package swisstools
+
+// LateEntryPolicy decides how players who register after the first round is paired are scored for
+// the rounds they missed.
+type LateEntryPolicy string
+
+const (
+	// LateEntryZero lets late players join on 0 points. Missed rounds count as unplayed for tiebreakers.
+	LateEntryZero LateEntryPolicy = ""
+	// LateEntryByes awards a bye for every missed round.
+	LateEntryByes LateEntryPolicy = "byes"
+	// LateEntryLosses records a loss for every missed round, without games.
+	LateEntryLosses LateEntryPolicy = "losses"
+	// LateEntryAverage awards the average points of the field, rounded down, after the last round the
+	// player missed.
+	LateEntryAverage LateEntryPolicy = "average"
+)
+
+// JoinedRound returns the first round a player could be paired in, 1 for players who registered
+// before the first round was paired.
+func (t *Tournament) JoinedRound(id int) (int, error) {
+	player, ok := t.players[id]
+	if !ok {
+		return 0, ErrPlayerNotFound
+	}
+	if player.joined == 0 {
+		return 1, nil
+	}
+	return player.joined, nil
+}
+
+// joinLate records the round a new player joins in.
+func (t *Tournament) joinLate(player *Player) {
+	joined := t.currentRound
+	if len(t.rounds[t.currentRound]) > 0 {
+		joined++
+	}
+	if joined > 1 {
+		player.joined = joined
+	}
+}
+
+// recordMissed adds the synthetic result of a round missed by a late player to their record.
+func (t *Tournament) recordMissed(records map[int]*playerRecord, id int, round int, record func(int, int, int, int, int, int, int)) {
+	r := records[id]
+	switch t.config.LateEntry {
+	case LateEntryByes:
+		record(id, byeId, 2, 0, 0, 0, 0)
+		r.missed--
+	case LateEntryLosses:
+		record(id, byeId, 0, 2, 0, 0, -1)
+		r.missed--
+	case LateEntryAverage:
+		if round != t.players[id].joined-1 {
+			return
+		}
+		total, count := 0, 0
+		for other, player := range t.players {
+			if !player.phantom && !player.disqualified && player.joined <= round {
+				total += records[other].standing.Points
+				count++
+			}
+		}
+		if count > 0 {
+			r.standing.Points += total / count
+			r.unplayedPoints += total / count
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package swisstools
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLateEntry(t *testing.T) {
+	for _, test := range []struct {
+		policy LateEntryPolicy
+		points int
+		wins   int
+		losses int
+	}{
+		{LateEntryZero, 0, 0, 0},
+		{LateEntryByes, 6, 2, 0},
+		{LateEntryLosses, 0, 0, 2},
+		// Half the field won both rounds.
+		{LateEntryAverage, 3, 0, 0},
+	} {
+		tournament := NewTournamentWithConfig(TournamentConfig{LateEntry: test.policy})
+		for i := 1; i <= 4; i++ {
+			tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+		}
+		for round := 1; round <= 2; round++ {
+			tournament.Pair()
+			if round == 2 {
+				tournament.AddPlayer("Late")
+			}
+			for _, pairing := range tournament.GetRound() {
+				winner := pairing.playera
+				if tournament.players[pairing.playerb].points > tournament.players[winner].points {
+					winner = pairing.playerb
+				}
+				tournament.AddResult(winner, 2, 0, 0)
+			}
+			tournament.NextRound()
+		}
+		if joined, _ := tournament.JoinedRound(5); joined != 3 {
+			t.Fatalf("Expecting the late player to join in round 3, got %d.", joined)
+		}
+		data, _ := tournament.DumpTournament()
+		loaded, _ := LoadTournament(data)
+		for _, standing := range loaded.GetStandings() {
+			if standing.Id != 5 {
+				continue
+			}
+			if standing.Points != test.points || standing.Wins != test.wins || standing.Losses != test.losses {
+				t.Fatalf("Expecting %d points and a %d-%d record with %q, got %+v.", test.points, test.wins, test.losses, test.policy, standing)
+			}
+		}
+		// Missed rounds count as draws for the opponents' tiebreakers whatever the policy.
+		if err := loaded.Pair(); err != nil {
+			t.Fatalf("Pair returned an error: %s", err)
+		}
+		pairing, _ := loaded.findPairing(5)
+		if pairing.IsBye() {
+			continue
+		}
+		loaded.AddResult(5, 2, 0, 0)
+		loaded.NextRound()
+		for _, standing := range loaded.GetStandings() {
+			opponent := pairing.playera + pairing.playerb - 5
+			if standing.Id == opponent && standing.Tiebreakers.Buchholz == 0 {
+				t.Fatalf("Expecting the late player to count for Buchholz, got %+v.", standing)
+			}
+		}
+	}
+}
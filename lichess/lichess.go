@@ -0,0 +1,210 @@
+// Package lichess creates Lichess games for the pairings of a chess mode swisstools tournament and
+// reports their results back to the tournament.
+package lichess
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dstathis/swisstools"
+)
+
+const DefaultBaseURL = "https://lichess.org"
+
+type Client struct {
+	BaseURL        string // Defaults to DefaultBaseURL.
+	Token          string // Personal API token of the organizer.
+	HTTPClient     *http.Client
+	ClockLimit     int // Initial clock time in seconds.
+	ClockIncrement int // Increment per move in seconds.
+	Rated          bool
+}
+
+// Challenge is a Lichess game created for a pairing.
+type Challenge struct {
+	GameID string
+	URL    string
+	White  int // Tournament id of the player with the white pieces.
+	Black  int // Tournament id of the player with the black pieces.
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL == "" {
+		return DefaultBaseURL
+	}
+	return strings.TrimRight(c.BaseURL, "/")
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return c.HTTPClient
+}
+
+func (c *Client) do(ctx context.Context, method string, path string, form url.Values, out any) error {
+	var body *strings.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	} else {
+		body = strings.NewReader("")
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL()+path, body)
+	if err != nil {
+		return err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("lichess: %s %s: %s", method, path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// CreateChallenges opens a Lichess challenge restricted to the two players of every pairing in the
+// current round. usernames maps tournament player ids to Lichess usernames. The tournament must run
+// in chess mode so that colors are allocated.
+func (c *Client) CreateChallenges(ctx context.Context, t *swisstools.Tournament, usernames map[int]string) ([]Challenge, error) {
+	challenges := []Challenge{}
+	for _, pairing := range t.GetRound() {
+		white, black := pairing.White(), pairing.Black()
+		if white == 0 || black == 0 {
+			continue // Byes, or a tournament without colors.
+		}
+		whiteName, ok := usernames[white]
+		if !ok {
+			return challenges, fmt.Errorf("lichess: no username for player %d", white)
+		}
+		blackName, ok := usernames[black]
+		if !ok {
+			return challenges, fmt.Errorf("lichess: no username for player %d", black)
+		}
+		form := url.Values{}
+		form.Set("rated", strconv.FormatBool(c.Rated))
+		form.Set("clock.limit", strconv.Itoa(c.ClockLimit))
+		form.Set("clock.increment", strconv.Itoa(c.ClockIncrement))
+		form.Set("users", whiteName+","+blackName) // The first user gets white.
+		form.Set("name", fmt.Sprintf("%s vs %s", whiteName, blackName))
+		var created struct {
+			ID  string `json:"id"`
+			URL string `json:"url"`
+		}
+		if err := c.do(ctx, http.MethodPost, "/api/challenge/open", form, &created); err != nil {
+			return challenges, err
+		}
+		challenges = append(challenges, Challenge{GameID: created.ID, URL: created.URL, White: white, Black: black})
+	}
+	return challenges, nil
+}
+
+// Game statuses of Lichess games that are still to be played and of games that ended with a result.
+// Other statuses, such as "aborted" and "noStart", end a game without a result.
+var (
+	unfinished = map[string]bool{"created": true, "started": true}
+	finished   = map[string]bool{"mate": true, "resign": true, "stalemate": true, "timeout": true, "draw": true, "outoftime": true, "cheat": true, "variantEnd": true}
+)
+
+// AbortedError is returned by FetchResults for games that ended without a result, for example
+// because they were aborted or never started. Their matches are left unreported, to be played again
+// or reported by hand.
+type AbortedError struct {
+	Challenges []Challenge
+	Statuses   []string // Lichess status of each game.
+}
+
+func (e *AbortedError) Error() string {
+	games := []string{}
+	for i, challenge := range e.Challenges {
+		games = append(games, fmt.Sprintf("%s (%s)", challenge.GameID, e.Statuses[i]))
+	}
+	return "games ended without a result: " + strings.Join(games, ", ")
+}
+
+// FetchResults looks up the games of the given challenges and enters the results of finished games
+// with AddResult. It returns the challenges whose games have not finished yet, and an *AbortedError
+// if games ended without a result.
+func (c *Client) FetchResults(ctx context.Context, t *swisstools.Tournament, challenges []Challenge) ([]Challenge, error) {
+	pending := []Challenge{}
+	aborted := &AbortedError{}
+	for i, challenge := range challenges {
+		var game struct {
+			Status string `json:"status"`
+			Winner string `json:"winner"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/game/export/"+url.PathEscape(challenge.GameID), nil, &game); err != nil {
+			return append(pending, challenges[i:]...), err
+		}
+		if unfinished[game.Status] {
+			pending = append(pending, challenge)
+			continue
+		}
+		if !finished[game.Status] {
+			aborted.Challenges = append(aborted.Challenges, challenge)
+			aborted.Statuses = append(aborted.Statuses, game.Status)
+			continue
+		}
+		var err error
+		switch game.Winner {
+		case "white":
+			err = t.AddResult(challenge.White, 1, 0, 0)
+		case "black":
+			err = t.AddResult(challenge.Black, 1, 0, 0)
+		default:
+			err = t.AddResult(challenge.White, 0, 0, 1)
+		}
+		if err != nil {
+			return append(pending, challenges[i:]...), err
+		}
+	}
+	if len(aborted.Challenges) > 0 {
+		return pending, aborted
+	}
+	return pending, nil
+}
+
+// Poll calls FetchResults every interval until all games have finished or ctx is done. Games that
+// ended without a result are returned in an *AbortedError once the others have finished.
+func (c *Client) Poll(ctx context.Context, t *swisstools.Tournament, challenges []Challenge, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	aborted := &AbortedError{}
+	for {
+		var err error
+		challenges, err = c.FetchResults(ctx, t, challenges)
+		var abortedNow *AbortedError
+		if errors.As(err, &abortedNow) {
+			aborted.Challenges = append(aborted.Challenges, abortedNow.Challenges...)
+			aborted.Statuses = append(aborted.Statuses, abortedNow.Statuses...)
+		} else if err != nil {
+			return err
+		}
+		if len(challenges) == 0 {
+			if len(aborted.Challenges) > 0 {
+				return aborted
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package lichess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dstathis/swisstools"
+)
+
+func TestChallengesAndResults(t *testing.T) {
+	users := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/challenge/open":
+			r.ParseForm()
+			id := fmt.Sprintf("game%d", len(users))
+			users[id] = r.Form.Get("users")
+			fmt.Fprintf(w, `{"id": %q, "url": "https://lichess.org/%s"}`, id, id)
+		case r.URL.Path == "/game/export/game0":
+			fmt.Fprint(w, `{"status": "mate", "winner": "black"}`)
+		case r.URL.Path == "/game/export/game1":
+			fmt.Fprint(w, `{"status": "aborted"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tournament := swisstools.NewTournamentWithConfig(swisstools.TournamentConfig{Chess: true})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	client := Client{BaseURL: server.URL, ClockLimit: 300}
+	names := map[int]string{1: "dylan", 2: "sam", 3: "alex", 4: "kim"}
+	challenges, err := client.CreateChallenges(context.Background(), &tournament, names)
+	if err != nil || len(challenges) != 2 {
+		t.Fatalf("Expecting two challenges, got %v (%v).", challenges, err)
+	}
+	if users["game0"] == "" || !strings.HasPrefix(users["game0"], names[challenges[0].White]) {
+		t.Fatalf("Expecting the white player to be listed first, got %q.", users["game0"])
+	}
+	pending, err := client.FetchResults(context.Background(), &tournament, challenges)
+	var aborted *AbortedError
+	if !errors.As(err, &aborted) || len(aborted.Challenges) != 1 || aborted.Challenges[0].GameID != "game1" || len(pending) != 0 {
+		t.Fatalf("Expecting the aborted game to be reported and nothing pending, got %v (%v).", pending, err)
+	}
+	decided, _ := tournament.GetPairingForPlayer(challenges[0].White)
+	if decided.Winner() != challenges[0].Black {
+		t.Fatalf("Expecting black to win the first game, got %+v.", decided)
+	}
+	if abortedPairing, _ := tournament.GetPairingForPlayer(challenges[1].White); abortedPairing.IsComplete() {
+		t.Fatalf("Expecting the aborted game to stay unreported, got %+v.", abortedPairing)
+	}
+}
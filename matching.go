@@ -0,0 +1,509 @@
+package swisstools
+
+// weightedEdge is an edge between vertices i and j of a graph for maxWeightMatching.
+type weightedEdge struct {
+	i, j   int
+	weight int64
+}
+
+// maxWeightMatching returns a maximum weight matching of the graph with vertices 0 to vertices-1,
+// as the vertex every vertex is matched to, -1 for unmatched vertices. With maxCardinality it is
+// the maximum weight matching among those with the most edges. It is Edmonds' blossom algorithm in
+// O(n³) following Joris van Rantwijk's implementation. Edge weights must be even so that the dual
+// variables stay integers.
+func maxWeightMatching(vertices int, edges []weightedEdge, maxCardinality bool) []int {
+	mate := make([]int, vertices)
+	for v := range mate {
+		mate[v] = -1
+	}
+	if len(edges) == 0 {
+		return mate
+	}
+	n := vertices
+	var maxWeight int64
+	for _, edge := range edges {
+		if edge.weight > maxWeight {
+			maxWeight = edge.weight
+		}
+	}
+	// Edge k has endpoints 2k, vertex i, and 2k+1, vertex j.
+	endpoint := make([]int, 2*len(edges))
+	neighbend := make([][]int, n)
+	for k, edge := range edges {
+		endpoint[2*k], endpoint[2*k+1] = edge.i, edge.j
+		neighbend[edge.i] = append(neighbend[edge.i], 2*k+1)
+		neighbend[edge.j] = append(neighbend[edge.j], 2*k)
+	}
+	// mate holds the remote endpoint of the matched edge of every vertex until the end.
+	label := make([]int, 2*n)
+	labelend := make([]int, 2*n)
+	inblossom := make([]int, n)
+	blossomparent := make([]int, 2*n)
+	blossomchilds := make([][]int, 2*n)
+	blossombase := make([]int, 2*n)
+	blossomendps := make([][]int, 2*n)
+	bestedge := make([]int, 2*n)
+	blossombestedges := make([][]int, 2*n)
+	unusedblossoms := []int{}
+	dualvar := make([]int64, 2*n)
+	allowedge := make([]bool, len(edges))
+	queue := []int{}
+	for v := 0; v < n; v++ {
+		inblossom[v] = v
+		blossombase[v] = v
+		blossombase[n+v] = -1
+		dualvar[v] = maxWeight
+		unusedblossoms = append(unusedblossoms, n+v)
+	}
+	for b := range blossomparent {
+		blossomparent[b] = -1
+		labelend[b] = -1
+		bestedge[b] = -1
+	}
+
+	at := func(s []int, i int) int {
+		return s[((i%len(s))+len(s))%len(s)]
+	}
+	slack := func(k int) int64 {
+		return dualvar[edges[k].i] + dualvar[edges[k].j] - 2*edges[k].weight
+	}
+	var blossomLeaves func(b int) []int
+	blossomLeaves = func(b int) []int {
+		if b < n {
+			return []int{b}
+		}
+		leaves := []int{}
+		for _, t := range blossomchilds[b] {
+			leaves = append(leaves, blossomLeaves(t)...)
+		}
+		return leaves
+	}
+
+	var assignLabel func(w int, t int, p int)
+	assignLabel = func(w int, t int, p int) {
+		b := inblossom[w]
+		label[w], label[b] = t, t
+		labelend[w], labelend[b] = p, p
+		bestedge[w], bestedge[b] = -1, -1
+		if t == 1 {
+			queue = append(queue, blossomLeaves(b)...)
+		} else if t == 2 {
+			base := blossombase[b]
+			assignLabel(endpoint[mate[base]], 1, mate[base]^1)
+		}
+	}
+
+	// scanBlossom traces back from v and w to find a new blossom or an augmenting path. It returns
+	// the base of the blossom or -1.
+	scanBlossom := func(v int, w int) int {
+		path := []int{}
+		base := -1
+		for v != -1 || w != -1 {
+			b := inblossom[v]
+			if label[b]&4 != 0 {
+				base = blossombase[b]
+				break
+			}
+			path = append(path, b)
+			label[b] = 5
+			if labelend[b] == -1 {
+				v = -1
+			} else {
+				v = endpoint[labelend[b]]
+				b = inblossom[v]
+				v = endpoint[labelend[b]]
+			}
+			if w != -1 {
+				v, w = w, v
+			}
+		}
+		for _, b := range path {
+			label[b] = 1
+		}
+		return base
+	}
+
+	addBlossom := func(base int, k int) {
+		v, w := edges[k].i, edges[k].j
+		bb, bv, bw := inblossom[base], inblossom[v], inblossom[w]
+		b := unusedblossoms[len(unusedblossoms)-1]
+		unusedblossoms = unusedblossoms[:len(unusedblossoms)-1]
+		blossombase[b] = base
+		blossomparent[b] = -1
+		blossomparent[bb] = b
+		path, endps := []int{}, []int{}
+		for bv != bb {
+			blossomparent[bv] = b
+			path = append(path, bv)
+			endps = append(endps, labelend[bv])
+			v = endpoint[labelend[bv]]
+			bv = inblossom[v]
+		}
+		path = append(path, bb)
+		for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+			path[i], path[j] = path[j], path[i]
+		}
+		for i, j := 0, len(endps)-1; i < j; i, j = i+1, j-1 {
+			endps[i], endps[j] = endps[j], endps[i]
+		}
+		endps = append(endps, 2*k)
+		for bw != bb {
+			blossomparent[bw] = b
+			path = append(path, bw)
+			endps = append(endps, labelend[bw]^1)
+			w = endpoint[labelend[bw]]
+			bw = inblossom[w]
+		}
+		blossomchilds[b], blossomendps[b] = path, endps
+		label[b] = 1
+		labelend[b] = labelend[bb]
+		dualvar[b] = 0
+		for _, v := range blossomLeaves(b) {
+			if label[inblossom[v]] == 2 {
+				queue = append(queue, v)
+			}
+			inblossom[v] = b
+		}
+		bestedgeto := make([]int, 2*n)
+		for i := range bestedgeto {
+			bestedgeto[i] = -1
+		}
+		for _, bv := range path {
+			var nblists [][]int
+			if blossombestedges[bv] == nil {
+				for _, v := range blossomLeaves(bv) {
+					nblist := []int{}
+					for _, p := range neighbend[v] {
+						nblist = append(nblist, p/2)
+					}
+					nblists = append(nblists, nblist)
+				}
+			} else {
+				nblists = [][]int{blossombestedges[bv]}
+			}
+			for _, nblist := range nblists {
+				for _, k := range nblist {
+					i, j := edges[k].i, edges[k].j
+					if inblossom[j] == b {
+						i, j = j, i
+					}
+					bj := inblossom[j]
+					if bj != b && label[bj] == 1 && (bestedgeto[bj] == -1 || slack(k) < slack(bestedgeto[bj])) {
+						bestedgeto[bj] = k
+					}
+				}
+			}
+			blossombestedges[bv] = nil
+			bestedge[bv] = -1
+		}
+		best := []int{}
+		for _, k := range bestedgeto {
+			if k != -1 {
+				best = append(best, k)
+			}
+		}
+		blossombestedges[b] = best
+		bestedge[b] = -1
+		for _, k := range best {
+			if bestedge[b] == -1 || slack(k) < slack(bestedge[b]) {
+				bestedge[b] = k
+			}
+		}
+	}
+
+	var expandBlossom func(b int, endstage bool)
+	expandBlossom = func(b int, endstage bool) {
+		for _, s := range blossomchilds[b] {
+			blossomparent[s] = -1
+			if s < n {
+				inblossom[s] = s
+			} else if endstage && dualvar[s] == 0 {
+				expandBlossom(s, endstage)
+			} else {
+				for _, v := range blossomLeaves(s) {
+					inblossom[v] = s
+				}
+			}
+		}
+		if !endstage && label[b] == 2 {
+			childs, endps := blossomchilds[b], blossomendps[b]
+			entrychild := inblossom[endpoint[labelend[b]^1]]
+			j := 0
+			for i, child := range childs {
+				if child == entrychild {
+					j = i
+				}
+			}
+			jstep, endptrick := -1, 1
+			if j&1 != 0 {
+				j -= len(childs)
+				jstep, endptrick = 1, 0
+			}
+			p := labelend[b]
+			for j != 0 {
+				label[endpoint[p^1]] = 0
+				label[endpoint[at(endps, j-endptrick)^endptrick^1]] = 0
+				assignLabel(endpoint[p^1], 2, p)
+				allowedge[at(endps, j-endptrick)/2] = true
+				j += jstep
+				p = at(endps, j-endptrick) ^ endptrick
+				allowedge[p/2] = true
+				j += jstep
+			}
+			bv := at(childs, j)
+			label[endpoint[p^1]], label[bv] = 2, 2
+			labelend[endpoint[p^1]], labelend[bv] = p, p
+			bestedge[bv] = -1
+			j += jstep
+			for at(childs, j) != entrychild {
+				bv := at(childs, j)
+				if label[bv] == 1 {
+					j += jstep
+					continue
+				}
+				for _, v := range blossomLeaves(bv) {
+					if label[v] != 0 {
+						label[v] = 0
+						label[endpoint[mate[blossombase[bv]]]] = 0
+						assignLabel(v, 2, labelend[v])
+						break
+					}
+				}
+				j += jstep
+			}
+		}
+		label[b], labelend[b] = -1, -1
+		blossomchilds[b], blossomendps[b] = nil, nil
+		blossombase[b] = -1
+		blossombestedges[b] = nil
+		bestedge[b] = -1
+		unusedblossoms = append(unusedblossoms, b)
+	}
+
+	var augmentBlossom func(b int, v int)
+	augmentBlossom = func(b int, v int) {
+		t := v
+		for blossomparent[t] != b {
+			t = blossomparent[t]
+		}
+		if t >= n {
+			augmentBlossom(t, v)
+		}
+		childs, endps := blossomchilds[b], blossomendps[b]
+		i := 0
+		for index, child := range childs {
+			if child == t {
+				i = index
+			}
+		}
+		j := i
+		jstep, endptrick := -1, 1
+		if i&1 != 0 {
+			j -= len(childs)
+			jstep, endptrick = 1, 0
+		}
+		for j != 0 {
+			j += jstep
+			t = at(childs, j)
+			p := at(endps, j-endptrick) ^ endptrick
+			if t >= n {
+				augmentBlossom(t, endpoint[p])
+			}
+			j += jstep
+			t = at(childs, j)
+			if t >= n {
+				augmentBlossom(t, endpoint[p^1])
+			}
+			mate[endpoint[p]] = p ^ 1
+			mate[endpoint[p^1]] = p
+		}
+		blossomchilds[b] = append(append([]int{}, childs[i:]...), childs[:i]...)
+		blossomendps[b] = append(append([]int{}, endps[i:]...), endps[:i]...)
+		blossombase[b] = blossombase[blossomchilds[b][0]]
+	}
+
+	augmentMatching := func(k int) {
+		for _, start := range [][2]int{{edges[k].i, 2*k + 1}, {edges[k].j, 2 * k}} {
+			s, p := start[0], start[1]
+			for {
+				bs := inblossom[s]
+				if bs >= n {
+					augmentBlossom(bs, s)
+				}
+				mate[s] = p
+				if labelend[bs] == -1 {
+					break
+				}
+				t := endpoint[labelend[bs]]
+				bt := inblossom[t]
+				s = endpoint[labelend[bt]]
+				j := endpoint[labelend[bt]^1]
+				if bt >= n {
+					augmentBlossom(bt, j)
+				}
+				mate[j] = labelend[bt]
+				p = labelend[bt] ^ 1
+			}
+		}
+	}
+
+	for stage := 0; stage < n; stage++ {
+		for b := range label {
+			label[b] = 0
+			bestedge[b] = -1
+		}
+		for b := n; b < 2*n; b++ {
+			blossombestedges[b] = nil
+		}
+		for k := range allowedge {
+			allowedge[k] = false
+		}
+		queue = queue[:0]
+		for v := 0; v < n; v++ {
+			if mate[v] == -1 && label[inblossom[v]] == 0 {
+				assignLabel(v, 1, -1)
+			}
+		}
+		augmented := false
+		for {
+			for len(queue) > 0 && !augmented {
+				v := queue[len(queue)-1]
+				queue = queue[:len(queue)-1]
+				for _, p := range neighbend[v] {
+					k := p / 2
+					w := endpoint[p]
+					if inblossom[v] == inblossom[w] {
+						continue
+					}
+					var kslack int64
+					if !allowedge[k] {
+						kslack = slack(k)
+						if kslack <= 0 {
+							allowedge[k] = true
+						}
+					}
+					if allowedge[k] {
+						if label[inblossom[w]] == 0 {
+							assignLabel(w, 2, p^1)
+						} else if label[inblossom[w]] == 1 {
+							if base := scanBlossom(v, w); base >= 0 {
+								addBlossom(base, k)
+							} else {
+								augmentMatching(k)
+								augmented = true
+								break
+							}
+						} else if label[w] == 0 {
+							label[w] = 2
+							labelend[w] = p ^ 1
+						}
+					} else if label[inblossom[w]] == 1 {
+						b := inblossom[v]
+						if bestedge[b] == -1 || kslack < slack(bestedge[b]) {
+							bestedge[b] = k
+						}
+					} else if label[w] == 0 {
+						if bestedge[w] == -1 || kslack < slack(bestedge[w]) {
+							bestedge[w] = k
+						}
+					}
+				}
+			}
+			if augmented {
+				break
+			}
+
+			// No augmenting path with the current duals, update them.
+			deltatype := -1
+			var delta int64
+			deltaedge, deltablossom := -1, -1
+			if !maxCardinality {
+				deltatype = 1
+				delta = dualvar[0]
+				for v := 1; v < n; v++ {
+					if dualvar[v] < delta {
+						delta = dualvar[v]
+					}
+				}
+			}
+			for v := 0; v < n; v++ {
+				if label[inblossom[v]] == 0 && bestedge[v] != -1 {
+					if d := slack(bestedge[v]); deltatype == -1 || d < delta {
+						delta, deltatype, deltaedge = d, 2, bestedge[v]
+					}
+				}
+			}
+			for b := 0; b < 2*n; b++ {
+				if blossomparent[b] == -1 && label[b] == 1 && bestedge[b] != -1 {
+					if d := slack(bestedge[b]) / 2; deltatype == -1 || d < delta {
+						delta, deltatype, deltaedge = d, 3, bestedge[b]
+					}
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if blossombase[b] >= 0 && blossomparent[b] == -1 && label[b] == 2 && (deltatype == -1 || dualvar[b] < delta) {
+					delta, deltatype, deltablossom = dualvar[b], 4, b
+				}
+			}
+			if deltatype == -1 {
+				deltatype = 1
+				delta = dualvar[0]
+				for v := 1; v < n; v++ {
+					if dualvar[v] < delta {
+						delta = dualvar[v]
+					}
+				}
+				if delta < 0 {
+					delta = 0
+				}
+			}
+			for v := 0; v < n; v++ {
+				switch label[inblossom[v]] {
+				case 1:
+					dualvar[v] -= delta
+				case 2:
+					dualvar[v] += delta
+				}
+			}
+			for b := n; b < 2*n; b++ {
+				if blossombase[b] >= 0 && blossomparent[b] == -1 {
+					switch label[b] {
+					case 1:
+						dualvar[b] += delta
+					case 2:
+						dualvar[b] -= delta
+					}
+				}
+			}
+			if deltatype == 1 {
+				break
+			} else if deltatype == 2 {
+				allowedge[deltaedge] = true
+				i := edges[deltaedge].i
+				if label[inblossom[i]] == 0 {
+					i = edges[deltaedge].j
+				}
+				queue = append(queue, i)
+			} else if deltatype == 3 {
+				allowedge[deltaedge] = true
+				queue = append(queue, edges[deltaedge].i)
+			} else if deltatype == 4 {
+				expandBlossom(deltablossom, false)
+			}
+		}
+		if !augmented {
+			break
+		}
+		for b := n; b < 2*n; b++ {
+			if blossomparent[b] == -1 && blossombase[b] >= 0 && label[b] == 1 && dualvar[b] == 0 {
+				expandBlossom(b, true)
+			}
+		}
+	}
+	for v := range mate {
+		if mate[v] >= 0 {
+			mate[v] = endpoint[mate[v]]
+		}
+	}
+	return mate
+}
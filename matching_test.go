@@ -0,0 +1,61 @@
+package swisstools
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bestMatching finds the weight and size of the best matching by trying every matching.
+func bestMatching(vertices int, edges []weightedEdge, maxCardinality bool) (int64, int) {
+	bestWeight, bestSize := int64(0), 0
+	var search func(k int, used []bool, weight int64, size int)
+	search = func(k int, used []bool, weight int64, size int) {
+		if k == len(edges) {
+			if (maxCardinality && (size > bestSize || size == bestSize && weight > bestWeight)) || (!maxCardinality && weight > bestWeight) {
+				bestWeight, bestSize = weight, size
+			}
+			return
+		}
+		search(k+1, used, weight, size)
+		edge := edges[k]
+		if !used[edge.i] && !used[edge.j] {
+			used[edge.i], used[edge.j] = true, true
+			search(k+1, used, weight+edge.weight, size+1)
+			used[edge.i], used[edge.j] = false, false
+		}
+	}
+	search(0, make([]bool, vertices), 0, 0)
+	return bestWeight, bestSize
+}
+
+func TestMaxWeightMatching(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for test := 0; test < 500; test++ {
+		vertices := 2 + random.Intn(8)
+		edges := []weightedEdge{}
+		for i := 0; i < vertices; i++ {
+			for j := i + 1; j < vertices; j++ {
+				if random.Intn(3) > 0 {
+					edges = append(edges, weightedEdge{i: i, j: j, weight: 2 * int64(random.Intn(20))})
+				}
+			}
+		}
+		for _, maxCardinality := range []bool{false, true} {
+			mate := maxWeightMatching(vertices, edges, maxCardinality)
+			weight, size := int64(0), 0
+			for _, edge := range edges {
+				if mate[edge.i] == edge.j {
+					if mate[edge.j] != edge.i {
+						t.Fatalf("Expecting a symmetric matching, got %v.", mate)
+					}
+					weight += edge.weight
+					size++
+				}
+			}
+			bestWeight, bestSize := bestMatching(vertices, edges, maxCardinality)
+			if weight != bestWeight || (maxCardinality && size != bestSize) {
+				t.Fatalf("Expecting weight %d and %d edges for %v, got %d and %d.", bestWeight, bestSize, edges, weight, size)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package swisstools
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// meleeColumns are the columns of a Melee.gg match export read by ImportMelee. The table and id
+// columns are optional.
+var meleeColumns = []string{"round", "player 1", "player 2", "player 1 wins", "player 2 wins", "draws"}
+
+// ImportMelee reads a Melee.gg match export in CSV form, one row per match with the columns "Round",
+// "Player 1", "Player 2", "Player 1 Wins", "Player 2 Wins" and "Draws", so that an event started
+// there can be continued with this library. Optional "Player 1 ID" and "Player 2 ID" columns are kept
+// as external ids. A match without a second player, or against "BYE", is a bye and matches without
+// results are left unreported. Every round in the export but the last is completed. The last one is
+// completed as well if all its results are in, so that the next round can be paired.
+func ImportMelee(r io.Reader, config TournamentConfig) (Tournament, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return Tournament{}, err
+	}
+	if len(rows) == 0 {
+		return Tournament{}, ErrEmptyImport
+	}
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range meleeColumns {
+		if _, ok := columns[name]; !ok {
+			return Tournament{}, fmt.Errorf("%w %s", ErrMissingColumn, name)
+		}
+	}
+	field := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+	t := NewTournamentWithConfig(config)
+	player := func(name string, externalId string) (int, error) {
+		if name == "" || strings.EqualFold(name, "bye") {
+			return byeId, nil
+		}
+		if id, err := t.GetPlayerID(name); err == nil {
+			return id, nil
+		}
+		if err := t.AddPlayer(name); err != nil {
+			return 0, err
+		}
+		if externalId != "" {
+			if err := t.SetExternalId(t.lastId, externalId); err != nil {
+				return 0, err
+			}
+		}
+		return t.lastId, nil
+	}
+	rounds := map[int]Round{}
+	last := 0
+	for _, row := range rows[1:] {
+		number, err := strconv.Atoi(field(row, "round"))
+		if err != nil || number < 1 {
+			return Tournament{}, ErrRoundOutOfRange
+		}
+		a, err := player(field(row, "player 1"), field(row, "player 1 id"))
+		if err != nil {
+			return Tournament{}, err
+		}
+		b, err := player(field(row, "player 2"), field(row, "player 2 id"))
+		if err != nil {
+			return Tournament{}, err
+		}
+		if a == byeId {
+			a, b = b, a
+		}
+		if a == byeId {
+			return Tournament{}, fmt.Errorf("match without players: %w", ErrPlayerNotFound)
+		}
+		pairing := Pairing{playera: a, playerb: b, playeraWins: -1, playerbWins: -1, draws: -1}
+		if table, err := strconv.Atoi(field(row, "table")); err == nil && b != byeId {
+			pairing.table = table
+		}
+		if b == byeId {
+			pairing.playeraWins, pairing.playerbWins, pairing.draws = 2, 0, 0
+		} else if field(row, "player 1 wins") != "" {
+			wins, errWins := strconv.Atoi(field(row, "player 1 wins"))
+			losses, errLosses := strconv.Atoi(field(row, "player 2 wins"))
+			draws, errDraws := strconv.Atoi(field(row, "draws"))
+			if errWins != nil || errLosses != nil || errDraws != nil {
+				return Tournament{}, ErrInvalidScore
+			}
+			if err := t.validateResult(pairing, Result{Player: a, Wins: wins, Losses: losses, Draws: draws}); err != nil {
+				return Tournament{}, err
+			}
+			pairing.playeraWins, pairing.playerbWins, pairing.draws = wins, losses, draws
+		}
+		rounds[number] = append(rounds[number], pairing)
+		if number > last {
+			last = number
+		}
+	}
+	t.importRounds(rounds, last)
+	return t, nil
+}
+
+// MeleeImporter imports Melee.gg match exports, see ImportMelee.
+type MeleeImporter struct{}
+
+func (MeleeImporter) Import(r io.Reader, config TournamentConfig) (Tournament, error) {
+	return ImportMelee(r, config)
+}
@@ -0,0 +1,40 @@
+package swisstools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImportMelee(t *testing.T) {
+	export := `Round,Table,Player 1,Player 1 ID,Player 2,Player 2 ID,Player 1 Wins,Player 2 Wins,Draws
+1,1,Dylan,m-1,Sam,m-2,2,1,0
+1,2,Alex,m-3,,,,,
+2,1,Dylan,m-1,Alex,m-3,,,
+2,2,Sam,m-2,BYE,,,,
+`
+	tournament, err := ImportMelee(strings.NewReader(export), TournamentConfig{})
+	if err != nil {
+		t.Fatalf("ImportMelee returned an error: %s", err)
+	}
+	if tournament.CurrentRoundNumber() != 2 {
+		t.Fatalf("Expecting to continue in round 2, got %d.", tournament.CurrentRoundNumber())
+	}
+	id, err := tournament.GetPlayerIDByExternalId("m-3")
+	if err != nil || tournament.players[id].name != "Alex" {
+		t.Fatalf("Expecting Alex to have external id m-3, got %d (%v).", id, err)
+	}
+	standings := tournament.GetStandings()
+	if standings[0].Name != "Alex" || standings[0].Points != 3 || standings[1].Name != "Dylan" || standings[1].Points != 3 {
+		t.Fatalf("Expecting Alex and Dylan on 3 points, got %+v.", standings)
+	}
+	if err := tournament.AddResultByName("Alex", 2, 0, 0); err != nil {
+		t.Fatalf("AddResultByName returned an error: %s", err)
+	}
+	if round := tournament.GetRound(); !round[1].IsBye() || round[1].Winner() != 2 {
+		t.Fatalf("Expecting Sam to have a bye, got %+v.", round[1])
+	}
+
+	if _, err := ImportMelee(strings.NewReader("Round,Player 1\n1,Dylan\n"), TournamentConfig{}); err == nil {
+		t.Fatalf("Expecting an error for missing columns.")
+	}
+}
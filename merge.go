@@ -0,0 +1,214 @@
+package swisstools
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// MergeConflict is a match that was reported differently in the two copies passed to MergeDumps, or a
+// detail of a player that was set differently in them.
+type MergeConflict struct {
+	Round  int // 0 for a player.
+	Ours   Pairing
+	Theirs Pairing
+	// The player, the detail, such as "seed", and its value in each copy as JSON.
+	Player      int
+	Field       string
+	OursValue   string
+	TheirsValue string
+}
+
+// MergeDumps reconciles two dumps of the same event that were updated separately, for example on two
+// laptops entering the results of different tables while offline. Results reported in only one copy
+// are combined. Matches reported differently in both copies keep our result and are returned as
+// conflicts to be resolved by hand, as are player details, see mergePlayer. The copies must agree on
+// the names of the players and the pairings they share.
+func MergeDumps(ours []byte, theirs []byte) (Tournament, []MergeConflict, error) {
+	a, err := decodeExport(ours)
+	if err != nil {
+		return Tournament{}, nil, err
+	}
+	b, err := decodeExport(theirs)
+	if err != nil {
+		return Tournament{}, nil, err
+	}
+	if !reflect.DeepEqual(a.Config, b.Config) {
+		return Tournament{}, nil, ErrConfigurationsDiffer
+	}
+	merged := a
+	if b.CurrentRound > a.CurrentRound {
+		merged.CurrentRound = b.CurrentRound
+		merged.LastRanks = b.LastRanks
+		merged.RoundTimes = b.RoundTimes
+	}
+	if b.LastId > merged.LastId {
+		merged.LastId = b.LastId
+	}
+	if len(b.Voided) > len(a.Voided) {
+		merged.Voided = b.Voided
+	}
+	if merged.Final == nil {
+		merged.Final = b.Final
+	}
+	if len(b.StageStarts) > len(a.StageStarts) {
+		merged.StageStarts = b.StageStarts
+	}
+	if merged.Finals == nil {
+		merged.Finals = b.Finals
+	}
+	// Both audit logs start with the changes made before the copies diverged.
+	shared := 0
+	for shared < len(a.Audit) && shared < len(b.Audit) && a.Audit[shared] == b.Audit[shared] {
+		shared++
+	}
+	merged.Audit = append(append([]AuditEntry{}, a.Audit...), b.Audit[shared:]...)
+	conflicts := []MergeConflict{}
+	players := map[int]int{} // Index in merged.Players.
+	merged.Players = append([]exportPlayer{}, a.Players...)
+	for i, player := range merged.Players {
+		players[player.Id] = i
+	}
+	for _, player := range b.Players {
+		i, ok := players[player.Id]
+		if !ok {
+			merged.Players = append(merged.Players, player)
+			continue
+		}
+		if merged.Players[i].Name != player.Name {
+			return Tournament{}, nil, ErrPlayersDiffer
+		}
+		var playerConflicts []MergeConflict
+		merged.Players[i], playerConflicts = mergePlayer(merged.Players[i], player)
+		conflicts = append(conflicts, playerConflicts...)
+	}
+	merged.Rounds = [][]exportPairing{}
+	for i := 0; i < len(a.Rounds) || i < len(b.Rounds); i++ {
+		if i >= len(b.Rounds) || len(b.Rounds[i]) == 0 {
+			merged.Rounds = append(merged.Rounds, a.Rounds[i])
+			continue
+		}
+		if i >= len(a.Rounds) || len(a.Rounds[i]) == 0 {
+			merged.Rounds = append(merged.Rounds, b.Rounds[i])
+			continue
+		}
+		round, roundConflicts, err := mergeRound(i+1, a.Rounds[i], b.Rounds[i])
+		if err != nil {
+			return Tournament{}, nil, err
+		}
+		merged.Rounds = append(merged.Rounds, round)
+		conflicts = append(conflicts, roundConflicts...)
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return Tournament{}, nil, err
+	}
+	t, err := LoadTournament(data)
+	if err != nil {
+		return Tournament{}, nil, err
+	}
+	return t, conflicts, nil
+}
+
+// mergeRound combines the results of the same round from two copies of a tournament.
+func mergeRound(number int, ours []exportPairing, theirs []exportPairing) ([]exportPairing, []MergeConflict, error) {
+	if len(ours) != len(theirs) {
+		return nil, nil, ErrPairingsDiffer
+	}
+	round := []exportPairing{}
+	conflicts := []MergeConflict{}
+	for i := range ours {
+		a, b := ours[i], theirs[i]
+		if a.PlayerA != b.PlayerA || a.PlayerB != b.PlayerB {
+			return nil, nil, ErrPairingsDiffer
+		}
+		switch {
+		case b.PlayerAWins == -1 || reflect.DeepEqual(a, b):
+			round = append(round, a)
+		case a.PlayerAWins == -1:
+			round = append(round, b)
+		default:
+			round = append(round, a)
+			if a.PlayerAWins != b.PlayerAWins || a.PlayerBWins != b.PlayerBWins || a.Draws != b.Draws {
+				conflicts = append(conflicts, MergeConflict{Round: number, Ours: importPairings(ours[i : i+1])[0], Theirs: importPairings(theirs[i : i+1])[0]})
+			}
+		}
+	}
+	return round, conflicts, nil
+}
+
+// mergePlayer combines the details of a player from two copies. Drops, disqualifications and
+// check-ins in either copy stand, and notes and metadata are combined. Any other detail set in only
+// one copy is taken from it, while one set differently in both keeps ours and is returned as a
+// conflict.
+func mergePlayer(ours exportPlayer, theirs exportPlayer) (exportPlayer, []MergeConflict) {
+	merged := ours
+	conflicts := []MergeConflict{}
+	conflict := func(field string, ours any, theirs any) {
+		a, _ := json.Marshal(ours)
+		b, _ := json.Marshal(theirs)
+		conflicts = append(conflicts, MergeConflict{Player: merged.Id, Field: field, OursValue: string(a), TheirsValue: string(b)})
+	}
+	merged.Dropped = ours.Dropped || theirs.Dropped
+	merged.CheckedIn = ours.CheckedIn || theirs.CheckedIn
+	switch {
+	case !ours.Disqualified:
+		merged.Disqualified, merged.DisqualifiedReason = theirs.Disqualified, theirs.DisqualifiedReason
+	case theirs.Disqualified && theirs.DisqualifiedReason != ours.DisqualifiedReason:
+		conflict("disqualified_reason", ours.DisqualifiedReason, theirs.DisqualifiedReason)
+	}
+	merged.Notes = append([]string{}, ours.Notes...)
+	noted := map[string]bool{}
+	for _, note := range ours.Notes {
+		noted[note] = true
+	}
+	for _, note := range theirs.Notes {
+		if !noted[note] {
+			merged.Notes = append(merged.Notes, note)
+		}
+	}
+	if len(theirs.Meta) > 0 {
+		merged.Meta = map[string]string{}
+		for key, value := range ours.Meta {
+			merged.Meta[key] = value
+		}
+		keys := []string{}
+		for key := range theirs.Meta {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := theirs.Meta[key]
+			if mine, ok := ours.Meta[key]; !ok {
+				merged.Meta[key] = value
+			} else if mine != value {
+				conflict("meta."+key, mine, value)
+			}
+		}
+	}
+	switch {
+	case ours.ExternalId == "":
+		merged.ExternalId = theirs.ExternalId
+	case theirs.ExternalId != "" && theirs.ExternalId != ours.ExternalId:
+		conflict("external_id", ours.ExternalId, theirs.ExternalId)
+	}
+	switch {
+	case ours.Seed == 0:
+		merged.Seed = theirs.Seed
+	case theirs.Seed != 0 && theirs.Seed != ours.Seed:
+		conflict("seed", ours.Seed, theirs.Seed)
+	}
+	switch {
+	case ours.Rating == nil:
+		merged.Rating = theirs.Rating
+	case theirs.Rating != nil && *theirs.Rating != *ours.Rating:
+		conflict("rating", ours.Rating, theirs.Rating)
+	}
+	switch {
+	case ours.Decklist == nil:
+		merged.Decklist = theirs.Decklist
+	case theirs.Decklist != nil && !reflect.DeepEqual(theirs.Decklist, ours.Decklist):
+		conflict("decklist", ours.Decklist, theirs.Decklist)
+	}
+	return merged, conflicts
+}
@@ -0,0 +1,84 @@
+package swisstools
+
+import "testing"
+
+func TestMergeDumps(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Robin", "Kim", "Jo"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	round := tournament.GetRound()
+	dump, _ := tournament.DumpTournament()
+	laptopA, _ := LoadTournament(dump)
+	laptopB, _ := LoadTournament(dump)
+	laptopA.AddResult(round[0].playera, 2, 0, 0)
+	laptopB.AddResult(round[1].playera, 2, 1, 0)
+	laptopA.AddResult(round[2].playera, 2, 0, 0)
+	laptopB.AddResult(round[2].playerb, 2, 0, 0)
+	ours, _ := laptopA.DumpTournament()
+	theirs, _ := laptopB.DumpTournament()
+	merged, conflicts, err := MergeDumps(ours, theirs)
+	if err != nil {
+		t.Fatalf("MergeDumps returned an error: %s", err)
+	}
+	pairings := merged.GetRound()
+	if pairings[0].Winner() != round[0].playera || pairings[1].Winner() != round[1].playera {
+		t.Fatalf("Expecting results from both copies, got %+v.", pairings)
+	}
+	if len(conflicts) != 1 || conflicts[0].Round != 1 || conflicts[0].Ours.Winner() != round[2].playera || conflicts[0].Theirs.Winner() != round[2].playerb {
+		t.Fatalf("Expecting a conflict on the third table, got %+v.", conflicts)
+	}
+	if pairings[2].Winner() != round[2].playera {
+		t.Fatalf("Expecting our result to be kept on conflict, got %+v.", pairings[2])
+	}
+
+	other := NewTournament()
+	other.AddPlayer("Someone")
+	otherDump, _ := other.DumpTournament()
+	if _, _, err := MergeDumps(ours, otherDump); err == nil {
+		t.Fatalf("Expecting an error when merging unrelated tournaments.")
+	}
+}
+
+func TestMergeDumpsPlayers(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Robin"} {
+		tournament.AddPlayer(name)
+	}
+	dump, _ := tournament.DumpTournament()
+	laptopA, _ := LoadTournament(dump)
+	laptopB, _ := LoadTournament(dump)
+	laptopA.DisqualifyPlayer(1, "marked cards")
+	laptopB.DropPlayer(2)
+	laptopA.AddPlayerNote(3, "Late")
+	laptopB.AddPlayerNote(3, "Left early")
+	laptopA.SetPlayerMeta(4, "team", "Red")
+	laptopB.SetPlayerMeta(4, "team", "Blue")
+	ours, _ := laptopA.DumpTournament()
+	theirs, _ := laptopB.DumpTournament()
+	merged, conflicts, err := MergeDumps(ours, theirs)
+	if err != nil {
+		t.Fatalf("MergeDumps returned an error: %s", err)
+	}
+	if player := merged.players[1]; !player.disqualified || !player.dropped || player.disqualifiedReason != "marked cards" {
+		t.Fatalf("Expecting the disqualification in our copy to stand, got %+v.", player)
+	}
+	if !merged.players[2].dropped {
+		t.Fatal("Expecting the drop in their copy to stand.")
+	}
+	if notes, _ := merged.GetPlayerNotes(3); len(notes) != 2 || notes[0] != "Late" || notes[1] != "Left early" {
+		t.Fatalf("Expecting the notes of both copies, got %v.", notes)
+	}
+	if len(conflicts) != 1 || conflicts[0].Player != 4 || conflicts[0].Field != "meta.team" || conflicts[0].OursValue != `"Red"` || conflicts[0].TheirsValue != `"Blue"` {
+		t.Fatalf("Expecting a conflict on the team of player 4, got %+v.", conflicts)
+	}
+	if meta, _ := merged.GetPlayerMeta(4); meta["team"] != "Red" {
+		t.Fatalf("Expecting our team to be kept on conflict, got %v.", meta)
+	}
+
+	merged, _, _ = MergeDumps(theirs, ours)
+	if !merged.players[1].disqualified || !merged.players[2].dropped {
+		t.Fatal("Expecting the drop and the disqualification from either copy.")
+	}
+}
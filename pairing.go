@@ -0,0 +1,217 @@
+package swisstools
+
+import (
+	"sort"
+	"time"
+)
+
+func (p Pairing) PlayerA() int {
+	return p.playera
+}
+
+// PlayerB returns the id of the second player, or -1 for a bye.
+func (p Pairing) PlayerB() int {
+	return p.playerb
+}
+
+// Result returns the games won by each player and the number of drawn games. All three are -1 while
+// the result has not been reported.
+func (p Pairing) Result() (int, int, int) {
+	return p.playeraWins, p.playerbWins, p.draws
+}
+
+// Draws returns the number of drawn games, or -1 while the result has not been reported.
+func (p Pairing) Draws() int {
+	return p.draws
+}
+
+// TableNumber returns the table the match is played at, counting from 1. Byes have no table and
+// return 0.
+func (p Pairing) TableNumber() int {
+	return p.table
+}
+
+func (p Pairing) IsIntentionalDraw() bool {
+	return p.intentionalDraw
+}
+
+// IsForfeit reports whether one or both players forfeited the match.
+func (p Pairing) IsForfeit() bool {
+	return p.forfeitA || p.forfeitB
+}
+
+// Winner returns the id of the player who won the match, or 0 if it was drawn or is not complete.
+func (p Pairing) Winner() int {
+	switch {
+	case !p.IsComplete():
+		return 0
+	case p.playeraWins > p.playerbWins:
+		return p.playera
+	case p.playerbWins > p.playeraWins:
+		return p.playerb
+	}
+	return 0
+}
+
+func (p Pairing) IsBye() bool {
+	return p.playerb == byeId
+}
+
+// IsComplete reports whether a result has been reported for the pairing. Byes are always complete.
+func (p Pairing) IsComplete() bool {
+	return p.playeraWins >= 0
+}
+
+// IsLocked reports whether the result is locked because its round is completed. Locked results can
+// only be changed with OverrideResult.
+func (p Pairing) IsLocked() bool {
+	return p.locked
+}
+
+// lock locks the results of a completed round.
+func lock(round Round) {
+	for i := range round {
+		round[i].locked = true
+	}
+}
+
+// copy returns a copy of the pairing that shares no games, extensions or reports with it.
+func (p Pairing) copy() Pairing {
+	p.games = append([]Game(nil), p.games...)
+	p.extensions = append([]Extension(nil), p.extensions...)
+	p.reports = append([]ResultReport(nil), p.reports...)
+	return p
+}
+
+// setResult records a result as reported by player id.
+func (p *Pairing) setResult(id int, wins int, losses int, draws int) {
+	if !p.IsComplete() {
+		p.reported = time.Now()
+	}
+	if p.playera == id {
+		p.playeraWins, p.playerbWins = wins, losses
+	} else {
+		p.playerbWins, p.playeraWins = wins, losses
+	}
+	p.draws = draws
+	p.intentionalDraw = false
+	p.forfeitA, p.forfeitB = false, false
+	// Games that do not add up to the result no longer describe the match.
+	if wins, losses, draws := p.gameScore(p.games); wins != p.playeraWins || losses != p.playerbWins || draws != p.draws {
+		p.games = nil
+	}
+}
+
+// gameScore returns the games won by player a and b and the drawn games.
+func (p Pairing) gameScore(games []Game) (int, int, int) {
+	wins, losses, draws := 0, 0, 0
+	for _, game := range games {
+		switch game.winner {
+		case p.playera:
+			wins++
+		case p.playerb:
+			losses++
+		default:
+			draws++
+		}
+	}
+	return wins, losses, draws
+}
+
+func findPairingIn(round Round, id int) (*Pairing, error) {
+	for i, pairing := range round {
+		if pairing.playera == id || pairing.playerb == id {
+			return &round[i], nil
+		}
+	}
+	return nil, ErrPlayerNotFound
+}
+
+// roundIndex maps player ids to the position of their pairing in the current round.
+type roundIndex struct {
+	round     int
+	pairings  Round // The indexed pairings. Pairings are only ever appended or replaced.
+	positions map[int]int
+}
+
+// currentIndex returns the positions of the pairings of the current round by player id, indexing
+// the round again if it was paired, grown or replaced since the last call.
+func (t *Tournament) currentIndex() map[int]int {
+	round := t.rounds[t.currentRound]
+	index := t.pairingIndex
+	if index != nil && index.round == t.currentRound && len(index.pairings) == len(round) && (len(round) == 0 || &index.pairings[0] == &round[0]) {
+		return index.positions
+	}
+	index = &roundIndex{round: t.currentRound, pairings: round, positions: make(map[int]int, 2*len(round))}
+	for i, pairing := range round {
+		for _, id := range []int{pairing.playera, pairing.playerb} {
+			if _, ok := index.positions[id]; !ok {
+				index.positions[id] = i
+			}
+		}
+	}
+	t.pairingIndex = index
+	return index.positions
+}
+
+// GetPairingForPlayer returns the current round pairing of a player.
+func (t *Tournament) GetPairingForPlayer(id int) (Pairing, error) {
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return Pairing{}, err
+	}
+	return *pairing, nil
+}
+
+// GetPairingsByTable returns the current round pairings by table number, byes last.
+func (t *Tournament) GetPairingsByTable() []Pairing {
+	return pairingsByTable(t.rounds[t.currentRound])
+}
+
+func pairingsByTable(round Round) []Pairing {
+	pairings := append([]Pairing{}, round...)
+	sort.SliceStable(pairings, func(i, j int) bool {
+		if pairings[i].IsBye() != pairings[j].IsBye() {
+			return !pairings[i].IsBye()
+		}
+		return pairings[i].table < pairings[j].table
+	})
+	return pairings
+}
+
+// PlayerPairing is a player's current round match from their side, a row of GetPairingsByPlayer.
+type PlayerPairing struct {
+	PlayerId       int
+	Name           string
+	Points         int
+	Table          int // 0 for a bye.
+	Bye            bool
+	OpponentId     int // -1 for a bye.
+	Opponent       string
+	OpponentPoints int
+	Pairing        Pairing
+}
+
+// GetPairingsByPlayer returns a row for every player paired in the current round, sorted by name, so
+// that players can look up their table. Phantoms only appear as opponents.
+func (t *Tournament) GetPairingsByPlayer() []PlayerPairing {
+	rows := []PlayerPairing{}
+	for _, id := range t.playerIdsByName() {
+		player := t.players[id]
+		if player.phantom {
+			continue
+		}
+		pairing, err := t.findPairing(id)
+		if err != nil {
+			continue
+		}
+		row := PlayerPairing{PlayerId: id, Name: player.name, Points: player.points, Table: pairing.table, Bye: pairing.IsBye(), OpponentId: byeId, Pairing: *pairing}
+		if !pairing.IsBye() {
+			row.OpponentId = pairing.playera + pairing.playerb - id
+			opponent := t.players[row.OpponentId]
+			row.Opponent, row.OpponentPoints = opponent.name, opponent.points
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
@@ -0,0 +1,80 @@
+package swisstools
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// benchmarkTournament returns a tournament with the default configuration of players who played
+// rounds Swiss rounds with random results.
+func benchmarkTournament(b *testing.B, players int, rounds int) Tournament {
+	b.Helper()
+	rng := rand.New(rand.NewSource(1))
+	tournament := NewTournament()
+	for i := 0; i < players; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	for round := 0; round < rounds; round++ {
+		if err := tournament.Pair(); err != nil {
+			b.Fatalf("Pair returned an error: %s", err)
+		}
+		for _, pairing := range tournament.GetRound() {
+			if !pairing.IsBye() {
+				tournament.AddResult(pairing.playera, 2, rng.Intn(2), 0)
+			}
+		}
+		tournament.NextRound()
+	}
+	return tournament
+}
+
+func benchmarkPair(b *testing.B, players int, rounds int) {
+	tournament := benchmarkTournament(b, players, rounds-1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tournament.rounds[tournament.currentRound] = Round{}
+		b.StartTimer()
+		if err := tournament.Pair(); err != nil {
+			b.Fatalf("Pair returned an error: %s", err)
+		}
+	}
+}
+
+func BenchmarkPair100Players(b *testing.B)  { benchmarkPair(b, 100, 5) }
+func BenchmarkPair1000Players(b *testing.B) { benchmarkPair(b, 1000, 10) }
+func BenchmarkPair2000Players(b *testing.B) { benchmarkPair(b, 2000, 15) }
+
+func BenchmarkGetPairingForPlayer(b *testing.B) {
+	tournament := benchmarkTournament(b, 2000, 0)
+	tournament.Pair()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tournament.GetPairingForPlayer(i%2000 + 1); err != nil {
+			b.Fatalf("GetPairingForPlayer returned an error: %s", err)
+		}
+	}
+}
+
+// benchmarkAddResultRound reports the results of a whole round of 2000 players.
+func benchmarkAddResultRound(b *testing.B, undoLimit int) {
+	tournament := benchmarkTournament(b, 2000, 0)
+	tournament.SetUndoLimit(undoLimit)
+	tournament.Pair()
+	unreported := append(Round{}, tournament.GetRound()...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(tournament.rounds[tournament.currentRound], unreported)
+		b.StartTimer()
+		for _, pairing := range unreported {
+			if !pairing.IsBye() {
+				tournament.AddResult(pairing.playerb, 2, 1, 0)
+			}
+		}
+	}
+}
+
+func BenchmarkAddResultRound(b *testing.B)            { benchmarkAddResultRound(b, defaultUndoLimit) }
+func BenchmarkAddResultRoundWithoutUndo(b *testing.B) { benchmarkAddResultRound(b, 0) }
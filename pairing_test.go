@@ -0,0 +1,115 @@
+package swisstools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPairingAccessors(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.AddPlayer("Alex")
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		if pairing.IsBye() {
+			if !pairing.IsComplete() || pairing.Winner() != pairing.playera {
+				t.Fatalf("Expecting the bye to be a complete win, got %+v.", pairing)
+			}
+			continue
+		}
+		if pairing.IsComplete() || pairing.Winner() != 0 {
+			t.Fatalf("Expecting an incomplete pairing without winner, got %+v.", pairing)
+		}
+		tournament.AddResult(pairing.playerb, 2, 1, 0)
+	}
+	for _, pairing := range tournament.GetRound() {
+		if pairing.IsBye() {
+			if pairing.PlayerB() != -1 || pairing.TableNumber() != 0 {
+				t.Fatalf("Expecting a bye without opponent or table, got %+v.", pairing)
+			}
+			continue
+		}
+		if pairing.PlayerA() != pairing.playera || pairing.PlayerB() != pairing.playerb || pairing.Draws() != 0 || pairing.TableNumber() != 1 {
+			t.Fatalf("Expecting accessors to match the pairing at table 1, got %+v.", pairing)
+		}
+		if wins, losses, draws := pairing.Result(); wins != 1 || losses != 2 || draws != 0 {
+			t.Fatalf("Expecting result 1-2-0, got %d-%d-%d.", wins, losses, draws)
+		}
+		if pairing.Winner() != pairing.playerb {
+			t.Fatalf("Expecting player %d to win, got %d.", pairing.playerb, pairing.Winner())
+		}
+	}
+}
+
+func TestPairingViews(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Sam", "Dylan", "Alex", "Kim", "Robin"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	byTable := tournament.GetPairingsByTable()
+	if len(byTable) != 3 || byTable[0].TableNumber() != 1 || byTable[1].TableNumber() != 2 || !byTable[2].IsBye() {
+		t.Fatalf("Expecting two tables followed by the bye, got %+v.", byTable)
+	}
+	byPlayer := tournament.GetPairingsByPlayer()
+	names := []string{}
+	for _, row := range byPlayer {
+		names = append(names, row.Name)
+		if row.Bye != (row.OpponentId == byeId) || (!row.Bye && tournament.players[row.OpponentId].name != row.Opponent) {
+			t.Fatalf("Expecting the opponent to match the pairing, got %+v.", row)
+		}
+		if pairing, _ := tournament.GetPairingForPlayer(row.PlayerId); pairing.TableNumber() != row.Table {
+			t.Fatalf("Expecting table %d for %s, got %+v.", row.Table, row.Name, pairing)
+		}
+	}
+	if strings.Join(names, ",") != "Alex,Dylan,Kim,Robin,Sam" {
+		t.Fatalf("Expecting the rows sorted by name, got %v.", names)
+	}
+	if _, err := tournament.GetPairingForPlayer(9); err == nil {
+		t.Fatalf("Expecting an error for an unknown player.")
+	}
+}
+
+func TestHavePlayedBeforeIndex(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	if !tournament.havePlayedBefore(1, 2) {
+		t.Fatalf("Expecting the current round pairing to count.")
+	}
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.NextRound()
+	if !tournament.havePlayedBefore(2, 1) || tournament.opponents.rounds != 1 {
+		t.Fatalf("Expecting the completed round in the index, got %+v.", tournament.opponents)
+	}
+	tournament.Reset()
+	if tournament.havePlayedBefore(1, 2) {
+		t.Fatalf("Expecting no opponents after Reset.")
+	}
+}
+
+func TestPairingIndex(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex"} {
+		tournament.AddPlayer(name)
+	}
+	if _, err := tournament.GetPairingForPlayer(1); err == nil {
+		t.Fatalf("Expecting no pairing before the round is paired.")
+	}
+	tournament.Pair()
+	for id := 1; id <= 3; id++ {
+		if pairing, err := tournament.GetPairingForPlayer(id); err != nil || (pairing.playera != id && pairing.playerb != id) {
+			t.Fatalf("Expecting the pairing of player %d, got %+v (%v).", id, pairing, err)
+		}
+	}
+	tournament.VoidRound("wrong players")
+	if _, err := tournament.GetPairingForPlayer(1); err == nil {
+		t.Fatalf("Expecting no pairing after voiding the round.")
+	}
+	tournament.AssignBye(1, 1)
+	if pairing, err := tournament.GetPairingForPlayer(1); err != nil || !pairing.IsBye() {
+		t.Fatalf("Expecting the assigned bye, got %+v (%v).", pairing, err)
+	}
+}
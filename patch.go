@@ -0,0 +1,211 @@
+package swisstools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// exportPatch holds the parts of a dump that changed between two versions of a tournament. Nil fields
+// are unchanged, which is why fields that may legitimately become empty are pointers.
+type exportPatch struct {
+	Version      string                  `json:"version"`
+	Base         string                  `json:"base"` // Checksum of the dump the patch applies to, see patchBase.
+	Config       *TournamentConfig       `json:"config,omitempty"`
+	LastId       int                     `json:"last_id"`
+	CurrentRound int                     `json:"current_round"`
+	Players      []exportPlayer          `json:"players,omitempty"` // Added and changed players.
+	RoundCount   int                     `json:"round_count"`
+	Rounds       map[int][]exportPairing `json:"rounds,omitempty"` // Changed rounds by index, round 1 first.
+	LastRanks    *map[int]int            `json:"last_ranks,omitempty"`
+	Voided       *[]exportVoided         `json:"voided,omitempty"`
+	RoundTimes   *[]exportRoundTimes     `json:"round_times,omitempty"`
+	Finals       *exportFinals           `json:"finals,omitempty"`
+	NoFinals     bool                    `json:"no_finals,omitempty"` // The finals were removed.
+	Audit        []AuditEntry            `json:"audit,omitempty"`     // Entries added to the audit log.
+	Reservations *map[int]int            `json:"table_reservations,omitempty"`
+	Constraints  *[]PairingConstraint    `json:"pairing_constraints,omitempty"`
+	StageStarts  *[]int                  `json:"stage_starts,omitempty"`
+	Final        *[]int                  `json:"final_order,omitempty"`
+}
+
+// checksum identifies the state of an export. The Checksum field must be empty.
+func (e exportTournament) checksum() (string, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// patchBase identifies the state a patch applies to. The audit log is left out as every device logs
+// the patches it applies.
+func (e exportTournament) patchBase() (string, error) {
+	e.Audit = nil
+	return e.checksum()
+}
+
+// DiffTournaments returns a patch which turns old into new when applied with ApplyPatch. The patch
+// only holds what changed, so it is much smaller than a full dump once a tournament is underway.
+func DiffTournaments(old *Tournament, new *Tournament) ([]byte, error) {
+	from, to := old.export(), new.export()
+	base, err := from.patchBase()
+	if err != nil {
+		return nil, err
+	}
+	patch := exportPatch{
+		Version:      exportVersion,
+		Base:         base,
+		LastId:       to.LastId,
+		CurrentRound: to.CurrentRound,
+		RoundCount:   len(to.Rounds),
+		Rounds:       map[int][]exportPairing{},
+	}
+	if !reflect.DeepEqual(from.Config, to.Config) {
+		patch.Config = &to.Config
+	}
+	players := map[int]exportPlayer{}
+	for _, player := range from.Players {
+		players[player.Id] = player
+	}
+	for _, player := range to.Players {
+		if previous, ok := players[player.Id]; !ok || !reflect.DeepEqual(previous, player) {
+			patch.Players = append(patch.Players, player)
+		}
+	}
+	for i, round := range to.Rounds {
+		if i >= len(from.Rounds) || !reflect.DeepEqual(from.Rounds[i], round) {
+			patch.Rounds[i] = round
+		}
+	}
+	if !reflect.DeepEqual(from.LastRanks, to.LastRanks) {
+		lastRanks := map[int]int{}
+		for id, rank := range to.LastRanks {
+			lastRanks[id] = rank
+		}
+		patch.LastRanks = &lastRanks
+	}
+	if !reflect.DeepEqual(from.Voided, to.Voided) {
+		voided := append([]exportVoided{}, to.Voided...)
+		patch.Voided = &voided
+	}
+	if !reflect.DeepEqual(from.RoundTimes, to.RoundTimes) {
+		times := append([]exportRoundTimes{}, to.RoundTimes...)
+		patch.RoundTimes = &times
+	}
+	if !reflect.DeepEqual(from.Finals, to.Finals) {
+		patch.Finals = to.Finals
+		patch.NoFinals = to.Finals == nil
+	}
+	if !reflect.DeepEqual(from.Reservations, to.Reservations) {
+		reservations := map[int]int{}
+		for table, id := range to.Reservations {
+			reservations[table] = id
+		}
+		patch.Reservations = &reservations
+	}
+	if !reflect.DeepEqual(from.Final, to.Final) {
+		final := append([]int{}, to.Final...)
+		patch.Final = &final
+	}
+	if !reflect.DeepEqual(from.StageStarts, to.StageStarts) {
+		starts := append([]int{}, to.StageStarts...)
+		patch.StageStarts = &starts
+	}
+	if !reflect.DeepEqual(from.Constraints, to.Constraints) {
+		constraints := append([]PairingConstraint{}, to.Constraints...)
+		patch.Constraints = &constraints
+	}
+	if len(to.Audit) > len(from.Audit) {
+		patch.Audit = to.Audit[len(from.Audit):]
+	}
+	return json.Marshal(patch)
+}
+
+// ApplyPatch applies a patch created by DiffTournaments. The tournament must be in the exact state the
+// patch was created from, apart from its audit log, otherwise it is left unchanged and an error is
+// returned. The patch is logged as a change of its own.
+func (t *Tournament) ApplyPatch(data []byte) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	patch := exportPatch{}
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return err
+	}
+	if patch.Version != exportVersion {
+		return ErrUnsupportedVersion
+	}
+	export := t.export()
+	base, err := export.patchBase()
+	if err != nil {
+		return err
+	}
+	if base != patch.Base {
+		return ErrPatchMismatch
+	}
+	if patch.Config != nil {
+		export.Config = *patch.Config
+	}
+	export.LastId = patch.LastId
+	export.CurrentRound = patch.CurrentRound
+	players := map[int]int{}
+	for i, player := range export.Players {
+		players[player.Id] = i
+	}
+	for _, player := range patch.Players {
+		if i, ok := players[player.Id]; ok {
+			export.Players[i] = player
+		} else {
+			export.Players = append(export.Players, player)
+		}
+	}
+	rounds := make([][]exportPairing, patch.RoundCount)
+	copy(rounds, export.Rounds)
+	for i, round := range patch.Rounds {
+		if i < 0 || i >= len(rounds) {
+			return ErrRoundOutOfRange
+		}
+		rounds[i] = round
+	}
+	export.Rounds = rounds
+	if patch.LastRanks != nil {
+		export.LastRanks = *patch.LastRanks
+	}
+	if patch.Voided != nil {
+		export.Voided = *patch.Voided
+	}
+	if patch.RoundTimes != nil {
+		export.RoundTimes = *patch.RoundTimes
+	}
+	if patch.Finals != nil || patch.NoFinals {
+		export.Finals = patch.Finals
+	}
+	if patch.Reservations != nil {
+		export.Reservations = *patch.Reservations
+	}
+	if patch.Final != nil {
+		export.Final = *patch.Final
+	}
+	if patch.StageStarts != nil {
+		export.StageStarts = *patch.StageStarts
+	}
+	if patch.Constraints != nil {
+		export.Constraints = *patch.Constraints
+	}
+	export.Audit = append(export.Audit, patch.Audit...)
+	dump, err := json.Marshal(export)
+	if err != nil {
+		return err
+	}
+	// Handlers, webhooks and other settings that are not part of a dump are kept.
+	if err := t.restore(dump); err != nil {
+		return err
+	}
+	t.audit = export.Audit
+	t.logChange("apply_patch", t.currentRound, 0, "", fmt.Sprintf("%d players, %d rounds", len(patch.Players), len(patch.Rounds)))
+	return nil
+}
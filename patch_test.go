@@ -0,0 +1,84 @@
+package swisstools
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestDiffAndApplyPatch(t *testing.T) {
+	scorekeeper := NewTournament()
+	scorekeeper.AddPlayer("Dylan")
+	scorekeeper.AddPlayer("Sam")
+	scorekeeper.AddPlayer("Alex")
+	scorekeeper.Pair()
+	dump, _ := scorekeeper.DumpTournament()
+	device, _ := LoadTournament(dump)
+
+	old, _ := LoadTournament(dump)
+	scorekeeper.AddResult(scorekeeper.GetRound()[0].playera, 2, 0, 0)
+	scorekeeper.NextRound()
+	scorekeeper.AddPlayer("Robin")
+	scorekeeper.AddPlayerNote(1, "Late")
+	patch, err := DiffTournaments(&old, &scorekeeper)
+	if err != nil {
+		t.Fatalf("DiffTournaments returned an error: %s", err)
+	}
+	dump, _ = scorekeeper.DumpTournament()
+	if len(patch) >= len(dump) {
+		t.Fatalf("Expecting the patch to be smaller than a full dump, got %d and %d bytes.", len(patch), len(dump))
+	}
+	if err := device.ApplyPatch(patch); err != nil {
+		t.Fatalf("ApplyPatch returned an error: %s", err)
+	}
+	patched, full := dumpWithoutAudit(&device), dumpWithoutAudit(&scorekeeper)
+	if !bytes.Equal(patched, full) {
+		t.Fatalf("Expecting:\n%s\ngot:\n%s", full, patched)
+	}
+	if audit := device.AuditLog(); audit[len(audit)-1].Action != "apply_patch" {
+		t.Fatalf("Expecting the patch in the audit log, got %+v.", audit[len(audit)-1])
+	}
+	if err := device.ApplyPatch(patch); err == nil {
+		t.Fatalf("Expecting an error when applying a patch twice.")
+	}
+
+	old = scorekeeper.clone()
+	scorekeeper.Reset()
+	patch, _ = DiffTournaments(&old, &scorekeeper)
+	if err := device.ApplyPatch(patch); err != nil {
+		t.Fatalf("ApplyPatch returned an error: %s", err)
+	}
+	full = dumpWithoutAudit(&scorekeeper)
+	if patched := dumpWithoutAudit(&device); !bytes.Equal(patched, full) {
+		t.Fatalf("Expecting the reset to be applied:\n%s\ngot:\n%s", full, patched)
+	}
+}
+
+func TestApplyPatchKeepsHandlers(t *testing.T) {
+	scorekeeper := NewTournament()
+	scorekeeper.AddPlayer("Dylan")
+	scorekeeper.AddPlayer("Sam")
+	dump, _ := scorekeeper.DumpTournament()
+	device, _ := LoadTournament(dump)
+	old, _ := LoadTournament(dump)
+	events := 0
+	device.OnEvent(func(Event) { events++ })
+	device.SetUndoLimit(5)
+	scorekeeper.Pair()
+	patch, _ := DiffTournaments(&old, &scorekeeper)
+	if err := device.ApplyPatch(patch); err != nil {
+		t.Fatalf("ApplyPatch returned an error: %s", err)
+	}
+	device.AddResult(1, 2, 0, 0)
+	if events != 1 || device.undoLimit != 5 {
+		t.Fatalf("Expecting the handlers and undo limit to be kept, got %d events and limit %d.", events, device.undoLimit)
+	}
+}
+
+// dumpWithoutAudit dumps a tournament without its audit log, which differs between devices.
+func dumpWithoutAudit(tournament *Tournament) []byte {
+	export := tournament.export()
+	export.Audit = nil
+	data, _ := json.Marshal(export)
+	return data
+}
@@ -0,0 +1,292 @@
+package swisstools
+
+func (t *Tournament) GetPlayerById(id int) (Player, error) {
+	player, ok := t.players[id]
+	if !ok {
+		return Player{}, ErrPlayerNotFound
+	}
+	return player, nil
+}
+
+func (t *Tournament) GetPlayerID(name string) (int, error) {
+	id, ok := t.nameIndex[nameKey(name)]
+	if !ok {
+		return 0, ErrPlayerNotFound
+	}
+	return id, nil
+}
+
+func (t *Tournament) GetPlayerByName(name string) (Player, error) {
+	id, err := t.GetPlayerID(name)
+	if err != nil {
+		return Player{}, err
+	}
+	return t.players[id], nil
+}
+
+func (t *Tournament) GetPlayerIDByExternalId(externalId string) (int, error) {
+	id, ok := t.externalIds[externalId]
+	if !ok {
+		return 0, ErrPlayerNotFound
+	}
+	return id, nil
+}
+
+func (t *Tournament) RenamePlayer(id int, name string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if name == "" {
+		return ErrEmptyName
+	}
+	if other, ok := t.nameIndex[nameKey(name)]; ok && other != id {
+		return ErrDuplicatePlayer
+	}
+	before := player.name
+	delete(t.nameIndex, nameKey(player.name))
+	player.name = name
+	t.players[id] = player
+	t.nameIndex[nameKey(name)] = id
+	t.logChange("rename_player", 0, id, before, name)
+	t.invalidateStandings()
+	return nil
+}
+
+// SetExternalId links a player to their id in an external system. An empty externalId removes the link.
+func (t *Tournament) SetExternalId(id int, externalId string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if other, ok := t.externalIds[externalId]; ok && other != id {
+		return ErrDuplicateExternalId
+	}
+	before := player.externalId
+	if player.externalId != "" {
+		delete(t.externalIds, player.externalId)
+	}
+	player.externalId = externalId
+	t.players[id] = player
+	if externalId != "" {
+		t.externalIds[externalId] = id
+	}
+	t.logChange("set_external_id", 0, id, before, externalId)
+	return nil
+}
+
+func (t *Tournament) AddPlayerNote(id int, note string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if note == "" {
+		return ErrEmptyNote
+	}
+	player.notes = append(player.notes, note)
+	t.players[id] = player
+	t.logChange("add_note", 0, id, "", note)
+	return nil
+}
+
+// SetPlayerMeta attaches a value to a player under key, such as a membership number or a chat
+// handle, for integrations. An empty value removes the key.
+func (t *Tournament) SetPlayerMeta(id int, key string, value string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if key == "" {
+		return ErrEmptyKey
+	}
+	before := ""
+	if old, ok := player.meta[key]; ok {
+		before = key + "=" + old
+	}
+	after := ""
+	if value != "" {
+		after = key + "=" + value
+	}
+	if value == "" {
+		delete(player.meta, key)
+	} else {
+		if player.meta == nil {
+			player.meta = map[string]string{}
+		}
+		player.meta[key] = value
+	}
+	t.players[id] = player
+	t.logChange("set_meta", 0, id, before, after)
+	return nil
+}
+
+// GetPlayerMeta returns a copy of the values attached to a player with SetPlayerMeta.
+func (t *Tournament) GetPlayerMeta(id int) (map[string]string, error) {
+	player, ok := t.players[id]
+	if !ok {
+		return nil, ErrPlayerNotFound
+	}
+	return copyMeta(player.meta), nil
+}
+
+func copyMeta(meta map[string]string) map[string]string {
+	copied := map[string]string{}
+	for key, value := range meta {
+		copied[key] = value
+	}
+	return copied
+}
+
+// GetPlayerNotes returns a copy of the player's notes, oldest first.
+func (t *Tournament) GetPlayerNotes(id int) ([]string, error) {
+	player, ok := t.players[id]
+	if !ok {
+		return nil, ErrPlayerNotFound
+	}
+	return append([]string{}, player.notes...), nil
+}
+
+// RemovePlayerNote removes the note at index, as returned by GetPlayerNotes.
+func (t *Tournament) RemovePlayerNote(id int, index int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if index < 0 || index >= len(player.notes) {
+		return ErrNoteNotFound
+	}
+	before := player.notes[index]
+	player.notes = append(player.notes[:index:index], player.notes[index+1:]...)
+	t.players[id] = player
+	t.logChange("remove_note", 0, id, before, "")
+	return nil
+}
+
+// DropPlayer removes a player from future rounds. They keep their results and their place in the
+// standings. A pairing they already have in the current round is left as it is.
+func (t *Tournament) DropPlayer(id int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if player.dropped {
+		return ErrPlayerDropped
+	}
+	t.recordChange(t.snapshot())
+	player.dropped = true
+	t.players[id] = player
+	t.invalidateStandings()
+	t.logChange("drop_player", t.currentRound, id, "", "dropped")
+	t.emit(Event{Type: PlayerDropped, Round: t.currentRound, PlayerId: id})
+	return nil
+}
+
+// PlayerView is a read-only copy of a player's details for display.
+type PlayerView struct {
+	Id         int
+	Name       string
+	ExternalId string
+	Points     int
+	Wins       int
+	Losses     int
+	Draws      int
+	Dropped    bool
+	// Disqualified players are left out of the standings.
+	Disqualified       bool
+	DisqualifiedReason string
+	Notes              []string
+	Decklist           *Decklist // Nil if no deck was registered.
+	ByeRounds          []int     // Rounds in which the player had a bye.
+	Members            []string  // Members of a team, see AddTeam.
+	CheckedIn          bool
+	Meta               map[string]string // Values attached with SetPlayerMeta.
+}
+
+// GetPlayerView returns a player's details. Changing the result does not change the tournament.
+func (t *Tournament) GetPlayerView(id int) (PlayerView, error) {
+	player, ok := t.players[id]
+	if !ok {
+		return PlayerView{}, ErrPlayerNotFound
+	}
+	return PlayerView{
+		Id:                 id,
+		Name:               player.name,
+		ExternalId:         player.externalId,
+		Points:             player.points,
+		Wins:               player.wins,
+		Losses:             player.losses,
+		Draws:              player.draws,
+		Dropped:            player.dropped,
+		Disqualified:       player.disqualified,
+		DisqualifiedReason: player.disqualifiedReason,
+		Notes:              append([]string{}, player.notes...),
+		Decklist:           player.decklist.copy(),
+		ByeRounds:          t.byeRounds(id),
+		Members:            append([]string(nil), player.members...),
+		CheckedIn:          player.checkedIn,
+		Meta:               copyMeta(player.meta),
+	}, nil
+}
+
+// PlayerViews returns the details of every player sorted by name. Phantoms are left out.
+func (t *Tournament) PlayerViews() []PlayerView {
+	views := []PlayerView{}
+	for _, id := range t.playerIdsByName() {
+		if t.players[id].phantom {
+			continue
+		}
+		view, _ := t.GetPlayerView(id)
+		views = append(views, view)
+	}
+	return views
+}
+
+// DisqualifyPlayer removes a player from the event for the given reason. Unlike a dropped player
+// they are left out of the standings, though their results still count for their opponents'
+// tiebreakers. An unreported current round match is recorded as a forfeit win for the opponent.
+func (t *Tournament) DisqualifyPlayer(id int, reason string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	if player.disqualified {
+		return ErrPlayerDisqualified
+	}
+	t.recordChange(t.snapshot())
+	if pairing, err := t.forfeitablePairing(id); err == nil {
+		opponent := pairing.playera
+		if opponent == id {
+			opponent = pairing.playerb
+		}
+		t.forfeit(pairing, opponent)
+	}
+	player.dropped = true
+	player.disqualified = true
+	player.disqualifiedReason = reason
+	t.players[id] = player
+	t.invalidateStandings()
+	t.logChange("disqualify_player", t.currentRound, id, "", reason)
+	t.emit(Event{Type: PlayerDropped, Round: t.currentRound, PlayerId: id})
+	return nil
+}
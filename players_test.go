@@ -0,0 +1,171 @@
+package swisstools
+
+import "testing"
+
+func TestNameIndex(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	if err := tournament.AddPlayer("Sam"); err == nil {
+		t.Fatal("Duplicate name but AddPlayer did not return an error.")
+	}
+	if err := tournament.RenamePlayer(2, "Samantha"); err != nil {
+		t.Fatalf("RenamePlayer returned an error: %s", err)
+	}
+	if _, err := tournament.GetPlayerID("Sam"); err == nil {
+		t.Fatal("Old name still resolves after rename.")
+	}
+	if id, err := tournament.GetPlayerID("Samantha"); err != nil || id != 2 {
+		t.Fatalf("Expecting id 2 for Samantha, got %d.", id)
+	}
+	tournament.SetExternalId(1, "DCI-1234")
+	if id, err := tournament.GetPlayerIDByExternalId("DCI-1234"); err != nil || id != 1 {
+		t.Fatalf("Expecting id 1 for DCI-1234, got %d.", id)
+	}
+	if err := tournament.SetExternalId(2, "DCI-1234"); err == nil {
+		t.Fatal("Duplicate external id but SetExternalId did not return an error.")
+	}
+}
+
+func TestPlayerNotes(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayerNote(1, "Late entry")
+	tournament.AddPlayerNote(1, "Deck check round 2")
+	if err := tournament.RemovePlayerNote(1, 0); err != nil {
+		t.Fatalf("RemovePlayerNote returned an error: %s", err)
+	}
+	data, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(data)
+	notes, err := loaded.GetPlayerNotes(1)
+	if err != nil || len(notes) != 1 || notes[0] != "Deck check round 2" {
+		t.Fatalf("Expecting the remaining note to survive a dump, got %v.", notes)
+	}
+	if err := tournament.AddPlayerNote(7, "Missing"); err == nil {
+		t.Fatal("Unknown player but AddPlayerNote did not return an error.")
+	}
+}
+
+func TestPhantomPlayers(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.AddPlayer("Alex")
+	tournament.AddPhantom("Phantom 1")
+	tournament.AddPhantom("Phantom 2")
+	tournament.AddPhantom("Phantom 3")
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		if !pairing.IsBye() && tournament.players[pairing.playera].phantom && tournament.players[pairing.playerb].phantom {
+			t.Fatalf("Phantoms paired against each other: %+v.", pairing)
+		}
+		if !pairing.IsBye() && (tournament.players[pairing.playera].phantom || tournament.players[pairing.playerb].phantom) {
+			winner := pairing.Winner()
+			if winner == 0 || tournament.players[winner].phantom {
+				t.Fatalf("Expecting the real player to beat the phantom, got %+v.", pairing)
+			}
+		}
+	}
+	if !tournament.IsRoundComplete() {
+		t.Fatal("Expecting every match against a phantom to be decided.")
+	}
+	tournament.NextRound()
+	standings := tournament.GetStandings()
+	if len(standings) != 3 {
+		t.Fatalf("Expecting only the 3 real players in the standings, got %d.", len(standings))
+	}
+	for _, standing := range standings {
+		if standing.Points != 3 || standing.Tiebreakers.OpponentMatchWinPercentage != 0 {
+			t.Fatalf("Expecting a bye-like win against the phantom, got %+v.", standing)
+		}
+	}
+}
+
+func TestPlayerView(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Sam")
+	tournament.AddPlayer("Dylan")
+	tournament.SetExternalId(1, "M-1")
+	tournament.AddPlayerNote(1, "Late")
+	tournament.SetDecklist(1, Decklist{Main: []Card{{Count: 4, Name: "Lightning Bolt"}}})
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.NextRound()
+	tournament.DropPlayer(1)
+	view, err := tournament.GetPlayerView(1)
+	if err != nil {
+		t.Fatalf("GetPlayerView returned an error: %s", err)
+	}
+	if view.Name != "Sam" || view.ExternalId != "M-1" || view.Points != 3 || view.Wins != 1 || !view.Dropped || view.Notes[0] != "Late" || view.Decklist.Main[0].Name != "Lightning Bolt" {
+		t.Fatalf("Expecting Sam's details, got %+v.", view)
+	}
+	view.Notes[0] = "Changed"
+	view.Decklist.Main[0].Count = 1
+	if again, _ := tournament.GetPlayerView(1); again.Notes[0] != "Late" || again.Decklist.Main[0].Count != 4 {
+		t.Fatalf("Expecting the view to be a copy, got %+v.", again)
+	}
+	if views := tournament.PlayerViews(); len(views) != 2 || views[0].Name != "Dylan" || views[1].Decklist == nil {
+		t.Fatalf("Expecting views sorted by name, got %+v.", views)
+	}
+	if _, err := tournament.GetPlayerView(3); err == nil {
+		t.Fatalf("Expecting an error for an unknown player.")
+	}
+}
+
+func TestDisqualifyPlayer(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.AddPlayer("Alex")
+	tournament.AddPlayer("Kim")
+	tournament.Pair()
+	round := tournament.GetRound()
+	cheater, opponent := round[0].playera, round[0].playerb
+	if err := tournament.DisqualifyPlayer(cheater, "Marked cards"); err != nil {
+		t.Fatalf("DisqualifyPlayer returned an error: %s", err)
+	}
+	if pairing := tournament.GetRound()[0]; !pairing.IsForfeit() || pairing.Winner() != opponent {
+		t.Fatalf("Expecting the opponent to win by forfeit, got %+v.", pairing)
+	}
+	tournament.AddResult(round[1].playera, 2, 0, 0)
+	tournament.NextRound()
+	standings := tournament.GetStandings()
+	if len(standings) != 3 {
+		t.Fatalf("Expecting the disqualified player to be left out of the standings, got %+v.", standings)
+	}
+	if view, _ := tournament.GetPlayerView(cheater); !view.Disqualified || !view.Dropped || view.DisqualifiedReason != "Marked cards" {
+		t.Fatalf("Expecting a disqualified player view, got %+v.", view)
+	}
+	dump, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(dump)
+	if view, _ := loaded.GetPlayerView(cheater); !view.Disqualified {
+		t.Fatalf("Expecting the disqualification to be kept in the dump.")
+	}
+	for i := 0; i < 3; i++ {
+		tournament.Undo()
+	}
+	if view, _ := tournament.GetPlayerView(cheater); view.Disqualified || len(tournament.GetStandings()) != 4 || tournament.GetRound()[0].IsComplete() {
+		t.Fatalf("Expecting undo to revert the disqualification and the forfeit, got %+v.", view)
+	}
+}
+
+func TestPlayerMeta(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.SetPlayerMeta(1, "discord", "dylan#1234")
+	tournament.SetPlayerMeta(1, "table_preference", "accessible")
+	tournament.SetPlayerMeta(1, "table_preference", "")
+	if err := tournament.SetPlayerMeta(2, "discord", "sam"); err == nil {
+		t.Fatalf("Expecting an error for an unknown player.")
+	}
+	data, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(data)
+	meta, _ := loaded.GetPlayerMeta(1)
+	if len(meta) != 1 || meta["discord"] != "dylan#1234" {
+		t.Fatalf("Expecting only the Discord handle to survive a dump, got %v.", meta)
+	}
+	meta["discord"] = "changed"
+	if view, _ := loaded.GetPlayerView(1); view.Meta["discord"] != "dylan#1234" {
+		t.Fatalf("Expecting GetPlayerMeta to return a copy, got %v.", view.Meta)
+	}
+}
@@ -0,0 +1,61 @@
+package swisstools
+
+import (
+	"sort"
+)
+
+var presets = map[string]TournamentConfig{
+	// Magic: The Gathering tournament rules.
+	"mtg": {
+		PointsWin:            3,
+		PointsDraw:           1,
+		MinimumWinPercentage: 1.0 / 3,
+		Tiebreakers:          []Tiebreaker{OpponentMatchWinPercentage, GameWinPercentage, OpponentGameWinPercentage},
+	},
+	// Pokémon TCG play! rules.
+	"pokemon": {
+		PointsWin:            3,
+		PointsDraw:           1,
+		MinimumWinPercentage: 0.25,
+		Tiebreakers:          []Tiebreaker{OpponentMatchWinPercentage, OpponentOpponentMatchWinPercentage},
+	},
+	// Konami Yu-Gi-Oh! TCG. Byes count as wins but not as opponents and there is no win percentage floor.
+	"yugioh": {
+		PointsWin:   3,
+		PointsDraw:  1,
+		Tiebreakers: []Tiebreaker{OpponentMatchWinPercentage, OpponentOpponentMatchWinPercentage},
+	},
+	// FIDE Swiss. Points are doubled (2 for a win, 1 for a draw) to keep them whole numbers.
+	"chess": {
+		PointsWin:   2,
+		PointsDraw:  1,
+		Chess:       true,
+		Tiebreakers: []Tiebreaker{Buchholz, SonnebornBerger},
+	},
+	// Plain 3-1-0 scoring ordered by strength of schedule.
+	"generic": {
+		PointsWin:   3,
+		PointsDraw:  1,
+		Tiebreakers: []Tiebreaker{OpponentMatchWinPercentage},
+	},
+}
+
+// Preset returns the configuration of a common tournament system by name. See Presets for the names.
+func Preset(name string) (TournamentConfig, error) {
+	config, ok := presets[name]
+	if !ok {
+		return TournamentConfig{}, ErrUnknownPreset
+	}
+	config.Tiebreakers = append([]Tiebreaker{}, config.Tiebreakers...)
+	return config, nil
+}
+
+// Presets returns the names of all presets in sorted order.
+func Presets() []string {
+	names := []string{}
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
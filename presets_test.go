@@ -0,0 +1,58 @@
+package swisstools
+
+import "testing"
+
+func TestPresetTiebreakers(t *testing.T) {
+	config, err := Preset("mtg")
+	if err != nil {
+		t.Fatalf("Preset returned an error: %s", err)
+	}
+	tournament := NewTournamentWithConfig(config)
+	tournament.AddPlayer("Zed")
+	tournament.AddPlayer("Bo")
+	tournament.AddPlayer("Amy")
+	tournament.AddPlayer("Cy")
+	tournament.rounds[1] = Round{
+		{playera: 1, playerb: 2, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 3, playerb: 4, playeraWins: 2, playerbWins: 1, draws: 0},
+	}
+	tournament.NextRound()
+	standings := tournament.GetStandings()
+	// Zed and Amy are tied on points and opponents' match win percentage, Zed won more games.
+	if standings[0].Name != "Zed" || standings[1].Name != "Amy" {
+		t.Fatalf("Expecting Zed ahead of Amy on game win percentage, got %+v.", standings)
+	}
+	if gw := standings[1].Tiebreakers.GameWinPercentage; gw < 0.66 || gw > 0.67 {
+		t.Fatalf("Expecting a game win percentage of 2/3 for Amy, got %f.", gw)
+	}
+	if _, err := Preset("croquet"); err == nil {
+		t.Fatal("Unknown preset but Preset did not return an error.")
+	}
+}
+
+func TestUnfinishedGamePolicy(t *testing.T) {
+	for _, policy := range []UnfinishedGamePolicy{UnfinishedAsDraw, UnfinishedIgnored} {
+		config, _ := Preset("mtg")
+		config.UnfinishedGames = policy
+		tournament := NewTournamentWithConfig(config)
+		tournament.AddPlayer("Dylan")
+		tournament.AddPlayer("Sam")
+		tournament.Pair()
+		tournament.AddResult(1, 1, 0, 0)
+		if err := tournament.SetUnfinishedGames(1, 1); err != nil {
+			t.Fatalf("SetUnfinishedGames returned an error: %s", err)
+		}
+		tournament.NextRound()
+		if stats := tournament.GetStatistics(); stats.UnfinishedGames != 1 {
+			t.Fatalf("Expecting 1 unfinished game in the statistics, got %d.", stats.UnfinishedGames)
+		}
+		standing, _ := tournament.GetStandingForPlayer(1)
+		expected := 1.0
+		if policy == UnfinishedAsDraw {
+			expected = 4.0 / 6
+		}
+		if gw := standing.Tiebreakers.GameWinPercentage; gw < expected-0.001 || gw > expected+0.001 {
+			t.Fatalf("Expecting a game win percentage of %f with policy %q, got %f.", expected, policy, gw)
+		}
+	}
+}
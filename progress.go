@@ -0,0 +1,56 @@
+package swisstools
+
+import (
+	"time"
+)
+
+type Progress struct {
+	CompletedRounds int
+	RemainingRounds int           // Rounds not completed yet, including the current round.
+	Turnover        time.Duration // Average observed time between finishing a round and pairing the next.
+	EstimatedFinish time.Time
+}
+
+// EstimateProgress estimates when the event finishes, as of now, from the number of remaining rounds,
+// TournamentConfig.RoundLength and the turnover time observed between rounds so far. It requires
+// TournamentConfig.Rounds and TournamentConfig.RoundLength to be set.
+func (t *Tournament) EstimateProgress(now time.Time) (Progress, error) {
+	if t.config.Rounds == 0 {
+		return Progress{}, ErrRoundsNotConfigured
+	}
+	if t.config.RoundLength == 0 {
+		return Progress{}, ErrRoundLengthNotConfigured
+	}
+	progress := Progress{CompletedRounds: t.CompletedRounds()}
+	progress.RemainingRounds = t.config.Rounds - progress.CompletedRounds
+	if progress.RemainingRounds < 0 {
+		progress.RemainingRounds = 0
+	}
+
+	observed := 0
+	var total time.Duration
+	for r := 1; r < t.currentRound; r++ {
+		paired := t.times[r+1].paired
+		finished := t.times[r].finished
+		if !paired.IsZero() && !finished.IsZero() && paired.After(finished) {
+			total += paired.Sub(finished)
+			observed++
+		}
+	}
+	if observed > 0 {
+		progress.Turnover = total / time.Duration(observed)
+	}
+
+	finish := now
+	remaining := progress.RemainingRounds
+	if paired := t.times[t.currentRound].paired; remaining > 0 && !paired.IsZero() {
+		// The current round is under way and ends after the round length at the earliest.
+		finish = paired.Add(t.config.RoundLength)
+		if finish.Before(now) {
+			finish = now
+		}
+		remaining--
+	}
+	progress.EstimatedFinish = finish.Add(time.Duration(remaining) * (progress.Turnover + t.config.RoundLength))
+	return progress, nil
+}
@@ -0,0 +1,29 @@
+package swisstools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateProgress(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Rounds: 3, RoundLength: 50 * time.Minute})
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	start := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.NextRound()
+	tournament.Pair()
+	tournament.times[1] = roundTimes{paired: start, finished: start.Add(45 * time.Minute)}
+	tournament.times[2].paired = start.Add(55 * time.Minute)
+
+	progress, err := tournament.EstimateProgress(start.Add(60 * time.Minute))
+	if err != nil {
+		t.Fatalf("EstimateProgress returned an error: %s", err)
+	}
+	// Round 2 ends at 11:45, round 3 is paired 10 minutes later and ends at 12:45.
+	expected := start.Add(165 * time.Minute)
+	if progress.RemainingRounds != 2 || progress.Turnover != 10*time.Minute || !progress.EstimatedFinish.Equal(expected) {
+		t.Fatalf("Expecting 2 rounds remaining, 10m turnover and finish at %s, got %+v.", expected, progress)
+	}
+}
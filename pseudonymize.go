@@ -0,0 +1,93 @@
+package swisstools
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"sort"
+)
+
+// DumpPseudonymized serializes the tournament like DumpTournament but replaces names and external ids
+// with pseudonyms, so that event data can be shared for research. Pseudonyms are derived from key with
+// HMAC-SHA256: the same key gives a player the same pseudonym in every export, based on their external
+// id if they have one and their name otherwise. Keep the key secret.
+//
+// Free text, such as notes, game notes, the reasons for disqualifications, extensions and voided
+// rounds, as well as decklists, metadata and the audit log are left out. Ratings are rounded to the
+// nearest 100 and seeds are replaced by their rank, lowest first, so that a player cannot be found by
+// their exact rating. Pairings, results, tables and timestamps are kept.
+func (t *Tournament) DumpPseudonymized(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, ErrEmptyKey
+	}
+	pseudonym := func(prefix string, value string) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(prefix + value))
+		return prefix + hex.EncodeToString(mac.Sum(nil))[:12]
+	}
+	export := t.export()
+	seedRanks := map[int]int{}
+	seeds := []int{}
+	for _, player := range export.Players {
+		if _, ok := seedRanks[player.Seed]; !ok && player.Seed != 0 {
+			seedRanks[player.Seed] = 0
+			seeds = append(seeds, player.Seed)
+		}
+	}
+	sort.Ints(seeds)
+	for i, seed := range seeds {
+		seedRanks[seed] = i + 1
+	}
+	for i, player := range export.Players {
+		basis := "name:" + player.Name
+		if player.ExternalId != "" {
+			basis = "id:" + player.ExternalId
+			export.Players[i].ExternalId = pseudonym("X-", player.ExternalId)
+		}
+		export.Players[i].Name = pseudonym("P-", basis)
+		export.Players[i].Notes = []string{}
+		export.Players[i].DisqualifiedReason = ""
+		export.Players[i].Meta = nil
+		export.Players[i].Decklist = nil
+		if player.Rating != nil {
+			export.Players[i].Rating = &Rating{Value: math.Round(player.Rating.Value/100) * 100}
+		}
+		export.Players[i].Seed = seedRanks[player.Seed]
+		if len(player.Members) > 0 {
+			members := []string{}
+			for _, member := range player.Members {
+				members = append(members, pseudonym("P-", "name:"+member))
+			}
+			export.Players[i].Members = members
+		}
+	}
+	for i := range export.Voided {
+		export.Voided[i].Reason = ""
+		scrubPairings(export.Voided[i].Pairings)
+	}
+	for _, round := range export.Rounds {
+		scrubPairings(round)
+	}
+	if export.Finals != nil {
+		for _, round := range export.Finals.Rounds {
+			scrubPairings(round)
+		}
+	}
+	// The audit log holds names.
+	export.Audit = nil
+	return json.Marshal(export)
+}
+
+// scrubPairings removes the free text of game notes and extension reasons.
+func scrubPairings(pairings []exportPairing) {
+	for _, pairing := range pairings {
+		for i := range pairing.Games {
+			pairing.Games[i].Notes = ""
+		}
+		for i := range pairing.Extensions {
+			pairing.Extensions[i].Reason = ""
+		}
+	}
+}
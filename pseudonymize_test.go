@@ -0,0 +1,50 @@
+package swisstools
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDumpPseudonymized(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.SetExternalId(2, "DCI-42")
+	tournament.AddPlayerNote(1, "Called Dylan's mom")
+	tournament.SetRating(1, Rating{Value: 1873})
+	tournament.SetSeed(1, 1873)
+	tournament.SetSeed(2, 1650)
+	tournament.SetDecklist(1, Decklist{Main: []Card{{Count: 60, Name: "Signature Card"}}})
+	tournament.Pair()
+	tournament.AddTimeExtension(1, time.Minute, "Deck check of the featured player")
+	tournament.AddGameResult(1, 1, 1, 1, "Game loss for tardiness")
+	tournament.AddGameResult(1, 2, 2, 1, "")
+	tournament.NextRound()
+	data, err := tournament.DumpPseudonymized([]byte("secret"))
+	if err != nil {
+		t.Fatalf("DumpPseudonymized returned an error: %s", err)
+	}
+	if strings.Contains(string(data), "Dylan") || strings.Contains(string(data), "Sam") || strings.Contains(string(data), "DCI-42") {
+		t.Fatalf("Identities leaked into the export: %s", data)
+	}
+	for _, secret := range []string{"1873", "Signature Card", "Deck check", "tardiness"} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("Expecting %q to be left out of the export: %s", secret, data)
+		}
+	}
+	again, _ := tournament.DumpPseudonymized([]byte("secret"))
+	if string(again) != string(data) {
+		t.Fatal("Pseudonyms are not stable for the same key.")
+	}
+	loaded, err := LoadTournament(data)
+	if err != nil {
+		t.Fatalf("Pseudonymized dump could not be loaded: %s", err)
+	}
+	if loaded.GetStandings()[0].Points != 3 || len(loaded.rounds[1]) != 1 {
+		t.Fatal("Pairing and result structure was not preserved.")
+	}
+	if loaded.players[1].rating.Value != 1900 || loaded.players[1].seed != 2 || loaded.players[2].seed != 1 {
+		t.Fatalf("Expecting a rounded rating and seed ranks, got %+v and %+v.", loaded.players[1], loaded.players[2])
+	}
+}
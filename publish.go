@@ -0,0 +1,70 @@
+package swisstools
+
+import (
+	"fmt"
+	"time"
+)
+
+// PairingsPublished reports whether the pairings of the current round are published, see
+// TournamentConfig.DraftPairings. Pairings are always published without draft pairings.
+func (t *Tournament) PairingsPublished() bool {
+	return len(t.rounds[t.currentRound]) > 0 && t.checkPublished() == nil
+}
+
+// PublishPairings releases the draft pairings of the current round made by Pair with
+// TournamentConfig.DraftPairings. They can no longer be discarded and results can be entered.
+func (t *Tournament) PublishPairings() error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if len(t.rounds[t.currentRound]) == 0 {
+		return ErrRoundNotPaired
+	}
+	if !t.times[t.currentRound].published.IsZero() {
+		return ErrPairingsPublished
+	}
+	t.recordChange(t.snapshot())
+	t.times[t.currentRound].published = time.Now()
+	t.logChange("publish_pairings", t.currentRound, 0, "", fmt.Sprintf("%d pairings", len(t.rounds[t.currentRound])))
+	t.emit(Event{Type: RoundPaired, Round: t.currentRound})
+	return nil
+}
+
+// DiscardPairings removes the draft pairings of the current round so that it can be paired again,
+// for example after correcting the registrations. Unlike VoidRound nothing is kept of them.
+func (t *Tournament) DiscardPairings() error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if len(t.rounds[t.currentRound]) == 0 {
+		return ErrRoundNotPaired
+	}
+	if !t.times[t.currentRound].published.IsZero() {
+		return ErrPairingsPublished
+	}
+	t.recordChange(t.snapshot())
+	t.rounds[t.currentRound] = Round{}
+	t.times[t.currentRound] = roundTimes{}
+	t.updatePlayerStandings()
+	t.logChange("discard_pairings", t.currentRound, 0, "", "")
+	return nil
+}
+
+// announcePairings publishes the new pairings of the current round unless
+// TournamentConfig.DraftPairings holds them back for review.
+func (t *Tournament) announcePairings() {
+	if t.config.DraftPairings {
+		return
+	}
+	t.times[t.currentRound].published = t.times[t.currentRound].paired
+	t.emit(Event{Type: RoundPaired, Round: t.currentRound})
+}
+
+// checkPublished returns an error while the current round pairings are a draft, for methods which
+// enter results.
+func (t *Tournament) checkPublished() error {
+	if t.config.DraftPairings && t.times[t.currentRound].published.IsZero() {
+		return ErrPairingsNotPublished
+	}
+	return nil
+}
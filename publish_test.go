@@ -0,0 +1,48 @@
+package swisstools
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDraftPairings(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{DraftPairings: true})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	paired := 0
+	tournament.OnEvent(func(event Event) {
+		if event.Type == RoundPaired {
+			paired++
+		}
+	})
+	tournament.Pair()
+	if tournament.PairingsPublished() || paired != 0 {
+		t.Fatalf("Expecting draft pairings without a RoundPaired event, got %d events.", paired)
+	}
+	if err := tournament.AddResult(1, 2, 0, 0); !errors.Is(err, ErrPairingsNotPublished) {
+		t.Fatalf("Expecting ErrPairingsNotPublished, got %v.", err)
+	}
+	if err := tournament.AddForfeit(1); !errors.Is(err, ErrPairingsNotPublished) {
+		t.Fatalf("Expecting ErrPairingsNotPublished for a forfeit, got %v.", err)
+	}
+	if err := tournament.DiscardPairings(); err != nil || len(tournament.GetRound()) != 0 {
+		t.Fatalf("Expecting the draft discarded, got %v.", err)
+	}
+	tournament.Pair()
+	if err := tournament.PublishPairings(); err != nil || !tournament.PairingsPublished() || paired != 1 {
+		t.Fatalf("Expecting the pairings published with a RoundPaired event, got %v and %d events.", err, paired)
+	}
+	if err := tournament.DiscardPairings(); !errors.Is(err, ErrPairingsPublished) {
+		t.Fatalf("Expecting ErrPairingsPublished, got %v.", err)
+	}
+	if err := tournament.AddResult(1, 2, 0, 0); err != nil {
+		t.Fatalf("AddResult returned an error: %s", err)
+	}
+
+	data, _ := tournament.DumpTournament()
+	loaded, err := LoadTournament(data)
+	if err != nil || !loaded.PairingsPublished() {
+		t.Fatalf("Expecting the pairings to stay published after loading, got %v.", err)
+	}
+}
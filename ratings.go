@@ -0,0 +1,208 @@
+package swisstools
+
+import (
+	"math"
+	"strconv"
+)
+
+// Rating is a player's strength in a rating system. Systems which do not track a deviation or a
+// volatility, such as Elo, leave them at 0.
+type Rating struct {
+	Value      float64 `json:"value"`
+	Deviation  float64 `json:"deviation,omitempty"`
+	Volatility float64 `json:"volatility,omitempty"`
+}
+
+// RatedGame is one match a player played in a rating period, a round, with its score for the player:
+// 1 for a win, 0.5 for a draw and 0 for a loss.
+type RatedGame struct {
+	Opponent Rating
+	Score    float64
+}
+
+// RatingSystem computes rating changes from match results.
+type RatingSystem interface {
+	// Initial returns the rating of a player who has none.
+	Initial() Rating
+	// Update returns a player's rating after a round given their rating and their games in it, all
+	// ratings as they were before the round.
+	Update(rating Rating, games []RatedGame) Rating
+}
+
+// Elo is the Elo rating system with K-factor K, 20 if it is 0. Unrated players start at Start, 1500 if
+// it is 0.
+type Elo struct {
+	K     float64
+	Start float64
+}
+
+func (e Elo) Initial() Rating {
+	if e.Start == 0 {
+		return Rating{Value: 1500}
+	}
+	return Rating{Value: e.Start}
+}
+
+func (e Elo) Update(rating Rating, games []RatedGame) Rating {
+	k := e.K
+	if k == 0 {
+		k = 20
+	}
+	change := 0.0
+	for _, game := range games {
+		expected := 1 / (1 + math.Pow(10, (game.Opponent.Value-rating.Value)/400))
+		change += k * (game.Score - expected)
+	}
+	return Rating{Value: rating.Value + change}
+}
+
+// Glicko2 is Glickman's Glicko-2 rating system with system constant Tau, 0.5 if it is 0. Unrated
+// players start at 1500 with deviation 350 and volatility 0.06, the same deviation and volatility
+// are assumed for ratings without them.
+type Glicko2 struct {
+	Tau float64
+}
+
+// glicko2Scale converts between the Glicko and the Glicko-2 scale.
+const glicko2Scale = 173.7178
+
+func (g Glicko2) Initial() Rating {
+	return Rating{Value: 1500, Deviation: 350, Volatility: 0.06}
+}
+
+func (g Glicko2) complete(rating Rating) Rating {
+	if rating.Deviation == 0 {
+		rating.Deviation = 350
+	}
+	if rating.Volatility == 0 {
+		rating.Volatility = 0.06
+	}
+	return rating
+}
+
+func (g Glicko2) Update(rating Rating, games []RatedGame) Rating {
+	tau := g.Tau
+	if tau == 0 {
+		tau = 0.5
+	}
+	rating = g.complete(rating)
+	mu, phi, sigma := (rating.Value-1500)/glicko2Scale, rating.Deviation/glicko2Scale, rating.Volatility
+	if len(games) == 0 {
+		return Rating{Value: rating.Value, Deviation: math.Sqrt(phi*phi+sigma*sigma) * glicko2Scale, Volatility: sigma}
+	}
+	variance, improvement := 0.0, 0.0
+	for _, game := range games {
+		opponent := g.complete(game.Opponent)
+		muJ, phiJ := (opponent.Value-1500)/glicko2Scale, opponent.Deviation/glicko2Scale
+		gPhi := 1 / math.Sqrt(1+3*phiJ*phiJ/(math.Pi*math.Pi))
+		expected := 1 / (1 + math.Exp(-gPhi*(mu-muJ)))
+		variance += gPhi * gPhi * expected * (1 - expected)
+		improvement += gPhi * (game.Score - expected)
+	}
+	variance = 1 / variance
+	delta := variance * improvement
+
+	// New volatility by the Illinois algorithm.
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		return ex*(delta*delta-phi*phi-variance-ex)/(2*(phi*phi+variance+ex)*(phi*phi+variance+ex)) - (x-a)/(tau*tau)
+	}
+	A, B := a, 0.0
+	if delta*delta > phi*phi+variance {
+		B = math.Log(delta*delta - phi*phi - variance)
+	} else {
+		k := 1.0
+		for f(a-k*tau) < 0 {
+			k++
+		}
+		B = a - k*tau
+	}
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > 0.000001 {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+	sigma = math.Exp(A / 2)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/variance)
+	mu += phi * phi * improvement
+	return Rating{Value: mu*glicko2Scale + 1500, Deviation: phi * glicko2Scale, Volatility: sigma}
+}
+
+// SetRatingSystem sets the system used to compute rating changes shown in the standings. The system
+// is not part of a dump and has to be set again after LoadTournament.
+func (t *Tournament) SetRatingSystem(system RatingSystem) {
+	t.ratingSystem = system
+	t.invalidateStandings()
+}
+
+// SetRating sets a player's rating before the tournament. Players registered with a rating start with
+// it.
+func (t *Tournament) SetRating(id int, rating Rating) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	before := player.rating
+	player.rating = rating
+	t.players[id] = player
+	t.logChange("set_rating", 0, id, describeRating(before), describeRating(rating))
+	t.invalidateStandings()
+	return nil
+}
+
+// describeRating formats a rating for the audit log.
+func describeRating(rating Rating) string {
+	return strconv.FormatFloat(rating.Value, 'f', -1, 64)
+}
+
+// ratingsAfter returns every player's rating before the tournament followed by their rating after
+// each of rounds 1 to round. Only matches played against real opponents are rated, players who played
+// no such match in a round keep their rating.
+func (t *Tournament) ratingsAfter(round int) map[int][]Rating {
+	history := map[int][]Rating{}
+	for id, player := range t.players {
+		if player.rating.Value == 0 {
+			history[id] = []Rating{t.ratingSystem.Initial()}
+		} else {
+			history[id] = []Rating{player.rating}
+		}
+	}
+	for r := 1; r <= round && r < len(t.rounds); r++ {
+		games := map[int][]RatedGame{}
+		for _, pairing := range t.rounds[r] {
+			if pairing.IsBye() || !pairing.IsComplete() || pairing.IsForfeit() || t.players[pairing.playera].phantom || t.players[pairing.playerb].phantom {
+				continue
+			}
+			a, b := history[pairing.playera], history[pairing.playerb]
+			score := 0.5
+			switch pairing.Winner() {
+			case pairing.playera:
+				score = 1
+			case pairing.playerb:
+				score = 0
+			}
+			games[pairing.playera] = append(games[pairing.playera], RatedGame{Opponent: b[len(b)-1], Score: score})
+			games[pairing.playerb] = append(games[pairing.playerb], RatedGame{Opponent: a[len(a)-1], Score: 1 - score})
+		}
+		for id, ratings := range history {
+			rating := ratings[len(ratings)-1]
+			if len(games[id]) > 0 {
+				rating = t.ratingSystem.Update(rating, games[id])
+			}
+			history[id] = append(ratings, rating)
+		}
+	}
+	return history
+}
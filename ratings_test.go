@@ -0,0 +1,68 @@
+package swisstools
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+)
+
+func TestGlicko2(t *testing.T) {
+	// The example from Glickman's description of Glicko-2.
+	rating := Glicko2{Tau: 0.5}.Update(Rating{Value: 1500, Deviation: 200, Volatility: 0.06}, []RatedGame{
+		{Opponent: Rating{Value: 1400, Deviation: 30}, Score: 1},
+		{Opponent: Rating{Value: 1550, Deviation: 100}, Score: 0},
+		{Opponent: Rating{Value: 1700, Deviation: 300}, Score: 0},
+	})
+	if math.Abs(rating.Value-1464.06) > 0.01 || math.Abs(rating.Deviation-151.52) > 0.01 || math.Abs(rating.Volatility-0.05999) > 0.00001 {
+		t.Fatalf("Expecting 1464.06, 151.52 and 0.05999, got %+v.", rating)
+	}
+	rating = Glicko2{}.Update(Rating{Value: 1500, Deviation: 200, Volatility: 0.06}, nil)
+	if rating.Value != 1500 || rating.Deviation <= 200 {
+		t.Fatalf("Expecting the deviation to grow without games, got %+v.", rating)
+	}
+}
+
+func TestEloRatings(t *testing.T) {
+	tournament := NewTournament()
+	tournament.Register(Registration{Name: "Dylan", Rating: 1500})
+	tournament.Register(Registration{Name: "Sam", Rating: 1500})
+	tournament.AddPlayer("Alex")
+	tournament.SetRatingSystem(Elo{K: 20})
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		if !pairing.IsBye() {
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+	}
+	tournament.NextRound()
+	for _, pairing := range tournament.GetRound() {
+		if pairing.IsBye() {
+			continue
+		}
+		winner, err := tournament.GetStandingForPlayer(pairing.playera)
+		if err != nil {
+			t.Fatalf("GetStandingForPlayer returned an error: %s", err)
+		}
+		loser, _ := tournament.GetStandingForPlayer(pairing.playerb)
+		if winner.Rating-winner.InitialRating != 10 || loser.Rating-loser.InitialRating != -10 || len(winner.RatingChanges) != 1 || winner.RatingChanges[0] != 10 {
+			t.Fatalf("Expecting a 10 point exchange, got %+v and %+v.", winner, loser)
+		}
+	}
+
+	var buf bytes.Buffer
+	tournament.ExportStandingsCSV(&buf)
+	rows, _ := csv.NewReader(&buf).ReadAll()
+	if header := rows[0]; header[len(header)-1] != "rating" {
+		t.Fatalf("Expecting a rating column, got %v.", header)
+	}
+
+	data, _ := tournament.DumpTournament()
+	loaded, err := LoadTournament(data)
+	if err != nil {
+		t.Fatalf("LoadTournament returned an error: %s", err)
+	}
+	if loaded.players[1].rating.Value != 1500 || loaded.players[3].rating.Value != 0 {
+		t.Fatalf("Expecting ratings to survive a dump, got %+v.", loaded.players)
+	}
+}
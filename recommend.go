@@ -0,0 +1,75 @@
+package swisstools
+
+// recommendations lists the recommended number of Swiss rounds and top cut size by the most players
+// they are meant for, following the common tournament rules for Swiss events with a playoff.
+var recommendations = []struct {
+	players int
+	rounds  int
+	topCut  int
+}{
+	{4, 2, 0},
+	{8, 3, 0},
+	{16, 4, 4},
+	{32, 5, 8},
+	{64, 6, 8},
+	{128, 7, 8},
+	{226, 8, 8},
+	{409, 9, 8},
+}
+
+// RecommendedRounds returns the recommended number of Swiss rounds for an event of players, 0 for
+// fewer than two players.
+func RecommendedRounds(players int) int {
+	if players < 2 {
+		return 0
+	}
+	for _, recommendation := range recommendations {
+		if players <= recommendation.players {
+			return recommendation.rounds
+		}
+	}
+	return 10
+}
+
+// RecommendedTopCut returns the recommended number of players making the top cut after rounds Swiss
+// rounds, 0 for no top cut. Fewer rounds than recommended leave more players undefeated, so the cut
+// doubles for every missing round, up to half the players. If rounds is 0 the recommended number of
+// rounds is assumed.
+func RecommendedTopCut(players int, rounds int) int {
+	recommended := RecommendedRounds(players)
+	cut := 8
+	for _, recommendation := range recommendations {
+		if players <= recommendation.players {
+			cut = recommendation.topCut
+			break
+		}
+	}
+	if cut == 0 || rounds == 0 {
+		return cut
+	}
+	for missing := recommended - rounds; missing > 0 && cut*2 <= players/2; missing-- {
+		cut *= 2
+	}
+	return cut
+}
+
+// maxRounds returns the number of Swiss rounds the tournament is limited to, TournamentConfig.Rounds
+// or the recommended number of rounds for the players still in it.
+func (t *Tournament) maxRounds() int {
+	if t.config.Rounds > 0 {
+		return t.config.Rounds
+	}
+	active := 0
+	for _, player := range t.players {
+		if !player.dropped && !player.phantom {
+			active++
+		}
+	}
+	return RecommendedRounds(active)
+}
+
+// roundLimitReached returns whether TournamentConfig.EnforceRoundLimit forbids playing the current
+// round.
+func (t *Tournament) roundLimitReached() bool {
+	return t.config.EnforceRoundLimit && t.currentRound > t.maxRounds()
+}
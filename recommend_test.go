@@ -0,0 +1,56 @@
+package swisstools
+
+import "testing"
+
+func TestRecommendations(t *testing.T) {
+	for _, test := range []struct {
+		players int
+		rounds  int
+		cut     int
+	}{
+		{1, 0, 0},
+		{4, 2, 0},
+		{12, 4, 4},
+		{16, 4, 4},
+		{17, 5, 8},
+		{200, 8, 8},
+		{1000, 10, 8},
+	} {
+		if rounds := RecommendedRounds(test.players); rounds != test.rounds {
+			t.Fatalf("Expecting %d rounds for %d players, got %d.", test.rounds, test.players, rounds)
+		}
+		if cut := RecommendedTopCut(test.players, 0); cut != test.cut {
+			t.Fatalf("Expecting a top %d for %d players, got %d.", test.cut, test.players, cut)
+		}
+	}
+	if cut := RecommendedTopCut(100, 5); cut != 32 {
+		t.Fatalf("Expecting a top 32 for 100 players after 5 rounds, got %d.", cut)
+	}
+	if cut := RecommendedTopCut(40, 3); cut != 16 {
+		t.Fatalf("Expecting the cut limited to a top 16 for 40 players, got %d.", cut)
+	}
+}
+
+func TestEnforceRoundLimit(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{EnforceRoundLimit: true})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	for round := 1; round <= 2; round++ {
+		if err := tournament.Pair(); err != nil {
+			t.Fatalf("Pair returned an error in round %d: %s", round, err)
+		}
+		for _, pairing := range tournament.GetRound() {
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+		if err := tournament.NextRound(); err != nil {
+			t.Fatalf("NextRound returned an error in round %d: %s", round, err)
+		}
+	}
+	if err := tournament.Pair(); err == nil {
+		t.Fatalf("Expecting an error pairing a third round for 4 players.")
+	}
+	if err := tournament.NextRound(); err == nil || tournament.currentRound != 3 {
+		t.Fatalf("Expecting NextRound to refuse a third round for 4 players.")
+	}
+}
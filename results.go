@@ -0,0 +1,224 @@
+package swisstools
+
+import (
+	"fmt"
+	"time"
+)
+
+// Result is a match result reported by one player, as passed to AddResults.
+type Result struct {
+	Player int
+	Wins   int
+	Losses int
+	Draws  int
+	// Override replaces a result that was already reported instead of failing.
+	Override bool
+}
+
+// AddResults enters a batch of current round results. The whole batch is validated first: every player
+// must have a non-bye match not reported yet unless Override is set, each match may appear only once,
+// and scores must be non-negative with at least one game played and at most
+// TournamentConfig.MaxGames. If any result is invalid nothing is entered.
+func (t *Tournament) AddResults(results []Result) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	seen := map[*Pairing]bool{}
+	pairings := []*Pairing{}
+	for i, result := range results {
+		pairing, err := t.findPairing(result.Player)
+		if err != nil {
+			return fmt.Errorf("result %d: %w", i, err)
+		}
+		if err := t.validateResult(*pairing, result); err != nil {
+			return fmt.Errorf("result %d: %w", i, err)
+		}
+		if seen[pairing] {
+			return fmt.Errorf("result %d: match reported twice", i)
+		}
+		seen[pairing] = true
+		pairings = append(pairings, pairing)
+	}
+	// The batch is undone as a whole.
+	t.recordChange(t.pairingSnapshot(t.currentRound, pairings...))
+	for _, result := range results {
+		pairing, _ := t.findPairing(result.Player)
+		t.enterResult(pairing, result)
+	}
+	return nil
+}
+
+func (t *Tournament) validateResult(pairing Pairing, result Result) error {
+	if err := t.checkPublished(); err != nil {
+		return err
+	}
+	switch {
+	case pairing.IsBye():
+		return ErrByeResult
+	case pairing.IsComplete() && !result.Override:
+		return ErrResultReported
+	}
+	return t.validateScore(result.Wins, result.Losses, result.Draws)
+}
+
+func (t *Tournament) validateScore(wins int, losses int, draws int) error {
+	switch {
+	case wins < 0 || losses < 0 || draws < 0:
+		return ErrNegativeScore
+	case wins+losses+draws == 0:
+		return ErrNoGames
+	case t.config.MaxGames > 0 && wins+losses+draws > t.config.MaxGames:
+		return ErrTooManyGames
+	}
+	return nil
+}
+
+// AddIntentionalDraw records that two players paired in the current round agreed to draw without
+// playing. Both get the points for a draw and, as no games were played, their game win percentages
+// are unaffected.
+func (t *Tournament) AddIntentionalDraw(a int, b int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if err := t.checkPublished(); err != nil {
+		return err
+	}
+	pairing, err := t.findPairing(a)
+	if err != nil {
+		return err
+	}
+	if pairing.playera != b && pairing.playerb != b {
+		return ErrPlayersNotPaired
+	}
+	if pairing.IsComplete() {
+		return ErrResultReported
+	}
+	t.recordChange(t.pairingSnapshot(t.currentRound, pairing))
+	pairing.playeraWins, pairing.playerbWins, pairing.draws = 0, 0, 0
+	pairing.intentionalDraw = true
+	pairing.reported = time.Now()
+	t.logChange("intentional_draw", t.currentRound, a, "", describePairing(*pairing))
+	t.emit(Event{Type: ResultRecorded, Round: t.currentRound, PlayerId: a, Pairing: *pairing})
+	return nil
+}
+
+// AddForfeit records that the opponent of winner forfeited their current round match, for example by
+// not showing up. The winner gets TournamentConfig.ForfeitGames game wins. The match counts as unplayed
+// for tiebreakers and the forfeiting player's game win percentage ignores it.
+func (t *Tournament) AddForfeit(winner int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	pairing, err := t.forfeitablePairing(winner)
+	if err != nil {
+		return err
+	}
+	t.recordChange(t.pairingSnapshot(t.currentRound, pairing))
+	t.forfeit(pairing, winner)
+	return nil
+}
+
+func (t *Tournament) forfeit(pairing *Pairing, winner int) {
+	games := t.config.ForfeitGames
+	if games <= 0 {
+		games = 2
+	}
+	pairing.setResult(winner, games, 0, 0)
+	pairing.forfeitA, pairing.forfeitB = pairing.playerb == winner, pairing.playera == winner
+	t.logChange("forfeit", t.currentRound, winner, "", describePairing(*pairing))
+	t.emit(Event{Type: ResultRecorded, Round: t.currentRound, PlayerId: winner, Pairing: *pairing})
+}
+
+// AddDoubleForfeit records that both players of id's current round match forfeited. Both get a match
+// loss.
+func (t *Tournament) AddDoubleForfeit(id int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	pairing, err := t.forfeitablePairing(id)
+	if err != nil {
+		return err
+	}
+	t.recordChange(t.pairingSnapshot(t.currentRound, pairing))
+	pairing.setResult(id, 0, 0, 0)
+	pairing.forfeitA, pairing.forfeitB = true, true
+	t.logChange("double_forfeit", t.currentRound, id, "", describePairing(*pairing))
+	t.emit(Event{Type: ResultRecorded, Round: t.currentRound, PlayerId: id, Pairing: *pairing})
+	return nil
+}
+
+func (t *Tournament) forfeitablePairing(id int) (*Pairing, error) {
+	if err := t.checkPublished(); err != nil {
+		return nil, err
+	}
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return nil, err
+	}
+	if pairing.IsBye() {
+		return nil, ErrByeResult
+	}
+	if pairing.IsComplete() {
+		return nil, ErrResultReported
+	}
+	return pairing, nil
+}
+
+// CorrectResult replaces the result of a player's match that is not locked yet, for example after a
+// scorekeeping error is found. The correction is recorded in the audit log with the old and the new
+// result. Results are locked once their round is completed, see OverrideResult.
+func (t *Tournament) CorrectResult(round int, id int, wins int, losses int, draws int) error {
+	return t.replaceResult(round, id, wins, losses, draws, "")
+}
+
+// OverrideResult replaces the result of a player's match like CorrectResult, including locked results
+// of completed rounds, and recomputes the standings. The reason is required and recorded in the audit
+// log.
+func (t *Tournament) OverrideResult(round int, id int, wins int, losses int, draws int, reason string) error {
+	if reason == "" {
+		return ErrReasonRequired
+	}
+	return t.replaceResult(round, id, wins, losses, draws, reason)
+}
+
+// replaceResult replaces a result, overriding the lock if a reason is given.
+func (t *Tournament) replaceResult(round int, id int, wins int, losses int, draws int, reason string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if round < 1 || round > t.currentRound {
+		return ErrRoundOutOfRange
+	}
+	pairing, err := findPairingIn(t.rounds[round], id)
+	if err != nil {
+		return err
+	}
+	if pairing.IsBye() {
+		return ErrByeResult
+	}
+	if pairing.locked && reason == "" {
+		return ErrResultLocked
+	}
+	if err := t.validateScore(wins, losses, draws); err != nil {
+		return err
+	}
+	t.recordChange(t.snapshot())
+	before := describePairing(*pairing)
+	pairing.setResult(id, wins, losses, draws)
+	if round < t.currentRound && t.currentRound > 2 {
+		t.lastRanks = map[int]int{}
+		for _, standing := range t.standingsAfter(t.currentRound - 2) {
+			t.lastRanks[standing.Id] = standing.Rank
+		}
+	}
+	if reason == "" {
+		t.logChange("correct_result", round, id, before, describePairing(*pairing))
+	} else {
+		t.logReason("override_result", round, id, before, describePairing(*pairing), reason)
+	}
+	if round < t.currentRound {
+		t.updatePlayerStandings()
+		t.emit(Event{Type: StandingsUpdated, Round: t.currentRound - 1})
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package swisstools
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAddResultsAtomic(t *testing.T) {
+	tournament := NewTournament()
+	for i := 1; i <= 4; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	tournament.Pair()
+	round := tournament.GetRound()
+	err := tournament.AddResults([]Result{
+		{Player: round[0].playera, Wins: 2, Losses: 0},
+		{Player: round[1].playera, Wins: -1, Losses: 2},
+	})
+	if err == nil {
+		t.Fatal("Negative score in batch but AddResults did not return an error.")
+	}
+	if tournament.GetRound()[0].IsComplete() {
+		t.Fatal("Invalid batch was partially applied.")
+	}
+	err = tournament.AddResults([]Result{
+		{Player: round[0].playera, Wins: 2, Losses: 0},
+		{Player: round[0].playerb, Wins: 0, Losses: 2},
+	})
+	if err == nil {
+		t.Fatal("Match reported twice but AddResults did not return an error.")
+	}
+	err = tournament.AddResults([]Result{
+		{Player: round[0].playera, Wins: 2, Losses: 0},
+		{Player: round[1].playerb, Wins: 1, Losses: 1, Draws: 1},
+	})
+	if err != nil || !tournament.IsRoundComplete() {
+		t.Fatalf("Expecting the round to be complete, got %v.", err)
+	}
+}
+func TestResultValidation(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{MaxGames: 3})
+	for i := 1; i <= 3; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	tournament.Pair()
+	var match, bye Pairing
+	for _, pairing := range tournament.GetRound() {
+		if pairing.IsBye() {
+			bye = pairing
+		} else {
+			match = pairing
+		}
+	}
+	for _, test := range []struct {
+		id                  int
+		wins, losses, draws int
+		expected            error
+	}{
+		{bye.playera, 2, 0, 0, ErrByeResult},
+		{match.playera, -1, 2, 0, ErrNegativeScore},
+		{match.playera, 0, 0, 0, ErrNoGames},
+		{match.playera, 2, 1, 1, ErrTooManyGames},
+	} {
+		if err := tournament.AddResult(test.id, test.wins, test.losses, test.draws); !errors.Is(err, test.expected) {
+			t.Fatalf("Expecting %v for %d-%d-%d, got %v.", test.expected, test.wins, test.losses, test.draws, err)
+		}
+	}
+	tournament.AddResult(match.playera, 2, 1, 0)
+	if err := tournament.AddResult(match.playerb, 2, 0, 0); !errors.Is(err, ErrResultReported) {
+		t.Fatalf("Expecting ErrResultReported, got %v.", err)
+	}
+	if err := tournament.AddResults([]Result{{Player: match.playerb, Wins: 2, Override: true}}); err != nil {
+		t.Fatalf("AddResults returned an error: %s", err)
+	}
+	if pairing, _ := tournament.findPairing(match.playera); pairing.Winner() != match.playerb {
+		t.Fatalf("Expecting the overriding result to stand, got %+v.", pairing)
+	}
+}
+
+func TestCorrectResult(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 0, 2, 0)
+	if err := tournament.CorrectResult(1, 1, 2, 0, 0); err != nil {
+		t.Fatalf("CorrectResult returned an error: %s", err)
+	}
+	log := tournament.AuditLog()
+	if entry := log[len(log)-1]; entry.Action != "correct_result" || entry.Round != 1 || entry.Before == entry.After {
+		t.Fatalf("Expecting the correction in the audit log, got %+v.", entry)
+	}
+	tournament.NextRound()
+	tournament.Pair()
+	if !tournament.rounds[1][0].IsLocked() {
+		t.Fatalf("Expecting the results of a completed round to be locked.")
+	}
+	if err := tournament.CorrectResult(1, 2, 2, 1, 0); !errors.Is(err, ErrResultLocked) {
+		t.Fatalf("Expecting ErrResultLocked, got %v.", err)
+	}
+	if err := tournament.OverrideResult(1, 2, 2, 1, 0, ""); !errors.Is(err, ErrReasonRequired) {
+		t.Fatalf("Expecting ErrReasonRequired, got %v.", err)
+	}
+	if err := tournament.OverrideResult(1, 2, 2, 1, 0, "result slip misread"); err != nil {
+		t.Fatalf("OverrideResult returned an error: %s", err)
+	}
+	standings := tournament.GetStandings()
+	if standings[0].Id != 2 || standings[0].Points != 3 || standings[1].Points != 0 {
+		t.Fatalf("Expecting Sam to lead after the override, got %+v.", standings)
+	}
+	log = tournament.AuditLog()
+	if entry := log[len(log)-1]; entry.Action != "override_result" || entry.Reason != "result slip misread" {
+		t.Fatalf("Expecting the override in the audit log, got %+v.", entry)
+	}
+	if err := tournament.OverrideResult(3, 1, 2, 0, 0, "typo"); err == nil {
+		t.Fatalf("Expecting an error for a round that does not exist.")
+	}
+	if err := tournament.OverrideResult(1, 1, 0, 0, 0, "typo"); err == nil {
+		t.Fatalf("Expecting an error for a result without games.")
+	}
+}
+
+func TestIntentionalDraw(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 1, 0)
+	tournament.NextRound()
+	tournament.Pair()
+	if err := tournament.AddIntentionalDraw(1, 3); err == nil {
+		t.Fatalf("Expecting an error for players who are not paired.")
+	}
+	if err := tournament.AddIntentionalDraw(2, 1); err != nil {
+		t.Fatalf("AddIntentionalDraw returned an error: %s", err)
+	}
+	if pairing := tournament.GetRound()[0]; !pairing.IsIntentionalDraw() || !pairing.IsComplete() {
+		t.Fatalf("Expecting a complete intentional draw, got %+v.", pairing)
+	}
+	tournament.NextRound()
+	dylan, _ := tournament.GetStandingForPlayer(1)
+	sam, _ := tournament.GetStandingForPlayer(2)
+	if dylan.Points != 4 || sam.Points != 1 || dylan.Draws != 1 {
+		t.Fatalf("Expecting draw points for both players, got %+v and %+v.", dylan, sam)
+	}
+	if dylan.Tiebreakers.GameWinPercentage != 2.0/3 {
+		t.Fatalf("Expecting the intentional draw not to count in game win percentage, got %v.", dylan.Tiebreakers.GameWinPercentage)
+	}
+}
+
+func TestForfeits(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{ForfeitGames: 1})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	round := tournament.GetRound()
+	if err := tournament.AddForfeit(round[0].playera); err != nil {
+		t.Fatalf("AddForfeit returned an error: %s", err)
+	}
+	if err := tournament.AddDoubleForfeit(round[1].playerb); err != nil {
+		t.Fatalf("AddDoubleForfeit returned an error: %s", err)
+	}
+	if err := tournament.AddForfeit(round[1].playera); err == nil {
+		t.Fatalf("Expecting an error for a match already reported.")
+	}
+	round = tournament.GetRound()
+	if wins, losses, _ := round[0].Result(); !round[0].IsForfeit() || wins != 1 || losses != 0 {
+		t.Fatalf("Expecting a 1-0 forfeit win, got %+v.", round[0])
+	}
+	tournament.NextRound()
+	winner, _ := tournament.GetStandingForPlayer(round[0].playera)
+	loser, _ := tournament.GetStandingForPlayer(round[0].playerb)
+	if winner.Points != 3 || winner.Tiebreakers.GameWinPercentage != 1 || winner.Tiebreakers.OpponentMatchWinPercentage != 0 {
+		t.Fatalf("Expecting the forfeit win to count like a bye, got %+v.", winner)
+	}
+	if loser.Losses != 1 || loser.Tiebreakers.GameWinPercentage != 0 {
+		t.Fatalf("Expecting a loss without games for the forfeiting player, got %+v.", loser)
+	}
+	for _, id := range []int{round[1].playera, round[1].playerb} {
+		if standing, _ := tournament.GetStandingForPlayer(id); standing.Losses != 1 || standing.Points != 0 {
+			t.Fatalf("Expecting a loss for both players of the double forfeit, got %+v.", standing)
+		}
+	}
+}
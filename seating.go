@@ -0,0 +1,89 @@
+package swisstools
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Seat is a player's place in a seating chart.
+type Seat struct {
+	PlayerId int
+	Name     string
+	Table    int
+	Seat     int
+}
+
+// SeatingChart seats every player alphabetically, seatsPerTable to a table, independent of pairings.
+// It is meant for player meetings, decklist collection and draft pods.
+func (t *Tournament) SeatingChart(seatsPerTable int) []Seat {
+	if seatsPerTable < 1 {
+		seatsPerTable = 2
+	}
+	chart := []Seat{}
+	for _, id := range t.playerIdsByName() {
+		if t.players[id].phantom {
+			continue
+		}
+		i := len(chart)
+		chart = append(chart, Seat{PlayerId: id, Name: t.players[id].name, Table: i/seatsPerTable + 1, Seat: i%seatsPerTable + 1})
+	}
+	return chart
+}
+
+func (t *Tournament) FormatSeatingChart(w io.Writer, seatsPerTable int) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Name", "Table", "Seat"})
+	for _, seat := range t.SeatingChart(seatsPerTable) {
+		table.Append([]string{seat.Name, strconv.Itoa(seat.Table), strconv.Itoa(seat.Seat)})
+	}
+	table.Render()
+}
+
+// ReserveTable makes a player always play at the given table, for example a feature match table or a
+// seat with wheelchair access. It applies from the next time a round is paired. A player can hold
+// more than one reservation, the lowest free one is used.
+func (t *Tournament) ReserveTable(table int, id int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if _, ok := t.players[id]; !ok {
+		return ErrPlayerNotFound
+	}
+	if table < 1 {
+		return ErrInvalidTable
+	}
+	if other, ok := t.reservations[table]; ok && other != id {
+		return ErrTableReserved
+	}
+	if t.reservations == nil {
+		t.reservations = map[int]int{}
+	}
+	t.reservations[table] = id
+	t.logChange("reserve_table", 0, id, "", strconv.Itoa(table))
+	return nil
+}
+
+// ReleaseTable removes the reservation of a table.
+func (t *Tournament) ReleaseTable(table int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	id, ok := t.reservations[table]
+	if !ok {
+		return ErrTableNotReserved
+	}
+	delete(t.reservations, table)
+	t.logChange("release_table", 0, id, strconv.Itoa(table), "")
+	return nil
+}
+
+// TableReservations returns the player id holding each reserved table.
+func (t *Tournament) TableReservations() map[int]int {
+	reservations := map[int]int{}
+	for table, id := range t.reservations {
+		reservations[table] = id
+	}
+	return reservations
+}
@@ -0,0 +1,60 @@
+package swisstools
+
+import "testing"
+
+func TestSeatingChart(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Eve", "Cy", "Alex", "Dee", "Bo"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.AddPhantom("Phantom")
+	chart := tournament.SeatingChart(2)
+	if len(chart) != 5 {
+		t.Fatalf("Expecting 5 seats, got %d.", len(chart))
+	}
+	if chart[0].Name != "Alex" || chart[0].Table != 1 || chart[0].Seat != 1 {
+		t.Fatalf("Expecting Alex at table 1 seat 1, got %+v.", chart[0])
+	}
+	if chart[4].Name != "Eve" || chart[4].Table != 3 || chart[4].Seat != 1 {
+		t.Fatalf("Expecting Eve at table 3 seat 1, got %+v.", chart[4])
+	}
+}
+
+func TestReserveTable(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim", "Robin", "Jo", "Lee"} {
+		tournament.AddPlayer(name)
+	}
+	if err := tournament.ReserveTable(1, 3); err != nil {
+		t.Fatalf("ReserveTable returned an error: %s", err)
+	}
+	if err := tournament.ReserveTable(1, 4); err == nil {
+		t.Fatalf("Expecting an error for a table reserved by another player.")
+	}
+	tournament.ReserveTable(10, 5)
+	for r := 0; r < 3; r++ {
+		tournament.Pair()
+		tables := map[int]bool{}
+		for _, pairing := range tournament.GetRound() {
+			if pairing.IsBye() {
+				continue
+			}
+			if tables[pairing.TableNumber()] {
+				t.Fatalf("Expecting every table to be used once, got %+v.", tournament.GetRound())
+			}
+			tables[pairing.TableNumber()] = true
+			// Against each other they play at the lower of the two tables.
+			withAlex := pairing.playera == 3 || pairing.playerb == 3
+			withRobin := pairing.playera == 5 || pairing.playerb == 5
+			if (withAlex && pairing.TableNumber() != 1) || (withRobin && !withAlex && pairing.TableNumber() != 10) {
+				t.Fatalf("Expecting reserved tables to be used, got %+v.", pairing)
+			}
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+		tournament.NextRound()
+	}
+	tournament.ReleaseTable(10)
+	if reservations := tournament.TableReservations(); len(reservations) != 1 || reservations[1] != 3 {
+		t.Fatalf("Expecting only table 1 to be reserved, got %v.", reservations)
+	}
+}
@@ -0,0 +1,55 @@
+package swisstools
+
+import "strconv"
+
+// SeedingMode decides how the first round is paired.
+type SeedingMode string
+
+const (
+	// SeedingRandom pairs the first round at random.
+	SeedingRandom SeedingMode = ""
+	// SeedingFold orders the first round by seed and pairs the top seed against the bottom seed,
+	// the second against the second to last and so on.
+	SeedingFold SeedingMode = "fold"
+	// SeedingSlide orders the first round by seed and pairs the top half against the bottom half in
+	// order, 1 against n/2+1, 2 against n/2+2 and so on.
+	SeedingSlide SeedingMode = "slide"
+)
+
+// SetSeed sets the value, such as a rating or ranking points, a player is seeded by in the first
+// round when TournamentConfig.Seeding is set. Higher seeds are stronger. Players registered with a
+// rating are seeded by it.
+func (t *Tournament) SetSeed(id int, seed int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	player, ok := t.players[id]
+	if !ok {
+		return ErrPlayerNotFound
+	}
+	before := player.seed
+	player.seed = seed
+	t.players[id] = player
+	t.logChange("set_seed", 0, id, strconv.Itoa(before), strconv.Itoa(seed))
+	return nil
+}
+
+// seeded reports whether the current round is paired by seed.
+func (t *Tournament) seeded() bool {
+	return t.config.Seeding != SeedingRandom && t.currentRound == 1
+}
+
+// seedOrder rearranges players in seed order so that consecutive players are the pairings of the
+// seeding mode.
+func (t *Tournament) seedOrder(players []int) []int {
+	half := len(players) / 2
+	order := make([]int, 0, len(players))
+	for i := 0; i < half; i++ {
+		if t.config.Seeding == SeedingSlide {
+			order = append(order, players[i], players[half+i])
+		} else {
+			order = append(order, players[i], players[len(players)-1-i])
+		}
+	}
+	return order
+}
@@ -0,0 +1,32 @@
+package swisstools
+
+import "testing"
+
+func TestSeededFirstRound(t *testing.T) {
+	for _, test := range []struct {
+		mode     SeedingMode
+		expected map[int]int
+	}{
+		{SeedingFold, map[int]int{1: 6, 2: 5, 3: 4}},
+		{SeedingSlide, map[int]int{1: 4, 2: 5, 3: 6}},
+	} {
+		tournament := NewTournamentWithConfig(TournamentConfig{Seeding: test.mode})
+		for i, name := range []string{"Dylan", "Sam", "Alex", "Kim", "Robin", "Jo", "Lee"} {
+			tournament.Register(Registration{Name: name, Rating: 2000 - i*100})
+		}
+		if err := tournament.Pair(); err != nil {
+			t.Fatalf("Pair returned an error: %s", err)
+		}
+		for _, pairing := range tournament.GetRound() {
+			if pairing.IsBye() {
+				if pairing.playera != 7 {
+					t.Fatalf("Expecting the lowest seed to get the bye in %s mode, got %d.", test.mode, pairing.playera)
+				}
+				continue
+			}
+			if test.expected[pairing.playera] != pairing.playerb {
+				t.Fatalf("Expecting %d to play %d in %s mode, got %d.", pairing.playera, test.expected[pairing.playera], test.mode, pairing.playerb)
+			}
+		}
+	}
+}
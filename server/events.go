@@ -0,0 +1,124 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/dstathis/swisstools"
+)
+
+// broadcaster tells subscribers what changed in the tournament. Subscribers read the new state
+// themselves, since tournament events are emitted while the tournament is locked for the change.
+type broadcaster struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber]bool
+}
+
+// subscriber collects changes until the client is sent the new state. Changes made in quick
+// succession are sent once.
+type subscriber struct {
+	mu        sync.Mutex
+	pairings  bool
+	standings bool
+	notify    chan struct{}
+}
+
+func newBroadcaster(tournament *swisstools.SyncTournament) *broadcaster {
+	b := &broadcaster{subscribers: map[*subscriber]bool{}}
+	tournament.Update(func(t *swisstools.Tournament) error {
+		t.OnEvent(b.handle)
+		return nil
+	})
+	return b
+}
+
+func (b *broadcaster) handle(event swisstools.Event) {
+	pairings := event.Type == swisstools.RoundPaired || event.Type == swisstools.ResultRecorded || event.Type == swisstools.PlayerDropped
+	standings := event.Type == swisstools.StandingsUpdated
+	if !pairings && !standings {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		sub.mu.Lock()
+		sub.pairings = sub.pairings || pairings
+		sub.standings = sub.standings || standings
+		sub.mu.Unlock()
+		select {
+		case sub.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *broadcaster) subscribe() *subscriber {
+	sub := &subscriber{pairings: true, standings: true, notify: make(chan struct{}, 1)}
+	sub.notify <- struct{}{}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[sub] = true
+	return sub
+}
+
+func (b *broadcaster) unsubscribe(sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subscribers, sub)
+}
+
+// changes returns and clears what changed since the last call.
+func (sub *subscriber) changes() (bool, bool) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	pairings, standings := sub.pairings, sub.standings
+	sub.pairings, sub.standings = false, false
+	return pairings, standings
+}
+
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet) {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, errorBody{Error: "streaming not supported"})
+		return
+	}
+	sub := s.broadcaster.subscribe()
+	defer s.broadcaster.unsubscribe(sub)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-sub.notify:
+		}
+		pairings, standings := sub.changes()
+		if pairings {
+			if err := writeEvent(w, "pairings", s.currentPairings()); err != nil {
+				return
+			}
+		}
+		if standings {
+			if err := writeEvent(w, "standings", s.currentStandings()); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, name string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
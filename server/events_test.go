@@ -0,0 +1,58 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dstathis/swisstools"
+)
+
+func TestEvents(t *testing.T) {
+	tournament := swisstools.NewSyncTournament(swisstools.NewTournament())
+	server := httptest.NewServer(New(tournament))
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /events returned an error: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expecting an event stream, got %q.", resp.Header.Get("Content-Type"))
+	}
+	lines := bufio.NewScanner(resp.Body)
+	next := func() (string, string) {
+		t.Helper()
+		event, data := "", ""
+		for lines.Scan() && lines.Text() != "" {
+			if name, ok := strings.CutPrefix(lines.Text(), "event: "); ok {
+				event = name
+			} else if body, ok := strings.CutPrefix(lines.Text(), "data: "); ok {
+				data = body
+			}
+		}
+		return event, data
+	}
+	if event, data := next(); event != "pairings" || data != "[]" {
+		t.Fatalf("Expecting the current pairings on connect, got %s %s.", event, data)
+	}
+	if event, _ := next(); event != "standings" {
+		t.Fatalf("Expecting the current standings on connect, got %s.", event)
+	}
+
+	tournament.Update(func(t *swisstools.Tournament) error {
+		t.AddPlayer("Dylan")
+		t.AddPlayer("Sam")
+		return nil
+	})
+	tournament.Pair()
+	if event, data := next(); event != "pairings" || !strings.Contains(data, `"name_a":"`) {
+		t.Fatalf("Expecting the new pairings, got %s %s.", event, data)
+	}
+}
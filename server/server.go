@@ -0,0 +1,336 @@
+// Package server exposes a swisstools tournament over HTTP with JSON bodies, as a base for web
+// scorekeeping tools.
+//
+// The endpoints are:
+//
+//	GET  /players             all players, sorted by name
+//	POST /players             register a player: {"name": "Dylan", "external_id": "123"}
+//	GET  /players/{id}        one player
+//	POST /players/{id}/drop   drop a player
+//	GET  /pairings            pairings of the current round by table
+//	POST /pairings            pair the current round
+//	POST /pairings/publish    publish draft pairings, see TournamentConfig.DraftPairings
+//	POST /results             report a result: {"player": 1, "wins": 2, "losses": 1, "draws": 0}
+//	GET  /standings           standings after the last completed round
+//	POST /rounds/next         complete the current round and start the next one
+//	GET  /tournament          the whole tournament as written by DumpTournament
+//	GET  /events              live pairings and standings as Server-Sent Events, see below
+//
+// Errors are returned as {"error": "..."} with a 404 status for unknown players, 400 for invalid
+// requests and 409 when the tournament refuses the change. A round paired beyond
+// TournamentConfig.MaxBracketDistance is still returned with a 200 status, with the problem in a
+// Warning header.
+//
+// /events sends a "pairings" event with the body of GET /pairings whenever pairings or results
+// change, and a "standings" event with the body of GET /standings whenever the standings change.
+// Both are sent when a client connects, so a projector display only needs an EventSource.
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dstathis/swisstools"
+)
+
+type Server struct {
+	tournament  *swisstools.SyncTournament
+	mux         *http.ServeMux
+	broadcaster *broadcaster
+}
+
+func New(tournament *swisstools.SyncTournament) *Server {
+	s := &Server{tournament: tournament, mux: http.NewServeMux(), broadcaster: newBroadcaster(tournament)}
+	s.mux.HandleFunc("/players", s.players)
+	s.mux.HandleFunc("/players/", s.player)
+	s.mux.HandleFunc("/pairings", s.pairings)
+	s.mux.HandleFunc("/pairings/publish", s.publish)
+	s.mux.HandleFunc("/results", s.results)
+	s.mux.HandleFunc("/standings", s.standings)
+	s.mux.HandleFunc("/rounds/next", s.nextRound)
+	s.mux.HandleFunc("/tournament", s.dump)
+	s.mux.HandleFunc("/events", s.events)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type Player struct {
+	Id         int    `json:"id"`
+	Name       string `json:"name"`
+	ExternalId string `json:"external_id,omitempty"`
+	Points     int    `json:"points"`
+	Wins       int    `json:"wins"`
+	Losses     int    `json:"losses"`
+	Draws      int    `json:"draws"`
+	Dropped    bool   `json:"dropped"`
+	CheckedIn  bool   `json:"checked_in"`
+}
+
+type Pairing struct {
+	Table    int    `json:"table,omitempty"`
+	PlayerA  int    `json:"player_a"`
+	NameA    string `json:"name_a"`
+	PlayerB  int    `json:"player_b,omitempty"` // Absent for a bye.
+	NameB    string `json:"name_b,omitempty"`
+	Bye      bool   `json:"bye,omitempty"`
+	First    int    `json:"first,omitempty"` // Player with white or on the play, if assigned.
+	Complete bool   `json:"complete"`
+	WinsA    int    `json:"wins_a"`
+	WinsB    int    `json:"wins_b"`
+	Draws    int    `json:"draws"`
+}
+
+type Standing struct {
+	Rank        int                    `json:"rank"`
+	Id          int                    `json:"id"`
+	Name        string                 `json:"name"`
+	Points      int                    `json:"points"`
+	Record      string                 `json:"record"`
+	Dropped     bool                   `json:"dropped,omitempty"`
+	Tiebreakers swisstools.Tiebreakers `json:"tiebreakers"`
+}
+
+type Registration struct {
+	Name       string `json:"name"`
+	ExternalId string `json:"external_id,omitempty"`
+}
+
+type Result struct {
+	Player int `json:"player"`
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Draws  int `json:"draws"`
+}
+
+type errorBody struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError maps errors of the tournament to a status code.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusConflict
+	switch {
+	case errors.Is(err, swisstools.ErrPlayerNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, swisstools.ErrEmptyName), errors.Is(err, swisstools.ErrNegativeScore),
+		errors.Is(err, swisstools.ErrNoGames), errors.Is(err, swisstools.ErrTooManyGames):
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, errorBody{Error: err.Error()})
+}
+
+func allow(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, method := range methods {
+		if r.Method == method {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeJSON(w, http.StatusMethodNotAllowed, errorBody{Error: "method not allowed"})
+	return false
+}
+
+func decode(w http.ResponseWriter, r *http.Request, body any) bool {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody{Error: "invalid body: " + err.Error()})
+		return false
+	}
+	return true
+}
+
+func playerFromView(view swisstools.PlayerView) Player {
+	return Player{Id: view.Id, Name: view.Name, ExternalId: view.ExternalId, Points: view.Points, Wins: view.Wins, Losses: view.Losses, Draws: view.Draws, Dropped: view.Dropped, CheckedIn: view.CheckedIn}
+}
+
+func (s *Server) players(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+	if r.Method == http.MethodGet {
+		players := []Player{}
+		s.tournament.View(func(t *swisstools.Tournament) {
+			for _, view := range t.PlayerViews() {
+				players = append(players, playerFromView(view))
+			}
+		})
+		writeJSON(w, http.StatusOK, players)
+		return
+	}
+	registration := Registration{}
+	if !decode(w, r, &registration) {
+		return
+	}
+	var player Player
+	err := s.tournament.Update(func(t *swisstools.Tournament) error {
+		if err := t.Register(swisstools.Registration{Name: registration.Name, ExternalId: registration.ExternalId}); err != nil {
+			return err
+		}
+		id, err := t.GetPlayerID(registration.Name)
+		if err != nil {
+			return err
+		}
+		view, err := t.GetPlayerView(id)
+		player = playerFromView(view)
+		return err
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, player)
+}
+
+// player serves /players/{id} and /players/{id}/drop.
+func (s *Server) player(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/players/"), "/")
+	id, err := strconv.Atoi(parts[0])
+	if err != nil || len(parts) > 2 || (len(parts) == 2 && parts[1] != "drop") {
+		writeJSON(w, http.StatusNotFound, errorBody{Error: "not found"})
+		return
+	}
+	if len(parts) == 2 {
+		if !allow(w, r, http.MethodPost) {
+			return
+		}
+		if err := s.tournament.Update(func(t *swisstools.Tournament) error { return t.DropPlayer(id) }); err != nil {
+			writeError(w, err)
+			return
+		}
+	} else if !allow(w, r, http.MethodGet) {
+		return
+	}
+	var view swisstools.PlayerView
+	s.tournament.View(func(t *swisstools.Tournament) {
+		view, err = t.GetPlayerView(id)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, playerFromView(view))
+}
+
+func (s *Server) pairings(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+	if r.Method == http.MethodPost {
+		var distance *swisstools.BracketDistanceError
+		if err := s.tournament.Pair(); errors.As(err, &distance) {
+			w.Header().Set("Warning", fmt.Sprintf("199 - %q", err.Error()))
+		} else if err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, s.currentPairings())
+}
+
+func (s *Server) publish(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodPost) {
+		return
+	}
+	if err := s.tournament.PublishPairings(); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.currentPairings())
+}
+
+func (s *Server) currentPairings() []Pairing {
+	pairings := []Pairing{}
+	s.tournament.View(func(t *swisstools.Tournament) {
+		name := func(id int) string {
+			view, _ := t.GetPlayerView(id)
+			return view.Name
+		}
+		for _, p := range t.GetPairingsByTable() {
+			pairing := Pairing{Table: p.TableNumber(), PlayerA: p.PlayerA(), NameA: name(p.PlayerA()), Bye: p.IsBye(), First: p.First(), Complete: p.IsComplete()}
+			if !p.IsBye() {
+				pairing.PlayerB, pairing.NameB = p.PlayerB(), name(p.PlayerB())
+			}
+			if p.IsComplete() {
+				pairing.WinsA, pairing.WinsB, pairing.Draws = p.Result()
+			}
+			pairings = append(pairings, pairing)
+		}
+	})
+	return pairings
+}
+
+func (s *Server) results(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodPost) {
+		return
+	}
+	result := Result{}
+	if !decode(w, r, &result) {
+		return
+	}
+	if err := s.tournament.AddResult(result.Player, result.Wins, result.Losses, result.Draws); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) standings(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.currentStandings())
+}
+
+func (s *Server) currentStandings() []Standing {
+	standings := []Standing{}
+	for _, standing := range s.tournament.GetStandings() {
+		standings = append(standings, Standing{Rank: standing.Rank, Id: standing.Id, Name: standing.Name, Points: standing.Points, Record: standing.Record, Dropped: standing.Dropped, Tiebreakers: standing.Tiebreakers})
+	}
+	return standings
+}
+
+func (s *Server) nextRound(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodPost) {
+		return
+	}
+	if err := s.tournament.NextRound(); err != nil {
+		writeError(w, err)
+		return
+	}
+	var round int
+	s.tournament.View(func(t *swisstools.Tournament) {
+		round = t.CurrentRoundNumber()
+	})
+	writeJSON(w, http.StatusOK, map[string]int{"round": round})
+}
+
+func (s *Server) dump(w http.ResponseWriter, r *http.Request) {
+	if !allow(w, r, http.MethodGet) {
+		return
+	}
+	// Slow clients must not hold up changes to the tournament.
+	var dump bytes.Buffer
+	var err error
+	s.tournament.View(func(t *swisstools.Tournament) {
+		err = t.DumpTournamentTo(&dump)
+	})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorBody{Error: err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	dump.WriteTo(w)
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dstathis/swisstools"
+)
+
+func TestServer(t *testing.T) {
+	tournament := swisstools.NewSyncTournament(swisstools.NewTournament())
+	server := httptest.NewServer(New(tournament))
+	defer server.Close()
+	request := func(method string, path string, body string, status int, out any) {
+		t.Helper()
+		req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s returned an error: %s", method, path, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Fatalf("Expecting status %d for %s %s, got %d.", status, method, path, resp.StatusCode)
+		}
+		if out != nil {
+			json.NewDecoder(resp.Body).Decode(out)
+		}
+	}
+
+	for _, name := range []string{"Dylan", "Sam", "Alex"} {
+		request("POST", "/players", `{"name": "`+name+`"}`, http.StatusCreated, nil)
+	}
+	request("POST", "/players", `{"name": "Sam"}`, http.StatusConflict, nil)
+	request("POST", "/players", `{"name": `, http.StatusBadRequest, nil)
+	players := []Player{}
+	request("GET", "/players", "", http.StatusOK, &players)
+	if len(players) != 3 || players[0].Name != "Alex" {
+		t.Fatalf("Expecting 3 players sorted by name, got %+v.", players)
+	}
+
+	pairings := []Pairing{}
+	request("POST", "/pairings", "", http.StatusOK, &pairings)
+	if len(pairings) != 2 || pairings[0].Table != 1 || !pairings[1].Bye {
+		t.Fatalf("Expecting a match and a bye, got %+v.", pairings)
+	}
+	request("POST", "/results", fmt.Sprintf(`{"player": %d, "wins": -1}`, pairings[0].PlayerA), http.StatusBadRequest, nil)
+	request("POST", "/results", `{"player": 9, "wins": 2}`, http.StatusNotFound, nil)
+	request("POST", "/results", fmt.Sprintf(`{"player": %d, "wins": 2, "losses": 1}`, pairings[0].PlayerA), http.StatusNoContent, nil)
+	round := map[string]int{}
+	request("POST", "/rounds/next", "", http.StatusOK, &round)
+	if round["round"] != 2 {
+		t.Fatalf("Expecting round 2, got %v.", round)
+	}
+
+	standings := []Standing{}
+	request("GET", "/standings", "", http.StatusOK, &standings)
+	if len(standings) != 3 || standings[0].Points != 3 || standings[0].Record != "1-0-0" {
+		t.Fatalf("Expecting standings after round 1, got %+v.", standings)
+	}
+	player := Player{}
+	request("POST", "/players/2/drop", "", http.StatusOK, &player)
+	if !player.Dropped {
+		t.Fatalf("Expecting Sam to be dropped, got %+v.", player)
+	}
+	request("GET", "/players/9", "", http.StatusNotFound, nil)
+	request("DELETE", "/standings", "", http.StatusMethodNotAllowed, nil)
+
+	resp, err := http.Get(server.URL + "/tournament")
+	if err != nil {
+		t.Fatalf("GET /tournament returned an error: %s", err)
+	}
+	defer resp.Body.Close()
+	if _, err := swisstools.LoadTournamentFrom(resp.Body); err != nil {
+		t.Fatalf("Expecting a loadable dump, got %v.", err)
+	}
+}
+
+func TestPublishPairings(t *testing.T) {
+	tournament := swisstools.NewTournamentWithConfig(swisstools.TournamentConfig{DraftPairings: true})
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	handler := New(swisstools.NewSyncTournament(tournament))
+	request := func(method string, path string, body string) int {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return recorder.Code
+	}
+	if status := request("POST", "/pairings", ""); status != http.StatusOK {
+		t.Fatalf("Expecting status 200 for pairing the round, got %d.", status)
+	}
+	if status := request("POST", "/results", `{"player": 1, "wins": 2}`); status != http.StatusConflict {
+		t.Fatalf("Expecting status 409 for a result on draft pairings, got %d.", status)
+	}
+	if status := request("POST", "/pairings/publish", ""); status != http.StatusOK {
+		t.Fatalf("Expecting status 200 for publishing, got %d.", status)
+	}
+	if status := request("POST", "/results", `{"player": 1, "wins": 2}`); status != http.StatusNoContent {
+		t.Fatalf("Expecting status 204 for a result on published pairings, got %d.", status)
+	}
+}
+
+func TestPairingsBracketDistance(t *testing.T) {
+	tournament := swisstools.NewTournamentWithConfig(swisstools.TournamentConfig{MaxBracketDistance: 1})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Robin"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	opponents, winners := map[int]int{}, map[int]bool{}
+	for _, pairing := range tournament.GetRound() {
+		opponents[pairing.PlayerA()], opponents[pairing.PlayerB()] = pairing.PlayerB(), pairing.PlayerA()
+		winners[pairing.PlayerA()] = true
+		tournament.AddResult(pairing.PlayerA(), 2, 0, 0)
+	}
+	tournament.NextRound()
+	// The leader beats the other winner, and the player they beat first wins too, so the leader has to
+	// be paired against the player on 0 points in the last round.
+	tournament.Pair()
+	leader := 0
+	for _, pairing := range tournament.GetRound() {
+		if winners[pairing.PlayerA()] {
+			leader = pairing.PlayerA()
+		}
+	}
+	tournament.AddResult(leader, 2, 0, 0)
+	tournament.AddResult(opponents[leader], 2, 0, 0)
+	tournament.NextRound()
+	handler := New(swisstools.NewSyncTournament(tournament))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("POST", "/pairings", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Header().Get("Warning"), "bracket distance") {
+		t.Fatalf("Expecting status 200 with a warning, got %d and %q.", recorder.Code, recorder.Header().Get("Warning"))
+	}
+	pairings := []Pairing{}
+	json.NewDecoder(recorder.Body).Decode(&pairings)
+	if len(pairings) != 2 {
+		t.Fatalf("Expecting the round to be paired, got %+v.", pairings)
+	}
+}
@@ -0,0 +1,167 @@
+package swisstools
+
+import (
+	"errors"
+	"math/rand"
+)
+
+type SimulationOptions struct {
+	CutSize         int     // Players making the cut, e.g. 8 for a top 8.
+	DrawProbability float64 // Chance of any match ending in a draw.
+	// PairProbabilities is the chance that the first player of a pair beats the second when the match
+	// is not drawn. The reverse pair does not need to be listed.
+	PairProbabilities map[[2]int]float64
+	// Archetypes maps player ids to their deck archetype and ArchetypeProbabilities gives the chance
+	// that the first archetype beats the second, for players without an entry in PairProbabilities.
+	Archetypes             map[int]string
+	ArchetypeProbabilities map[[2]string]float64
+	Seed                   int64 // Seed for the simulated results, 0 for a random seed.
+}
+
+type SimulationResult struct {
+	Iterations int
+	Players    map[int]PlayerProjection
+}
+
+type PlayerProjection struct {
+	CutProbability float64
+	// RankProbabilities holds the chance of finishing at each rank, rank 1 first.
+	RankProbabilities []float64
+	ExpectedPoints    float64
+}
+
+// SimulateOutcomes plays out the rest of the Swiss rounds iterations times, with random results drawn
+// from opts, and returns how often each player finished at each rank and made the cut. Unreported
+// results of the current round are simulated as well. It requires TournamentConfig.Rounds to be set.
+func (t *Tournament) SimulateOutcomes(iterations int, opts SimulationOptions) (SimulationResult, error) {
+	if t.config.Rounds == 0 {
+		return SimulationResult{}, ErrRoundsNotConfigured
+	}
+	if iterations < 1 {
+		return SimulationResult{}, ErrInvalidIterations
+	}
+	rng := rand.New(rand.NewSource(opts.Seed))
+	if opts.Seed == 0 {
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	}
+	result := SimulationResult{Iterations: iterations, Players: map[int]PlayerProjection{}}
+	counts := map[int][]int{}
+	cuts := map[int]int{}
+	points := map[int]int{}
+	for i := 0; i < iterations; i++ {
+		sim := t.clone()
+		for sim.currentRound <= t.config.Rounds {
+			if len(sim.rounds[sim.currentRound]) == 0 {
+				if sim.stageComplete() {
+					if err := sim.AdvanceStage(); err != nil {
+						return SimulationResult{}, err
+					}
+				}
+				// Pairings beyond the bracket distance are still made.
+				var distance *BracketDistanceError
+				if err := sim.Pair(); err != nil && !errors.As(err, &distance) {
+					return SimulationResult{}, err
+				}
+			}
+			for j, pairing := range sim.rounds[sim.currentRound] {
+				if !pairing.IsComplete() {
+					sim.rounds[sim.currentRound][j] = opts.simulateMatch(rng, pairing)
+				}
+			}
+			if err := sim.NextRound(); err != nil {
+				return SimulationResult{}, err
+			}
+		}
+		for _, standing := range sim.GetStandings() {
+			if counts[standing.Id] == nil {
+				counts[standing.Id] = make([]int, len(sim.standings))
+			}
+			counts[standing.Id][standing.Rank-1]++
+			if standing.Rank <= opts.CutSize {
+				cuts[standing.Id]++
+			}
+			points[standing.Id] += standing.Points
+		}
+	}
+	for id, ranks := range counts {
+		projection := PlayerProjection{
+			CutProbability:    float64(cuts[id]) / float64(iterations),
+			RankProbabilities: make([]float64, len(ranks)),
+			ExpectedPoints:    float64(points[id]) / float64(iterations),
+		}
+		for rank, count := range ranks {
+			projection.RankProbabilities[rank] = float64(count) / float64(iterations)
+		}
+		result.Players[id] = projection
+	}
+	return result, nil
+}
+
+// winProbability returns the chance that a beats b in a match which is not drawn.
+func (opts SimulationOptions) winProbability(a int, b int) float64 {
+	if p, ok := opts.PairProbabilities[[2]int{a, b}]; ok {
+		return p
+	}
+	if p, ok := opts.PairProbabilities[[2]int{b, a}]; ok {
+		return 1 - p
+	}
+	archetypeA, okA := opts.Archetypes[a]
+	archetypeB, okB := opts.Archetypes[b]
+	if okA && okB {
+		if p, ok := opts.ArchetypeProbabilities[[2]string{archetypeA, archetypeB}]; ok {
+			return p
+		}
+		if p, ok := opts.ArchetypeProbabilities[[2]string{archetypeB, archetypeA}]; ok {
+			return 1 - p
+		}
+	}
+	return 0.5
+}
+
+func (opts SimulationOptions) simulateMatch(rng *rand.Rand, pairing Pairing) Pairing {
+	switch roll := rng.Float64(); {
+	case roll < opts.DrawProbability:
+		pairing.playeraWins, pairing.playerbWins, pairing.draws = 1, 1, 1
+	case roll < opts.DrawProbability+(1-opts.DrawProbability)*opts.winProbability(pairing.playera, pairing.playerb):
+		pairing.playeraWins, pairing.playerbWins, pairing.draws = 2, 0, 0
+	default:
+		pairing.playeraWins, pairing.playerbWins, pairing.draws = 0, 2, 0
+	}
+	return pairing
+}
+
+// clone returns a copy of the tournament which can be changed without affecting the original.
+func (t *Tournament) clone() Tournament {
+	c := *t
+	c.players = map[int]Player{}
+	for id, player := range t.players {
+		c.players[id] = player
+	}
+	c.rounds = make([]Round, len(t.rounds))
+	for i, round := range t.rounds {
+		c.rounds[i] = append(Round{}, round...)
+	}
+	c.times = append([]roundTimes{}, t.times...)
+	c.lastRanks = map[int]int{}
+	for id, rank := range t.lastRanks {
+		c.lastRanks[id] = rank
+	}
+	c.nameIndex = map[string]int{}
+	for name, id := range t.nameIndex {
+		c.nameIndex[name] = id
+	}
+	c.externalIds = map[string]int{}
+	for externalId, id := range t.externalIds {
+		c.externalIds[externalId] = id
+	}
+	c.voided = append([]VoidedRound{}, t.voided...)
+	c.finals = nil
+	c.handlers = nil
+	c.ratingSystem = nil
+	c.undoLimit, c.undo, c.redo = 0, nil, nil
+	c.audit = nil
+	c.autosaver, c.webhooks = nil, nil
+	c.opponents, c.pairingIndex = nil, nil
+	c.invalidateStandings()
+	return c
+}
@@ -0,0 +1,76 @@
+package swisstools
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSimulateMatchupProbabilities(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Rounds: 3})
+	for i := 1; i <= 8; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	archetypes := map[int]string{}
+	for i := 1; i <= 8; i++ {
+		archetypes[i] = "Control"
+	}
+	archetypes[1] = "Aggro"
+	opts := SimulationOptions{
+		CutSize:                2,
+		Archetypes:             archetypes,
+		ArchetypeProbabilities: map[[2]string]float64{{"Control", "Aggro"}: 0.05},
+		Seed:                   1,
+	}
+	result, err := tournament.SimulateOutcomes(500, opts)
+	if err != nil {
+		t.Fatalf("SimulateOutcomes returned an error: %s", err)
+	}
+	aggro := result.Players[1]
+	if aggro.CutProbability < 0.6 || aggro.RankProbabilities[0] < 0.5 {
+		t.Fatalf("Expecting the favored Aggro player to make the cut most of the time, got %+v.", aggro)
+	}
+	total := 0.0
+	for _, projection := range result.Players {
+		total += projection.CutProbability
+	}
+	if total < 1.99 || total > 2.01 {
+		t.Fatalf("Expecting cut probabilities to add up to 2, got %f.", total)
+	}
+	if len(tournament.GetRound()) != 0 || tournament.CurrentRoundNumber() != 1 {
+		t.Fatal("Simulation changed the tournament.")
+	}
+}
+
+func TestSimulateDoesNotAutosave(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Rounds: 2})
+	for i := 1; i <= 4; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	storage := &memoryStorage{}
+	tournament.EnableAutosave(storage, "weekly", AutosaveChange)
+	saves := storage.saves
+	if _, err := tournament.SimulateOutcomes(10, SimulationOptions{CutSize: 2, Seed: 1}); err != nil {
+		t.Fatalf("SimulateOutcomes returned an error: %s", err)
+	}
+	if storage.saves != saves {
+		t.Fatalf("Expecting simulated rounds not to be saved, got %d saves.", storage.saves-saves)
+	}
+}
+
+func TestSimulateStages(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Stages: []Stage{{Rounds: 2}, {Rounds: 2}}})
+	for i := 1; i <= 8; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	result, err := tournament.SimulateOutcomes(20, SimulationOptions{Seed: 1})
+	if err != nil {
+		t.Fatalf("SimulateOutcomes returned an error: %s", err)
+	}
+	total := 0.0
+	for _, projection := range result.Players {
+		total += projection.ExpectedPoints
+	}
+	if total < 47.99 || total > 48.01 {
+		t.Fatalf("Expecting 4 rounds of 4 matches to give 48 points, got %f.", total)
+	}
+}
@@ -0,0 +1,96 @@
+package swisstools
+
+import (
+	"fmt"
+)
+
+// Stage is a phase of Swiss rounds in a multi-stage tournament, such as day 1 and day 2 of a large
+// event. The top cut after the last stage is configured with TournamentConfig.Finals.
+type Stage struct {
+	Name   string `json:"name"`
+	Rounds int    `json:"rounds"`
+	// Players advancing into the stage, all players with at least MinPoints and of those at most
+	// the best Top if Top is set. Both are ignored for the first stage.
+	MinPoints int `json:"min_points,omitempty"`
+	Top       int `json:"top,omitempty"`
+	// ResetPoints starts everyone in the stage on 0 points, otherwise points carry forward. Rematches
+	// are avoided across stages either way.
+	ResetPoints bool `json:"reset_points,omitempty"`
+}
+
+// CurrentStage returns the number of the stage being played starting from 1, 0 if the tournament
+// has no stages.
+func (t *Tournament) CurrentStage() int {
+	if len(t.config.Stages) == 0 {
+		return 0
+	}
+	return len(t.stageStarts) + 1
+}
+
+// stageStart returns the first round of the current stage.
+func (t *Tournament) stageStart() int {
+	if len(t.stageStarts) == 0 {
+		return 1
+	}
+	return t.stageStarts[len(t.stageStarts)-1]
+}
+
+// stageComplete returns whether every round of the current stage was played and another stage
+// follows.
+func (t *Tournament) stageComplete() bool {
+	stage := t.CurrentStage()
+	if stage == 0 || stage >= len(t.config.Stages) {
+		return false
+	}
+	return t.currentRound-t.stageStart() >= t.config.Stages[stage-1].Rounds
+}
+
+// pointsFrom returns the first round whose results count for the standings after round, the start
+// of the last stage up to round which reset points.
+func (t *Tournament) pointsFrom(round int) int {
+	from := 1
+	for i, start := range t.stageStarts {
+		if start <= round && t.config.Stages[i+1].ResetPoints {
+			from = start
+		}
+	}
+	return from
+}
+
+// AdvanceStage ends the current stage once all of its rounds are played and starts the next one.
+// Players who do not qualify for the next stage are dropped and keep their place in the standings.
+func (t *Tournament) AdvanceStage() error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	stage := t.CurrentStage()
+	if stage == 0 || stage >= len(t.config.Stages) {
+		return ErrNoNextStage
+	}
+	if !t.stageComplete() {
+		return ErrStageNotFinished
+	}
+	if len(t.rounds[t.currentRound]) > 0 {
+		return ErrRoundAlreadyPaired
+	}
+	t.recordChange(t.snapshot())
+	next := t.config.Stages[stage]
+	advanced := 0
+	for _, standing := range t.GetStandings() {
+		if standing.Dropped {
+			continue
+		}
+		if standing.Points >= next.MinPoints && (next.Top == 0 || advanced < next.Top) {
+			advanced++
+			continue
+		}
+		player := t.players[standing.Id]
+		player.dropped = true
+		t.players[standing.Id] = player
+		t.emit(Event{Type: PlayerDropped, PlayerId: standing.Id})
+	}
+	t.stageStarts = append(t.stageStarts, t.currentRound)
+	t.updatePlayerStandings()
+	t.logChange("advance_stage", t.currentRound, 0, "", fmt.Sprintf("stage %d, %d players", stage+1, advanced))
+	return nil
+}
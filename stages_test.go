@@ -0,0 +1,63 @@
+package swisstools
+
+import "testing"
+
+func TestStages(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Stages: []Stage{
+		{Name: "Day 1", Rounds: 2},
+		{Name: "Day 2", Rounds: 1, MinPoints: 3, Top: 2, ResetPoints: true},
+	}})
+	if tournament.config.Rounds != 3 || tournament.CurrentStage() != 1 {
+		t.Fatalf("Expecting 3 rounds in stage 1, got %d rounds in stage %d.", tournament.config.Rounds, tournament.CurrentStage())
+	}
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	if err := tournament.AdvanceStage(); err == nil {
+		t.Fatalf("Expecting an error advancing an unfinished stage.")
+	}
+	for round := 1; round <= 2; round++ {
+		tournament.Pair()
+		for _, pairing := range tournament.GetRound() {
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+		tournament.NextRound()
+	}
+	if err := tournament.Pair(); err == nil {
+		t.Fatalf("Expecting an error pairing past the end of the stage.")
+	}
+	if err := tournament.AdvanceStage(); err != nil {
+		t.Fatalf("AdvanceStage returned an error: %s", err)
+	}
+	standings := tournament.GetStandings()
+	if tournament.CurrentStage() != 2 || standings[0].Points != 6 || standings[1].Dropped || !standings[2].Dropped || !standings[3].Dropped {
+		t.Fatalf("Expecting the top 2 to advance with the day 1 standings kept, got %+v.", standings)
+	}
+	if err := tournament.AdvanceStage(); err == nil {
+		t.Fatalf("Expecting an error advancing past the last stage.")
+	}
+
+	data, _ := tournament.DumpTournament()
+	loaded, err := LoadTournament(data)
+	if err != nil {
+		t.Fatalf("LoadTournament returned an error: %s", err)
+	}
+	for _, tournament := range []Tournament{tournament, loaded} {
+		top := standings[0].Id
+		if tournament.players[top].points != 0 {
+			t.Fatalf("Expecting points to be reset for the new stage, got %d.", tournament.players[top].points)
+		}
+		if err := tournament.Pair(); err != nil {
+			t.Fatalf("Pair returned an error: %s", err)
+		}
+		if round := tournament.GetRound(); len(round) != 1 || round[0].IsBye() {
+			t.Fatalf("Expecting a single match between the two advancing players, got %v.", round)
+		}
+		tournament.AddResult(standings[1].Id, 2, 0, 0)
+		tournament.NextRound()
+		winner := tournament.GetStandings()[0]
+		if winner.Id != standings[1].Id || winner.Points != 3 {
+			t.Fatalf("Expecting the day 2 winner first with 3 points, got %+v.", winner)
+		}
+	}
+}
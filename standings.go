@@ -0,0 +1,282 @@
+package swisstools
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+type PlayerStanding struct {
+	Rank   int
+	Id     int
+	Name   string
+	Points int
+	Wins   int
+	Losses int
+	Draws  int
+	// RankChange is how many places the player moved up (positive) or down (negative) in the last
+	// completed round. It is 0 before the second round is completed.
+	RankChange int
+	Dropped    bool
+	Record     string // Wins, losses and draws as "W-L-D".
+	// MatchesPlayed counts the matches the player was paired for, and ByesReceived the byes.
+	MatchesPlayed int
+	ByesReceived  int
+	Tiebreakers   Tiebreakers
+	Members       []string // Members of a team, see AddTeam.
+	// Ratings before the tournament and after the last completed round, and the change in each
+	// completed round, when a rating system is set with SetRatingSystem.
+	InitialRating float64
+	Rating        float64
+	RatingChanges []float64
+}
+
+// Movement describes RankChange as "up", "down" or "steady".
+func (s PlayerStanding) Movement() string {
+	switch {
+	case s.RankChange > 0:
+		return "up"
+	case s.RankChange < 0:
+		return "down"
+	}
+	return "steady"
+}
+
+// StandingsPoint is one player's position after one round, a row of the standings history.
+type StandingsPoint struct {
+	Round    int    `json:"round"`
+	PlayerId int    `json:"player_id"`
+	Name     string `json:"name"`
+	Points   int    `json:"points"`
+	Rank     int    `json:"rank"`
+	// Rating after the round and its change in the round, when a rating system is set.
+	Rating       float64 `json:"rating,omitempty"`
+	RatingChange float64 `json:"rating_change,omitempty"`
+}
+
+// GetStandings returns the standings after the last completed round, best player first.
+func (t *Tournament) GetStandings() []PlayerStanding {
+	return append([]PlayerStanding{}, t.cachedStandings()...)
+}
+
+// DroppedPlacement decides where GetStandingsWithOptions lists dropped players.
+type DroppedPlacement string
+
+const (
+	DroppedInPlace DroppedPlacement = ""       // Rank dropped players like everyone else.
+	DroppedLast    DroppedPlacement = "last"   // Move dropped players below the active ones.
+	DroppedHidden  DroppedPlacement = "hidden" // Leave dropped players out.
+)
+
+// StandingsOptions adjusts the standings returned by GetStandingsWithOptions.
+type StandingsOptions struct {
+	Dropped DroppedPlacement
+}
+
+// GetStandingsWithOptions returns the standings like GetStandings, with dropped players placed as
+// opts.Dropped asks. Ranks are renumbered when dropped players are moved or hidden.
+func (t *Tournament) GetStandingsWithOptions(opts StandingsOptions) []PlayerStanding {
+	standings := t.GetStandings()
+	if opts.Dropped == DroppedInPlace {
+		return standings
+	}
+	active, dropped := []PlayerStanding{}, []PlayerStanding{}
+	for _, standing := range standings {
+		if standing.Dropped {
+			dropped = append(dropped, standing)
+		} else {
+			active = append(active, standing)
+		}
+	}
+	if opts.Dropped == DroppedLast {
+		active = append(active, dropped...)
+	}
+	for i := range active {
+		active[i].Rank = i + 1
+	}
+	return active
+}
+
+// GetStandingsAtRound returns the standings as they were after round, computed from the results of
+// rounds 1 to round only. RankChange is relative to the round before.
+func (t *Tournament) GetStandingsAtRound(round int) ([]PlayerStanding, error) {
+	if round < 1 || round >= t.currentRound {
+		return nil, ErrRoundOutOfRange
+	}
+	standings := t.standingsAfter(round)
+	if round > 1 {
+		previous := map[int]int{}
+		for _, standing := range t.standingsAfter(round - 1) {
+			previous[standing.Id] = standing.Rank
+		}
+		for i, standing := range standings {
+			if rank, ok := previous[standing.Id]; ok {
+				standings[i].RankChange = rank - standing.Rank
+			}
+		}
+	}
+	return standings, nil
+}
+
+// GetStandingsRange returns at most limit standings starting at offset, for paging through the
+// standings of large events.
+func (t *Tournament) GetStandingsRange(offset int, limit int) []PlayerStanding {
+	standings := t.cachedStandings()
+	if offset < 0 || offset >= len(standings) || limit <= 0 {
+		return []PlayerStanding{}
+	}
+	end := offset + limit
+	if end > len(standings) {
+		end = len(standings)
+	}
+	return append([]PlayerStanding{}, standings[offset:end]...)
+}
+
+func (t *Tournament) GetStandingForPlayer(id int) (PlayerStanding, error) {
+	t.cachedStandings()
+	i, ok := t.standingsIndex[id]
+	if !ok {
+		return PlayerStanding{}, ErrPlayerNotFound
+	}
+	return t.standings[i], nil
+}
+
+// cachedStandings returns the standings after the last completed round, computing them only if
+// they were invalidated since the last call.
+func (t *Tournament) cachedStandings() []PlayerStanding {
+	if t.standings != nil {
+		return t.standings
+	}
+	standings := t.standingsAfter(t.currentRound - 1)
+	t.standingsIndex = map[int]int{}
+	for i, standing := range standings {
+		if t.currentRound > 2 {
+			if rank, ok := t.lastRanks[standing.Id]; ok {
+				standings[i].RankChange = rank - standing.Rank
+			}
+		}
+		t.standingsIndex[standing.Id] = i
+	}
+	t.standings = standings
+	return standings
+}
+
+// invalidateStandings discards the cached standings after a change which affects them.
+func (t *Tournament) invalidateStandings() {
+	t.standings = nil
+	t.standingsIndex = nil
+}
+
+// standingsAfter computes the standings using only the results of rounds 1 to round.
+func (t *Tournament) standingsAfter(round int) []PlayerStanding {
+	records := t.recordsAfter(round)
+	var ratings map[int][]Rating
+	if t.ratingSystem != nil {
+		ratings = t.ratingsAfter(round)
+	}
+	standings := []PlayerStanding{}
+	for id, record := range records {
+		if t.players[id].phantom || t.players[id].disqualified {
+			continue
+		}
+		standing := record.standing
+		standing.Record = fmt.Sprintf("%d-%d-%d", standing.Wins, standing.Losses, standing.Draws)
+		standing.MatchesPlayed, standing.ByesReceived = record.matches-record.byes, record.byes
+		standing.Tiebreakers = t.computeTiebreakers(id, records)
+		if history, ok := ratings[id]; ok {
+			standing.InitialRating, standing.Rating = history[0].Value, history[len(history)-1].Value
+			standing.RatingChanges = []float64{}
+			for i := 1; i < len(history); i++ {
+				standing.RatingChanges = append(standing.RatingChanges, history[i].Value-history[i-1].Value)
+			}
+		}
+		standings = append(standings, standing)
+	}
+	collator := t.collator()
+	sort.Slice(standings, func(i, j int) bool {
+		if standings[i].Points != standings[j].Points {
+			return standings[i].Points > standings[j].Points
+		}
+		for _, tiebreaker := range t.config.Tiebreakers {
+			a, b := standings[i].Tiebreakers.value(tiebreaker), standings[j].Tiebreakers.value(tiebreaker)
+			if a != b {
+				return a > b
+			}
+		}
+		if c := collator.CompareString(standings[i].Name, standings[j].Name); c != 0 {
+			return c < 0
+		}
+		return standings[i].Id < standings[j].Id
+	})
+	for i := range standings {
+		standings[i].Rank = i + 1
+	}
+	return standings
+}
+
+// FormatStandings renders the standings as a table in rank order, with each player's record as
+// wins-losses-draws, their tiebreakers and whether they dropped.
+func (t *Tournament) FormatStandings(w io.Writer, opts FormatOptions) error {
+	percent := func(percentage float64) string {
+		return fmt.Sprintf("%.2f%%", percentage*100)
+	}
+	rows := [][]string{}
+	for _, standing := range t.GetStandingsWithOptions(StandingsOptions{Dropped: opts.Dropped}) {
+		status := ""
+		if standing.Dropped {
+			status = "dropped"
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(standing.Rank),
+			standing.Name,
+			standing.Record,
+			strconv.Itoa(standing.Points),
+			percent(standing.Tiebreakers.OpponentMatchWinPercentage),
+			percent(standing.Tiebreakers.GameWinPercentage),
+			percent(standing.Tiebreakers.OpponentGameWinPercentage),
+			status,
+		})
+	}
+	return renderTable(w, opts.Format, []string{"Rank", "Name", "Record", "Points", "OMW%", "GW%", "OGW%", "Status"}, rows)
+}
+
+// StandingsHistory returns every player's points and rank after each completed round, ordered by
+// round and rank.
+func (t *Tournament) StandingsHistory() []StandingsPoint {
+	history := []StandingsPoint{}
+	for round := 1; round < t.currentRound; round++ {
+		for _, standing := range t.standingsAfter(round) {
+			point := StandingsPoint{Round: round, PlayerId: standing.Id, Name: standing.Name, Points: standing.Points, Rank: standing.Rank, Rating: standing.Rating}
+			if len(standing.RatingChanges) > 0 {
+				point.RatingChange = standing.RatingChanges[len(standing.RatingChanges)-1]
+			}
+			history = append(history, point)
+		}
+	}
+	return history
+}
+
+func (t *Tournament) ExportStandingsHistoryJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(t.StandingsHistory())
+}
+
+func (t *Tournament) ExportStandingsHistoryCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"round", "player_id", "name", "points", "rank"}
+	if t.ratingSystem != nil {
+		header = append(header, "rating", "rating_change")
+	}
+	writer.Write(header)
+	for _, point := range t.StandingsHistory() {
+		row := []string{strconv.Itoa(point.Round), strconv.Itoa(point.PlayerId), point.Name, strconv.Itoa(point.Points), strconv.Itoa(point.Rank)}
+		if t.ratingSystem != nil {
+			row = append(row, formatRating(point.Rating), formatRating(point.RatingChange))
+		}
+		writer.Write(row)
+	}
+	writer.Flush()
+	return writer.Error()
+}
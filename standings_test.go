@@ -0,0 +1,161 @@
+package swisstools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStandingsHistoryCSV(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(2, 2, 0, 0)
+	tournament.NextRound()
+	tournament.Pair()
+	tournament.AddResult(1, 2, 1, 0)
+	tournament.NextRound()
+	var buf bytes.Buffer
+	if err := tournament.ExportStandingsHistoryCSV(&buf); err != nil {
+		t.Fatalf("ExportStandingsHistoryCSV returned an error: %s", err)
+	}
+	expected := "round,player_id,name,points,rank\n1,2,Sam,3,1\n1,1,Dylan,0,2\n2,1,Dylan,3,1\n2,2,Sam,3,2\n"
+	if buf.String() != expected {
+		t.Fatalf("Expecting:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestStandingsMovement(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(2, 2, 0, 0)
+	tournament.NextRound()
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.NextRound()
+	standings := tournament.GetStandings()
+	if standings[0].Id != 1 || standings[0].RankChange != 1 || standings[0].Movement() != "up" {
+		t.Fatalf("Expecting Dylan to move up to first, got %+v.", standings[0])
+	}
+	if standings[1].Movement() != "down" {
+		t.Fatalf("Expecting Sam to move down, got %+v.", standings[1])
+	}
+}
+
+func TestStandingsRange(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Alex", "Bo", "Cy", "Dee", "Eve"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.rounds[1] = Round{
+		{playera: 5, playerb: 1, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 4, playerb: 2, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 3, playerb: byeId, playeraWins: 2, playerbWins: 0, draws: 0},
+	}
+	tournament.NextRound()
+	page := tournament.GetStandingsRange(2, 2)
+	if len(page) != 2 || page[0].Name != "Eve" || page[1].Name != "Alex" {
+		t.Fatalf("Expecting Eve and Alex on ranks 3 and 4, got %+v.", page)
+	}
+	if page := tournament.GetStandingsRange(4, 10); len(page) != 1 || page[0].Name != "Bo" {
+		t.Fatalf("Expecting only Bo on the last page, got %+v.", page)
+	}
+	standing, err := tournament.GetStandingForPlayer(4)
+	if err != nil || standing.Rank != 2 {
+		t.Fatalf("Expecting Dee on rank 2, got %+v.", standing)
+	}
+	if _, err := tournament.GetStandingForPlayer(9); err == nil {
+		t.Fatal("Unknown player but GetStandingForPlayer did not return an error.")
+	}
+}
+
+func TestFormatStandings(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 1, 0)
+	tournament.NextRound()
+	tournament.DropPlayer(1)
+	if err := tournament.Pair(); err != nil {
+		t.Fatalf("Pair returned an error: %s", err)
+	}
+	if round := tournament.GetRound(); len(round) != 1 || !round[0].IsBye() {
+		t.Fatalf("Expecting the dropped player not to be paired, got %+v.", round)
+	}
+	var buf bytes.Buffer
+	tournament.FormatStandings(&buf, FormatOptions{})
+	lines := strings.Split(buf.String(), "\n")
+	if !strings.Contains(lines[3], "Dylan") || !strings.Contains(lines[3], "1-0-0") || !strings.Contains(lines[3], "66.67%") || !strings.Contains(lines[3], "dropped") {
+		t.Fatalf("Expecting Dylan first with record, tiebreakers and drop status, got:\n%s", buf.String())
+	}
+}
+
+func TestStandingsRecordAndDrops(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		if !pairing.IsBye() {
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+	}
+	tournament.NextRound()
+	standings := tournament.GetStandings()
+	for _, standing := range standings {
+		if standing.Record != fmt.Sprintf("%d-%d-%d", standing.Wins, standing.Losses, standing.Draws) || standing.MatchesPlayed+standing.ByesReceived != 1 {
+			t.Fatalf("Expecting a record string and one match or bye, got %+v.", standing)
+		}
+	}
+	leader := standings[0].Id
+	tournament.DropPlayer(leader)
+	if last := tournament.GetStandingsWithOptions(StandingsOptions{Dropped: DroppedLast}); last[2].Id != leader || last[2].Rank != 3 || last[0].Rank != 1 {
+		t.Fatalf("Expecting the dropped leader last, got %+v.", last)
+	}
+	if hidden := tournament.GetStandingsWithOptions(StandingsOptions{Dropped: DroppedHidden}); len(hidden) != 2 || hidden[0].Rank != 1 {
+		t.Fatalf("Expecting the dropped leader hidden, got %+v.", hidden)
+	}
+}
+
+func TestGetStandingsAtRound(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	for round := 1; round <= 2; round++ {
+		tournament.Pair()
+		for _, pairing := range tournament.GetRound() {
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+		tournament.NextRound()
+	}
+	if _, err := tournament.GetStandingsAtRound(3); err == nil {
+		t.Fatalf("Expecting an error for a round that is not completed.")
+	}
+	first, _ := tournament.GetStandingsAtRound(1)
+	if first[0].Points != 3 || first[3].Points != 0 || first[0].Record != "1-0-0" {
+		t.Fatalf("Expecting the standings after round 1, got %+v.", first)
+	}
+	second, _ := tournament.GetStandingsAtRound(2)
+	if !reflect.DeepEqual(second, tournament.GetStandings()) {
+		t.Fatalf("Expecting the standings after round 2 to be the current standings, got %+v.", second)
+	}
+
+	data, _ := tournament.DumpTournamentWithStandings()
+	export := exportTournament{}
+	json.Unmarshal(data, &export)
+	if len(export.Standings) != 2 || export.Standings[0][0].Points != 3 || export.Standings[1][0].Points != 6 {
+		t.Fatalf("Expecting standings for both rounds in the dump, got %+v.", export.Standings)
+	}
+	if _, err := LoadTournament(data); err != nil {
+		t.Fatalf("LoadTournament returned an error: %s", err)
+	}
+}
@@ -0,0 +1,148 @@
+package swisstools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Store keeps many tournaments in memory keyed by id. The Store itself is safe for concurrent use;
+// callers are responsible for synchronizing access to the tournaments it returns, see SyncTournament.
+type Store struct {
+	mu          sync.RWMutex
+	tournaments map[string]*Tournament
+}
+
+func NewStore() *Store {
+	return &Store{tournaments: map[string]*Tournament{}}
+}
+
+// Create adds a new tournament under id, which must be usable as a file name, see Persist.
+func (s *Store) Create(id string, config TournamentConfig) (*Tournament, error) {
+	if err := checkId(id); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tournaments[id]; ok {
+		return nil, ErrDuplicateTournament
+	}
+	tournament := NewTournamentWithConfig(config)
+	s.tournaments[id] = &tournament
+	return &tournament, nil
+}
+
+// Put adds an existing tournament, replacing any tournament stored under the same id.
+func (s *Store) Put(id string, tournament *Tournament) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tournaments[id] = tournament
+}
+
+func (s *Store) Get(id string) (*Tournament, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tournament, ok := s.tournaments[id]
+	if !ok {
+		return nil, ErrTournamentNotFound
+	}
+	return tournament, nil
+}
+
+// List returns the ids of all stored tournaments in sorted order.
+func (s *Store) List() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := []string{}
+	for id := range s.tournaments {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (s *Store) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tournaments[id]; !ok {
+		return ErrTournamentNotFound
+	}
+	delete(s.tournaments, id)
+	return nil
+}
+
+// Persist writes every tournament to dir as <id>.json. Every file is replaced as a whole, so a crash
+// never leaves a partial file. Ids that are not plain file names, such as ones added with Put, are
+// refused with ErrInvalidId.
+func (s *Store) Persist(dir string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for id := range s.tournaments {
+		if err := checkId(id); err != nil {
+			return fmt.Errorf("%q: %w", id, err)
+		}
+	}
+	for id, tournament := range s.tournaments {
+		data, err := tournament.DumpTournament()
+		if err != nil {
+			return err
+		}
+		if err := writeFile(dir, id+".json", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkId makes sure a tournament id can be used as a file name without leaving the directory.
+func checkId(id string) error {
+	if id == "" || id == "." || strings.Contains(id, "..") || strings.ContainsAny(id, `/\`) || filepath.Base(id) != id {
+		return ErrInvalidId
+	}
+	return nil
+}
+
+// writeFile writes data to name in dir through a temporary file, so that a crash never leaves a
+// partial file.
+func writeFile(dir string, name string, data []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), filepath.Join(dir, name))
+}
+
+// LoadStore reads all tournaments written to dir by Persist.
+func LoadStore(dir string) (*Store, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	s := NewStore()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		tournament, err := LoadTournament(data)
+		if err != nil {
+			return nil, err
+		}
+		s.tournaments[strings.TrimSuffix(filepath.Base(file), ".json")] = &tournament
+	}
+	return s, nil
+}
@@ -0,0 +1,63 @@
+package swisstools
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestStorePersist(t *testing.T) {
+	store := NewStore()
+	tournament, err := store.Create("weekly", TournamentConfig{Rounds: 3})
+	if err != nil {
+		t.Fatalf("Create returned an error: %s", err)
+	}
+	if _, err := store.Create("weekly", TournamentConfig{}); err == nil {
+		t.Fatal("Duplicate id but Create did not return an error.")
+	}
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.NextRound()
+	store.Create("casual", TournamentConfig{})
+	store.Delete("casual")
+
+	dir := t.TempDir()
+	if err := store.Persist(dir); err != nil {
+		t.Fatalf("Persist returned an error: %s", err)
+	}
+	loaded, err := LoadStore(dir)
+	if err != nil {
+		t.Fatalf("LoadStore returned an error: %s", err)
+	}
+	if ids := loaded.List(); len(ids) != 1 || ids[0] != "weekly" {
+		t.Fatalf("Expecting only the weekly tournament, got %v.", ids)
+	}
+	weekly, _ := loaded.Get("weekly")
+	if weekly.players[1].points != 3 || weekly.currentRound != 2 || weekly.config.Rounds != 3 {
+		t.Fatalf("Tournament not restored, got %+v.", weekly)
+	}
+}
+
+func TestStoreRejectsPathIds(t *testing.T) {
+	store := NewStore()
+	for _, id := range []string{"", "..", "../escape", "nested/weekly", `nested\weekly`} {
+		if _, err := store.Create(id, TournamentConfig{}); !errors.Is(err, ErrInvalidId) {
+			t.Fatalf("Expecting ErrInvalidId for %q, got %v.", id, err)
+		}
+	}
+	parent := t.TempDir()
+	dir := filepath.Join(parent, "store")
+	tournament := NewTournament()
+	store.Put("../escape", &tournament)
+	if err := store.Persist(dir); !errors.Is(err, ErrInvalidId) {
+		t.Fatalf("Expecting ErrInvalidId persisting an id with a path, got %v.", err)
+	}
+	if err := (FileStorage{Dir: dir}).Save("../escape", []byte("{}")); !errors.Is(err, ErrInvalidId) {
+		t.Fatalf("Expecting ErrInvalidId saving an id with a path, got %v.", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(parent, "*.json")); len(files) != 0 {
+		t.Fatalf("Expecting nothing written outside the directory, got %v.", files)
+	}
+}
@@ -0,0 +1,205 @@
+package swisstools
+
+import (
+	"fmt"
+)
+
+// PairingStrategy decides the pairings of a round. Tournament.Pair records the result of every bye and
+// match against a phantom, allocates colors in chess mode and numbers the tables, so a strategy only
+// decides who plays whom.
+type PairingStrategy interface {
+	// Pair returns a pairing for every player in state.Players, made with NewPairing and NewBye. An
+	// error leaves the round unpaired.
+	Pair(state TournamentState) ([]Pairing, error)
+}
+
+// TournamentState is what a PairingStrategy sees of the tournament.
+type TournamentState struct {
+	Round  int
+	Config TournamentConfig
+	// Players to pair ordered by points, see SwissStrategy for the order of players on equal points.
+	// Dropped players and players already paired this round, such as with an assigned bye, are left
+	// out.
+	Players []PlayerState
+	t       *Tournament
+}
+
+// PlayerState is a player to pair.
+type PlayerState struct {
+	Id      int
+	Name    string
+	Points  int
+	Phantom bool
+	Seed    int
+}
+
+// HavePlayed returns whether two players met in an earlier round.
+func (s TournamentState) HavePlayed(a int, b int) bool {
+	return s.t.havePlayedBefore(a, b)
+}
+
+// Standings returns the standings after the last completed round.
+func (s TournamentState) Standings() []PlayerStanding {
+	return s.t.GetStandings()
+}
+
+func (s TournamentState) ids() []int {
+	ids := []int{}
+	for _, player := range s.Players {
+		ids = append(ids, player.Id)
+	}
+	return ids
+}
+
+// check makes sure pairings pair every player of the state exactly once.
+func (s TournamentState) check(pairings []Pairing) error {
+	paired := map[int]bool{}
+	for _, player := range s.Players {
+		paired[player.Id] = false
+	}
+	for _, pairing := range pairings {
+		for _, id := range []int{pairing.playera, pairing.playerb} {
+			if id == byeId && id == pairing.playerb {
+				continue
+			}
+			done, ok := paired[id]
+			if !ok {
+				return fmt.Errorf("player %d cannot be paired", id)
+			}
+			if done {
+				return fmt.Errorf("player %d is paired twice", id)
+			}
+			paired[id] = true
+		}
+	}
+	for id, done := range paired {
+		if !done {
+			return fmt.Errorf("player %d is not paired", id)
+		}
+	}
+	return nil
+}
+
+func (t *Tournament) state() TournamentState {
+	state := TournamentState{Round: t.currentRound, Config: t.config, Players: []PlayerState{}, t: t}
+	for _, id := range t.pairingOrder() {
+		player := t.players[id]
+		state.Players = append(state.Players, PlayerState{Id: id, Name: player.name, Points: player.points, Phantom: player.phantom, Seed: player.seed})
+	}
+	return state
+}
+
+// SetPairingStrategy replaces the pairing strategy used by Pair, nil restores the one selected by
+// TournamentConfig.PairingAlgorithm. The strategy is not part of a dump and has to be set again after
+// LoadTournament.
+func (t *Tournament) SetPairingStrategy(strategy PairingStrategy) {
+	t.pairingStrategy = strategy
+}
+
+// NewPairing returns a match between two players for a PairingStrategy.
+func NewPairing(a int, b int) Pairing {
+	return Pairing{playera: a, playerb: b, playeraWins: -1, playerbWins: -1, draws: -1}
+}
+
+// NewBye returns a bye for a PairingStrategy.
+func NewBye(id int) Pairing {
+	return Pairing{playera: id, playerb: byeId, playeraWins: 2, playerbWins: 0, draws: 0}
+}
+
+// SwissStrategy is the default pairing strategy. If there is an odd number of players the lowest
+// placed player of those with the fewest byes receives a bye, so no one gets a second bye before
+// everyone else had one, see TournamentConfig.ByePolicy. TournamentConfig.MaxByes allows more byes
+// where the round could not be paired without rematches otherwise. The rest are paired from the top
+// of the standings down against the highest placed opponent they have not played yet, within
+// TournamentConfig.MaxBracketDistance, with legal colors in chess mode and respecting pairing
+// constraints, relaxing those one at a time if the round cannot be paired otherwise.
+type SwissStrategy struct{}
+
+func (SwissStrategy) Pair(state TournamentState) ([]Pairing, error) {
+	t := state.t
+	if t == nil {
+		return nil, ErrInvalidState
+	}
+	players := state.ids()
+	withinLimit := t.bracketLimit(players)
+
+	bye := 0
+	if len(players)%2 == 1 {
+		bye = t.byeCandidates(players)[0]
+		for i, id := range players {
+			if id == bye {
+				players = append(players[:i:i], players[i+1:]...)
+				break
+			}
+		}
+	}
+	if t.seeded() {
+		players = t.seedOrder(players)
+	}
+
+	// Prefer new opponents within the bracket limit with legal colors, and players not to be avoided,
+	// and relax the constraints one at a time until the round can be paired. Extra byes, if allowed,
+	// come before rematches. Players who must never meet are never paired.
+	newOpponent := func(a int, b int) bool { return !t.havePlayedBefore(a, b) }
+	realMatch := func(a int, b int) bool { return !t.players[a].phantom || !t.players[b].phantom }
+	allowed := func(a int, b int) bool { return t.pairingConstraint(a, b) != NeverPair }
+	preferred := func(a int, b int) bool { return t.pairingConstraint(a, b) == "" }
+	levels := [][]func(int, int) bool{}
+	for _, avoid := range [][]func(int, int) bool{{preferred}, nil} {
+		for _, constraints := range [][]func(int, int) bool{
+			{newOpponent, withinLimit, t.colorsCompatible},
+			{newOpponent, t.colorsCompatible},
+			{newOpponent, withinLimit},
+			{newOpponent},
+		} {
+			levels = append(levels, append(append([]func(int, int) bool{realMatch, allowed}, avoid...), constraints...))
+		}
+	}
+	var matches [][2]int
+	for _, constraints := range levels {
+		if matches = pairPlayers(players, allOf(constraints)); matches != nil {
+			break
+		}
+	}
+	for extra := 2; matches == nil && extra <= t.extraByes(len(state.Players)); extra += 2 {
+		// Extra byes are stand-in opponents with ids below byeId, placed last so that they are only
+		// used when needed.
+		withByes := append([]int{}, players...)
+		for i := 0; i < extra; i++ {
+			withByes = append(withByes, byeId-1-i)
+		}
+		allowed := allOf(levels[len(levels)-1])
+		matches = pairPlayers(withByes, func(a int, b int) bool { return a < byeId || b < byeId || allowed(a, b) })
+	}
+	if !t.config.NoRematches {
+		for _, constraints := range [][]func(int, int) bool{{realMatch, allowed, withinLimit}, {realMatch, allowed}, {allowed}} {
+			if matches != nil {
+				break
+			}
+			matches = pairPlayers(players, allOf(constraints))
+		}
+		if matches == nil {
+			return nil, ErrNoValidPairing
+		}
+	}
+	if matches == nil {
+		return nil, &RematchError{Points: t.players[t.rematchBracket(players)].points}
+	}
+
+	pairings := []Pairing{}
+	if bye != 0 {
+		pairings = append(pairings, NewBye(bye))
+	}
+	for _, match := range matches {
+		switch {
+		case match[0] < byeId && match[1] < byeId:
+		case match[1] < byeId:
+			pairings = append(pairings, NewBye(match[0]))
+		case match[0] < byeId:
+			pairings = append(pairings, NewBye(match[1]))
+		default:
+			pairings = append(pairings, NewPairing(match[0], match[1]))
+		}
+	}
+	return pairings, nil
+}
@@ -0,0 +1,112 @@
+package swisstools
+
+import "testing"
+
+type pairingStrategyFunc func(TournamentState) ([]Pairing, error)
+
+func (f pairingStrategyFunc) Pair(state TournamentState) ([]Pairing, error) {
+	return f(state)
+}
+
+func TestPairingStrategy(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.AddPlayer("Alex")
+	tournament.AddPhantom("Phantom")
+
+	tournament.SetPairingStrategy(pairingStrategyFunc(func(state TournamentState) ([]Pairing, error) {
+		return []Pairing{NewPairing(1, 2)}, nil
+	}))
+	if err := tournament.Pair(); err == nil || len(tournament.GetRound()) != 0 {
+		t.Fatalf("Expecting an error and no pairings when players are left unpaired, got %v.", tournament.GetRound())
+	}
+
+	tournament.SetPairingStrategy(pairingStrategyFunc(func(state TournamentState) ([]Pairing, error) {
+		if len(state.Players) != 4 || state.Round != 1 || state.HavePlayed(1, 2) {
+			t.Fatalf("Expecting 4 players to pair in round 1, got %+v.", state)
+		}
+		return []Pairing{NewPairing(1, 4), NewPairing(2, 3)}, nil
+	}))
+	if err := tournament.Pair(); err != nil {
+		t.Fatalf("Pair returned an error: %s", err)
+	}
+	round := tournament.GetRound()
+	if len(round) != 2 || round[0].Winner() != 1 || round[1].IsComplete() || round[1].TableNumber() != 2 {
+		t.Fatalf("Expecting the phantom match won and tables numbered, got %+v.", round)
+	}
+	tournament.AddResult(2, 2, 0, 0)
+	tournament.NextRound()
+
+	tournament.SetPairingStrategy(nil)
+	tournament.DropPlayer(4)
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		// Sam and Alex met in the first round.
+		if pairing.playera+pairing.playerb == 5 {
+			t.Fatalf("Expecting the Swiss strategy to avoid rematches, got %+v.", pairing)
+		}
+	}
+}
+
+func TestByeRotation(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim", "Robin"} {
+		tournament.AddPlayer(name)
+	}
+	for round := 1; round <= 5; round++ {
+		tournament.Pair()
+		for _, pairing := range tournament.GetRound() {
+			if pairing.IsBye() {
+				if round > 1 {
+					standings := tournament.GetStandings()
+					for _, standing := range standings {
+						view, _ := tournament.GetPlayerView(standing.Id)
+						if len(view.ByeRounds) == 0 && standing.Id != pairing.playera && standing.Rank > tournament.standings[tournament.standingsIndex[pairing.playera]].Rank {
+							t.Fatalf("Expecting the lowest placed player without a bye to get it in round %d, got %d.", round, pairing.playera)
+						}
+					}
+				}
+				continue
+			}
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+		tournament.NextRound()
+	}
+	for _, view := range tournament.PlayerViews() {
+		if len(view.ByeRounds) != 1 {
+			t.Fatalf("Expecting everyone to get exactly one bye, got %v for %s.", view.ByeRounds, view.Name)
+		}
+	}
+}
+
+func TestExtraByes(t *testing.T) {
+	for _, algorithm := range []PairingAlgorithm{PairingBacktracking, PairingWeighted} {
+		tournament := NewTournamentWithConfig(TournamentConfig{PairingAlgorithm: algorithm, NoRematches: true})
+		for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+			tournament.AddPlayer(name)
+		}
+		for _, round := range [][]Pairing{{NewPairing(1, 2), NewPairing(3, 4)}, {NewPairing(1, 3), NewPairing(2, 4)}, {NewPairing(2, 3), NewPairing(1, 4)}} {
+			tournament.SetPairingStrategy(pairingStrategyFunc(func(TournamentState) ([]Pairing, error) { return round, nil }))
+			tournament.Pair()
+			for _, pairing := range round {
+				tournament.AddResult(pairing.playera, 2, 0, 0)
+			}
+			tournament.NextRound()
+		}
+		tournament.SetPairingStrategy(nil)
+		tournament.DropPlayer(4)
+		// Dylan, Sam and Alex all met already.
+		if err := tournament.Pair(); err == nil {
+			t.Fatalf("Expecting a RematchError with %q pairing and a single bye, got %v.", algorithm, tournament.GetRound())
+		}
+		tournament.config.MaxByes = 3
+		if err := tournament.Pair(); err != nil {
+			t.Fatalf("Pair returned an error with %q pairing: %s", algorithm, err)
+		}
+		round := tournament.GetRound()
+		if len(round) != 3 || !round[0].IsBye() || !round[1].IsBye() || !round[2].IsBye() {
+			t.Fatalf("Expecting three byes with %q pairing, got %v.", algorithm, round)
+		}
+	}
+}
@@ -1,25 +1,154 @@
 package swisstools
 
 import (
-	"errors"
+	"fmt"
 	"io"
 	"math/rand"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/olekukonko/tablewriter"
 )
 
+const byeId = -1 // Opponent id used for a bye.
+
 type Tournament struct {
 	lastId       int // Most recent player id to be assigned.
 	players      map[int]Player
 	currentRound int
 	rounds       []Round
+	config       TournamentConfig
+	lastRanks    map[int]int // Ranks after the round before the last completed round.
+	nameIndex    map[string]int
+	externalIds  map[string]int
+	voided       []VoidedRound
+	times        []roundTimes // Indexed like rounds.
+	// Standings after the last completed round, nil when they need to be recomputed.
+	standings      []PlayerStanding
+	standingsIndex map[int]int // Player id to index in standings.
+	finals         *finals     // Nil until StartFinals.
+	eligibility    []EligibilityRule
+	handlers       []func(Event)
+	// States taken before the changes that can be undone and after the ones that were undone.
+	undoLimit int
+	undo      []*undoState
+	redo      []*undoState
+	undoErr   error // Why the undo history was last dropped, see UndoError.
+	audit     []AuditEntry
+	operator  string
+	// Player ids by table number for players who always play at the same table.
+	reservations map[int]int
+	ratingSystem RatingSystem // Nil unless ratings are tracked.
+	// Nil for SwissStrategy.
+	pairingStrategy PairingStrategy
+	constraints     map[[2]int]ConstraintPolicy // Keyed by constraintKey.
+	stageStarts     []int                       // First round of every stage after the first.
+	final           []int                       // Player ids in final order, nil until finished.
+	autosaver       *autosaver                  // Nil unless EnableAutosave was called.
+	webhooks        []*webhook
+	opponents       *opponentIndex // Nil until needed, see playedIndex.
+	pairingIndex    *roundIndex    // Nil until needed, see currentIndex.
+}
+
+type roundTimes struct {
+	paired    time.Time
+	published time.Time // Zero while the pairings are a draft, see TournamentConfig.DraftPairings.
+	finished  time.Time
+}
+
+type TournamentConfig struct {
+	// Points awarded for each match result. If PointsWin is 0 the usual 3-1-0 scoring is used.
+	PointsWin  int `json:"points_win"`
+	PointsDraw int `json:"points_draw"`
+	PointsLoss int `json:"points_loss"`
+	// Tiebreakers orders players on equal points, first tiebreaker first.
+	Tiebreakers []Tiebreaker `json:"tiebreakers,omitempty"`
+	// MinimumWinPercentage is the floor applied to match and game win percentages in tiebreakers.
+	MinimumWinPercentage float64 `json:"minimum_win_percentage"`
+	// Rounds is the number of Swiss rounds, 0 if it has not been decided.
+	Rounds int `json:"rounds"`
+	// MaxBracketDistance forbids pairing players whose score brackets are more than this many
+	// brackets apart unless no other opponent is available. 0 disables the limit.
+	MaxBracketDistance int `json:"max_bracket_distance"`
+	// RoundLength is the time allowed for a round, used to estimate when the event finishes.
+	RoundLength time.Duration `json:"round_length"`
+	// Locale is the BCP 47 language tag used to sort player names, e.g. "sv" or "de". Names are
+	// sorted by the root Unicode collation order if it is empty.
+	Locale string `json:"locale,omitempty"`
+	// StandingsFinalRound pairs the final round, see Rounds, strictly in standings order within each
+	// score bracket instead of shuffling players on equal points.
+	StandingsFinalRound bool `json:"standings_final_round"`
+	// KingOfTheHillFinalRound pairs the final round strictly 1 against 2, 3 against 4 and so on across
+	// the whole standings, regardless of score brackets and rematches.
+	KingOfTheHillFinalRound bool `json:"king_of_the_hill_final_round"`
+	// NoRematches makes Pair fail with a RematchError instead of pairing players who already met.
+	NoRematches bool `json:"no_rematches"`
+	// UnfinishedGames decides how games unfinished at time count in game win percentages.
+	UnfinishedGames UnfinishedGamePolicy `json:"unfinished_games,omitempty"`
+	// Finals configures the playoff played by the top of the standings after the Swiss rounds.
+	Finals FinalsConfig `json:"finals"`
+	// ConfirmResults makes AddResult wait for both players, or a player and a judge, to report the
+	// same result before entering it, see PendingResults.
+	ConfirmResults bool `json:"confirm_results,omitempty"`
+	// RequireCheckIn makes StartTournament drop every player who did not check in, see
+	// CheckInPlayer.
+	RequireCheckIn bool `json:"require_check_in,omitempty"`
+	// LateEntry decides how players registering after the first round is paired are scored for the
+	// rounds they missed.
+	LateEntry LateEntryPolicy `json:"late_entry,omitempty"`
+	// MaxGames is the most games a reported match result may add up to, drawn games included. 0
+	// means no limit.
+	MaxGames int `json:"max_games,omitempty"`
+	// ForfeitGames is the number of games won by a player whose opponent forfeits, 2 if it is 0.
+	ForfeitGames int `json:"forfeit_games,omitempty"`
+	// PairingAlgorithm selects the built-in pairing strategy, see SetPairingStrategy.
+	PairingAlgorithm PairingAlgorithm `json:"pairing_algorithm,omitempty"`
+	// MaxByes is the most byes given in a round, 1 if it is 0. Byes beyond the one for an odd number
+	// of players are given two at a time, and only to avoid rematches, for example after mass drops.
+	MaxByes int `json:"max_byes,omitempty"`
+	// ByePolicy decides who receives byes.
+	ByePolicy ByePolicy `json:"bye_policy,omitempty"`
+	// EnforceRoundLimit makes Pair and NextRound refuse rounds beyond Rounds, or beyond
+	// RecommendedRounds for the players still in the event if Rounds is 0.
+	EnforceRoundLimit bool `json:"enforce_round_limit,omitempty"`
+	// Stages splits the Swiss rounds into stages, see AdvanceStage. Rounds defaults to the sum of the
+	// rounds of all stages.
+	Stages []Stage `json:"stages,omitempty"`
+	// Seeding pairs the first round by the players' seeds, see SetSeed, instead of at random.
+	Seeding SeedingMode `json:"seeding,omitempty"`
+	// Chess allocates white and black for every pairing, alternating colors and never giving a
+	// player the same color three times in a row or a color difference above 2.
+	Chess bool `json:"chess"`
+	// PlayDraw assigns the player on the play in the first game of every match outside of chess
+	// mode, giving it to the player who was on the play less often and deciding ties at random.
+	PlayDraw bool `json:"play_draw,omitempty"`
+	// DraftPairings makes Pair create draft pairings to be reviewed, and discarded with
+	// DiscardPairings to pair again, until they are released with PublishPairings. Results can only
+	// be entered once the pairings are published.
+	DraftPairings bool `json:"draft_pairings,omitempty"`
 }
 
 type Player struct {
-	name   string
-	points int
-	notes  []string
+	name       string
+	externalId string // Id of the player in an external system such as a membership database.
+	phantom    bool   // Placeholder who loses every match and is left out of the standings.
+	dropped    bool   // Dropped players stay in the standings but are no longer paired.
+	// Disqualified players are dropped and left out of the standings, but still count as opponents.
+	disqualified       bool
+	disqualifiedReason string
+	points             int
+	wins               int
+	losses             int
+	draws              int
+	notes              []string
+	decklist           *Decklist // Nil until the player registers a deck.
+	seed               int       // Seed for the first round, see TournamentConfig.Seeding.
+	rating             Rating    // Rating before the tournament, zero if unrated.
+	members            []string  // Names of the members of a team, nil for a single player.
+	joined             int       // First round a late player could be paired in, 0 if not late.
+	checkedIn          bool
+	meta               map[string]string // Values attached by integrations, see SetPlayerMeta.
 }
 
 type Pairing struct {
@@ -28,88 +157,862 @@ type Pairing struct {
 	playeraWins int
 	playerbWins int
 	draws       int
+	unfinished  int // Games unfinished when time was called, not included in the draws.
+	dieRoll     int // Id of the player who won the die roll and chose to play or draw.
+	white       int // Id of the player with the white pieces in chess mode.
+	play        int // Id of the player on the play in the first game, see TournamentConfig.PlayDraw.
+	table       int // Table number, 0 for byes.
+	// Intentional draws are 0-0-0 draws agreed without playing.
+	intentionalDraw bool
+	// Players who forfeited the match, both for a double forfeit.
+	forfeitA   bool
+	forfeitB   bool
+	games      []Game
+	extensions []Extension
+	// When the pairing was made, when the match was started with StartMatch and when its result was
+	// first entered. Zero if unknown.
+	created  time.Time
+	started  time.Time
+	reported time.Time
+	reports  []ResultReport // Results waiting for confirmation, see TournamentConfig.ConfirmResults.
+	locked   bool           // Results are locked once their round is completed, see OverrideResult.
+}
+
+// Extension is extra time granted to a single match by a judge.
+type Extension struct {
+	Duration time.Duration
+	Reason   string
+}
+
+type Game struct {
+	first  int // Id of the player who played first.
+	winner int // Id of the player who won the game, 0 for a drawn game.
+	notes  string
+}
+
+// UnfinishedGamePolicy decides how games unfinished at time count in game win percentages.
+type UnfinishedGamePolicy string
+
+const (
+	UnfinishedAsDraw  UnfinishedGamePolicy = ""       // Count unfinished games as drawn games.
+	UnfinishedIgnored UnfinishedGamePolicy = "ignore" // Leave unfinished games out entirely.
+)
+
+type Statistics struct {
+	UnfinishedGames  int     // Games unfinished when time was called.
+	Games            int     // Games recorded with play/draw information.
+	DecidedGames     int     // Recorded games which were not drawn.
+	OnThePlayWins    int     // Decided games won by the player who played first.
+	OnThePlayWinRate float64 // OnThePlayWins / DecidedGames, 0 when no game was decided.
 }
 
 type Round = []Pairing
 
+// VoidedRound is a round discarded with VoidRound.
+type VoidedRound struct {
+	Round    int
+	Reason   string
+	Pairings []Pairing
+}
+
+// BracketDistanceError is returned by Pair when the round could only be paired by matching players
+// further apart than TournamentConfig.MaxBracketDistance. The round is paired regardless.
+type BracketDistanceError struct {
+	Pairings []Pairing // The pairings which violate the limit.
+}
+
+func (e *BracketDistanceError) Error() string {
+	return fmt.Sprintf("%d pairings exceed the bracket distance limit", len(e.Pairings))
+}
+
+// RematchError is returned by Pair when TournamentConfig.NoRematches is set and the round cannot be
+// paired without a rematch. The round is left unpaired.
+type RematchError struct {
+	Points int // Points of the first score bracket which cannot be paired.
+}
+
+func (e *RematchError) Error() string {
+	return fmt.Sprintf("no pairing without rematches in the %d point bracket", e.Points)
+}
+
 func NewTournament() Tournament {
+	return NewTournamentWithConfig(TournamentConfig{})
+}
+
+func NewTournamentWithConfig(config TournamentConfig) Tournament {
 	rand.Seed(time.Now().Unix())
 	tournament := Tournament{}
+	if config.PointsWin == 0 {
+		config.PointsWin, config.PointsDraw, config.PointsLoss = 3, 1, 0
+	}
+	if config.Rounds == 0 {
+		for _, stage := range config.Stages {
+			config.Rounds += stage.Rounds
+		}
+	}
+	tournament.config = config
 	tournament.lastId = 0
 	tournament.players = map[int]Player{}
+	tournament.nameIndex = map[string]int{}
+	tournament.externalIds = map[string]int{}
 	tournament.currentRound = 1 // Index round starting with 1 to make the round numbers human readable.
 	tournament.rounds = make([]Round, 2)
+	tournament.times = make([]roundTimes, 2)
+	tournament.undoLimit = defaultUndoLimit
 	return tournament
 }
 
+// AddPlayer registers a player by name. It fails with an *EligibilityError if an eligibility rule
+// rejects them, see Register.
 func (t *Tournament) AddPlayer(name string) error {
-	if name == "" {
-		return errors.New("empty name")
+	return t.Register(Registration{Name: name})
+}
+
+// AddPhantom registers a phantom placeholder player to balance pod sizes. Phantoms lose every match,
+// are not paired against each other unless unavoidable and never appear in the standings.
+func (t *Tournament) AddPhantom(name string) error {
+	return t.addPlayer(Player{name: name, phantom: true}, "add_phantom")
+}
+
+// addPlayer registers a new player, whose name, external id and other registration details are
+// already set, and logs it as action.
+func (t *Tournament) addPlayer(player Player, action string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if player.name == "" {
+		return ErrEmptyName
+	}
+	if _, ok := t.nameIndex[nameKey(player.name)]; ok {
+		return ErrDuplicatePlayer
 	}
 	t.lastId++
-	player := Player{}
 	player.points = 0
-	player.name = name
 	player.notes = []string{}
+	t.joinLate(&player)
 	t.players[t.lastId] = player
+	t.nameIndex[nameKey(player.name)] = t.lastId
+	if player.externalId != "" {
+		t.externalIds[player.externalId] = t.lastId
+	}
+	t.invalidateStandings()
+	t.logChange(action, 0, t.lastId, "", player.name)
+	t.emit(Event{Type: PlayerAdded, PlayerId: t.lastId})
 	return nil
 }
 
 func (t *Tournament) FormatPlayers(w io.Writer) {
 	table := tablewriter.NewWriter(w)
 	table.SetHeader([]string{"Name", "Wins", "Losses", "Points"})
-	for _, player := range t.players {
-		table.Append([]string{player.name, "0", "0", "0"})
+	for _, id := range t.playerIdsByName() {
+		player := t.players[id]
+		table.Append([]string{player.name, strconv.Itoa(player.wins), strconv.Itoa(player.losses), strconv.Itoa(player.points)})
 	}
 	table.Render()
 }
 
-func (t *Tournament) NextRound() {
+// NextRound completes the current round and starts the next one. It fails if
+// TournamentConfig.EnforceRoundLimit forbids the round.
+func (t *Tournament) NextRound() error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if t.roundLimitReached() {
+		return ErrRoundLimitReached
+	}
+	t.recordChange(t.snapshot())
+	t.completeRound()
+	return nil
+}
+
+// completeRound does the work of NextRound. The caller records the change.
+func (t *Tournament) completeRound() {
+	t.lastRanks = map[int]int{}
+	for _, standing := range t.GetStandings() {
+		t.lastRanks[standing.Id] = standing.Rank
+	}
+	t.times[t.currentRound].finished = time.Now()
+	lock(t.rounds[t.currentRound])
 	t.currentRound++
+	t.rounds = append(t.rounds, Round{})
+	t.times = append(t.times, roundTimes{})
+	t.updatePlayerStandings()
+	completed := t.currentRound - 1
+	t.logChange("next_round", completed, 0, "", "")
+	t.emit(Event{Type: RoundCompleted, Round: completed})
+	t.emit(Event{Type: StandingsUpdated, Round: completed})
+	if t.finals == nil && t.finishedPlay() {
+		t.emit(Event{Type: TournamentFinished, Round: completed})
+	}
+}
+
+// updatePlayerStandings recomputes every player's record and points from the completed rounds. At
+// the start of a stage which resets points everyone is back on 0, while the standings still show the
+// end of the previous stage.
+func (t *Tournament) updatePlayerStandings() {
+	t.invalidateStandings()
+	reset := t.pointsFrom(t.currentRound) != t.pointsFrom(t.currentRound-1)
+	for _, standing := range t.cachedStandings() {
+		player := t.players[standing.Id]
+		player.points, player.wins, player.losses, player.draws = standing.Points, standing.Wins, standing.Losses, standing.Draws
+		if reset {
+			player.points, player.wins, player.losses, player.draws = 0, 0, 0, 0
+		}
+		t.players[standing.Id] = player
+	}
+}
+
+// opponentIndex holds the opponents of every player in the completed rounds.
+type opponentIndex struct {
+	rounds    int // Completed rounds in the index.
+	opponents map[int]map[int]bool
+}
+
+// playedIndex returns the opponents of every player in the completed rounds, adding the rounds
+// completed since the last call. The index is rebuilt if rounds were discarded, see Reset.
+func (t *Tournament) playedIndex() *opponentIndex {
+	completed := t.currentRound - 1
+	if t.opponents == nil || t.opponents.rounds > completed {
+		// Round 0 is normally empty.
+		t.opponents = &opponentIndex{opponents: map[int]map[int]bool{}}
+		t.opponents.add(t.rounds[0])
+	}
+	index := t.opponents
+	for index.rounds < completed {
+		index.rounds++
+		index.add(t.rounds[index.rounds])
+	}
+	return index
+}
+
+func (index *opponentIndex) add(round Round) {
+	for _, pairing := range round {
+		for _, ids := range [][2]int{{pairing.playera, pairing.playerb}, {pairing.playerb, pairing.playera}} {
+			if index.opponents[ids[0]] == nil {
+				index.opponents[ids[0]] = map[int]bool{}
+			}
+			index.opponents[ids[0]][ids[1]] = true
+		}
+	}
 }
 
-func (t *Tournament) Pair() {
+func (t *Tournament) havePlayedBefore(a int, b int) bool {
+	if t.playedIndex().opponents[a][b] {
+		return true
+	}
+	for _, pairing := range t.rounds[t.currentRound] {
+		if (pairing.playera == a && pairing.playerb == b) || (pairing.playera == b && pairing.playerb == a) {
+			return true
+		}
+	}
+	return false
+}
+
+// Pair pairs the current round with the pairing strategy, see SetPairingStrategy, by default the one
+// selected by TournamentConfig.PairingAlgorithm.
+func (t *Tournament) Pair() error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if t.stageComplete() {
+		return ErrStageFinished
+	}
+	if t.roundLimitReached() {
+		return ErrRoundLimitReached
+	}
+	snapshot := t.snapshot()
+	var strategy PairingStrategy = SwissStrategy{}
+	if t.config.PairingAlgorithm == PairingWeighted {
+		strategy = WeightedStrategy{}
+	}
+	if t.pairingStrategy != nil {
+		strategy = t.pairingStrategy
+	}
+	kingOfTheHill := t.config.KingOfTheHillFinalRound && t.isFinalRound()
+	if kingOfTheHill {
+		strategy = kingOfTheHillStrategy{}
+	}
+	state := t.state()
+	pairings, err := strategy.Pair(state)
+	if err != nil {
+		return err
+	}
+	if err := state.check(pairings); err != nil {
+		return err
+	}
+
+	t.recordChange(snapshot)
+	t.times[t.currentRound].paired = time.Now()
+	withinLimit := t.bracketLimit(state.ids())
+	if kingOfTheHill {
+		// King of the hill ignores score brackets.
+		withinLimit = func(int, int) bool { return true }
+	}
+	violating := []int{}
+	for _, p := range pairings {
+		pairing := Pairing{playera: p.playera, playerb: p.playerb, playeraWins: -1, playerbWins: -1, draws: -1}
+		pairing.created = t.times[t.currentRound].paired
+		switch {
+		case p.IsBye():
+			pairing.playeraWins, pairing.playerbWins, pairing.draws = 2, 0, 0
+		case t.players[p.playera].phantom && t.players[p.playerb].phantom:
+			pairing.playeraWins, pairing.playerbWins, pairing.draws = 0, 0, 0
+		case t.players[p.playera].phantom:
+			pairing.playeraWins, pairing.playerbWins, pairing.draws = 0, 2, 0
+		case t.players[p.playerb].phantom:
+			pairing.playeraWins, pairing.playerbWins, pairing.draws = 2, 0, 0
+		}
+		t.allocateSides(&pairing)
+		t.rounds[t.currentRound] = append(t.rounds[t.currentRound], pairing)
+		if !p.IsBye() && !withinLimit(p.playera, p.playerb) {
+			violating = append(violating, len(t.rounds[t.currentRound])-1)
+		}
+	}
+	t.numberTables()
+	violations := []Pairing{}
+	for _, i := range violating {
+		violations = append(violations, t.rounds[t.currentRound][i])
+	}
+	t.logChange("pair", t.currentRound, 0, "", fmt.Sprintf("%d pairings", len(t.rounds[t.currentRound])))
+	t.announcePairings()
+	if len(violations) > 0 {
+		return &BracketDistanceError{Pairings: violations}
+	}
+	return nil
+}
+
+// bracketLimit returns whether two players are within TournamentConfig.MaxBracketDistance score
+// brackets of each other. Score brackets are numbered from the top, one per distinct point total
+// among players.
+func (t *Tournament) bracketLimit(players []int) func(int, int) bool {
+	brackets := map[int]int{}
+	points := []int{}
+	for _, id := range players {
+		if _, ok := brackets[t.players[id].points]; !ok {
+			brackets[t.players[id].points] = 0
+			points = append(points, t.players[id].points)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(points)))
+	for i, p := range points {
+		brackets[p] = i
+	}
+	return func(a int, b int) bool {
+		if t.config.MaxBracketDistance <= 0 {
+			return true
+		}
+		distance := brackets[t.players[a].points] - brackets[t.players[b].points]
+		return distance <= t.config.MaxBracketDistance && -distance <= t.config.MaxBracketDistance
+	}
+}
+
+// numberTables gives every match of the current round without a table one. Matches of players with
+// a reserved table are placed there, the others take the lowest free tables in order.
+func (t *Tournament) numberTables() {
+	round := t.rounds[t.currentRound]
+	used := map[int]bool{}
+	for table := range t.reservations {
+		used[table] = true
+	}
+	for _, pairing := range round {
+		used[pairing.table] = true
+	}
+	reserved := map[int]int{}
+	for table, id := range t.reservations {
+		if other, ok := reserved[id]; !ok || table < other {
+			reserved[id] = table
+		}
+	}
+	for i, pairing := range round {
+		if pairing.table != 0 || pairing.IsBye() {
+			continue
+		}
+		a, okA := reserved[pairing.playera]
+		b, okB := reserved[pairing.playerb]
+		switch {
+		case okA && (!okB || a < b):
+			round[i].table = a
+		case okB:
+			round[i].table = b
+		}
+	}
+	table := 1
+	for i, pairing := range round {
+		if pairing.table != 0 || pairing.IsBye() {
+			continue
+		}
+		for used[table] {
+			table++
+		}
+		round[i].table = table
+		used[table] = true
+	}
+}
+
+// byeRounds returns the rounds, up to the current one, in which a player had a bye.
+func (t *Tournament) byeRounds(id int) []int {
+	rounds := []int{}
+	for r := 1; r <= t.currentRound && r < len(t.rounds); r++ {
+		for _, pairing := range t.rounds[r] {
+			if pairing.IsBye() && pairing.playera == id {
+				rounds = append(rounds, r)
+			}
+		}
+	}
+	return rounds
+}
+
+// ByePolicy decides who receives byes among the players with the fewest byes.
+type ByePolicy string
+
+const (
+	// ByeLowestPlaced gives byes to the lowest placed players in the standings.
+	ByeLowestPlaced ByePolicy = ""
+	// ByeRandom gives byes to random players.
+	ByeRandom ByePolicy = "random"
+)
+
+// extraByes returns how many byes beyond the one for an odd number of players a round of players
+// may have.
+func (t *Tournament) extraByes(players int) int {
+	extra := t.config.MaxByes - players%2
+	if extra < 0 {
+		return 0
+	}
+	return extra - extra%2
+}
+
+// byeCandidates orders players by who should get the next bye: players with the fewest byes first
+// and among them the lowest placed in the standings, or in random order with ByeRandom. In the first
+// round, without standings, the order of players is kept reversed.
+func (t *Tournament) byeCandidates(players []int) []int {
+	byes := map[int]int{}
+	for r := 1; r < t.currentRound; r++ {
+		for _, pairing := range t.rounds[r] {
+			if pairing.IsBye() {
+				byes[pairing.playera]++
+			}
+		}
+	}
+	place := map[int]int{}
+	for i, id := range players {
+		place[id] = len(players) - i
+	}
+	if t.currentRound > 1 {
+		standings := t.cachedStandings()
+		for _, id := range players {
+			// Phantoms are not in the standings and go first.
+			place[id] = -1
+			if i, ok := t.standingsIndex[id]; ok {
+				place[id] = len(standings) - i
+			}
+		}
+	}
+	if t.config.ByePolicy == ByeRandom {
+		for _, id := range players {
+			place[id] = rand.Int()
+		}
+	}
+	candidates := append([]int{}, players...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if byes[a] != byes[b] {
+			return byes[a] < byes[b]
+		}
+		return place[a] < place[b]
+	})
+	return candidates
+}
+
+func (t *Tournament) isFinalRound() bool {
+	return t.config.Rounds > 0 && t.currentRound == t.config.Rounds
+}
+
+// kingOfTheHillStrategy pairs the standings strictly 1 against 2, 3 against 4 and so on, ignoring
+// score brackets and previous meetings. The bye goes to the same player as with SwissStrategy, and
+// phantoms play the lowest placed players so that they only meet each other if there are not enough
+// players.
+type kingOfTheHillStrategy struct{}
+
+func (kingOfTheHillStrategy) Pair(state TournamentState) ([]Pairing, error) {
+	t := state.t
+	players := state.ids()
+	bye := 0
+	if len(players)%2 == 1 {
+		bye = t.byeCandidates(players)[0]
+	}
+	regular, phantoms := []int{}, []int{}
+	for _, id := range players {
+		switch {
+		case id == bye:
+		case t.players[id].phantom:
+			phantoms = append(phantoms, id)
+		default:
+			regular = append(regular, id)
+		}
+	}
+	top := len(regular) - len(phantoms)
+	if top < 0 {
+		top = 0
+	}
+	order := append([]int{}, regular[:top]...)
+	for i, id := range phantoms {
+		if top+i < len(regular) {
+			order = append(order, regular[top+i])
+		}
+		order = append(order, id)
+	}
+	pairings := []Pairing{}
+	for i := 0; i+1 < len(order); i += 2 {
+		pairings = append(pairings, NewPairing(order[i], order[i+1]))
+	}
+	if bye != 0 {
+		pairings = append(pairings, NewBye(bye))
+	}
+	return pairings, nil
+}
+
+// pairingOrder returns the players to pair ordered by points. Players on equal points are shuffled,
+// unless this is the final round and TournamentConfig.StandingsFinalRound is set, in which case they
+// are in standings order so that 1 plays 2, 3 plays 4 and so on, or this is a seeded first round, in
+// which case they are in seed order.
+func (t *Tournament) pairingOrder() []int {
+	// Players with a pairing already, such as an assigned bye, are left out.
+	paired := map[int]bool{}
+	for _, pairing := range t.rounds[t.currentRound] {
+		paired[pairing.playera], paired[pairing.playerb] = true, true
+	}
 	players := []int{}
-	for id, _ := range t.players {
-		players = append(players, id)
+	if (t.config.StandingsFinalRound || t.config.KingOfTheHillFinalRound) && t.isFinalRound() {
+		for _, standing := range t.GetStandings() {
+			if !standing.Dropped && !paired[standing.Id] {
+				players = append(players, standing.Id)
+			}
+		}
+		for id, player := range t.players {
+			if player.phantom && !paired[id] {
+				players = append(players, id)
+			}
+		}
+		return players
 	}
-	for len(players) > 0 {
-		if len(players) == 1 {
-			t.rounds[t.currentRound] = append(t.rounds[t.currentRound], Pairing{playera: players[0], playerb: -1, playeraWins: 2, playerbWins: 0, draws: 0})
-			players = players[:0]
-		} else {
-			// Choose 2 random players and delete them from the list.
-			playerIndex := rand.Intn(len(players))
-			player0 := players[playerIndex]
-			players[playerIndex] = players[len(players)-1]
-			players = players[:len(players)-1]
-			playerIndex = rand.Intn(len(players))
-			player1 := players[playerIndex]
-			players[playerIndex] = players[len(players)-1]
-			players = players[:len(players)-1]
-			t.rounds[t.currentRound] = append(t.rounds[t.currentRound], Pairing{playera: player0, playerb: player1, playeraWins: -1, playerbWins: -1, draws: -1})
+	for id, player := range t.players {
+		if !player.dropped && !paired[id] {
+			players = append(players, id)
 		}
 	}
+	rand.Shuffle(len(players), func(i, j int) { players[i], players[j] = players[j], players[i] })
+	sort.SliceStable(players, func(i, j int) bool { return t.players[players[i]].points > t.players[players[j]].points })
+	if t.seeded() {
+		sort.SliceStable(players, func(i, j int) bool { return t.players[players[i]].seed > t.players[players[j]].seed })
+	}
+	return players
 }
 
-func (t *Tournament) AddResult(id int, wins int, losses int, draws int) error {
-	for i, pairing := range t.rounds[t.currentRound] {
-		if pairing.playera == id {
-			t.rounds[t.currentRound][i].playeraWins = wins
-			t.rounds[t.currentRound][i].playerbWins = losses
-			t.rounds[t.currentRound][i].draws = draws
-			return nil
+// rematchBracket returns the highest placed player of the first score bracket, from the top, whose
+// players cannot be paired without rematches, even with the help of one player floating down.
+func (t *Tournament) rematchBracket(players []int) int {
+	allowed := func(a int, b int) bool {
+		return !t.havePlayedBefore(a, b) && (!t.players[a].phantom || !t.players[b].phantom)
+	}
+	for end := 0; end < len(players); {
+		start := end
+		for end < len(players) && t.players[players[end]].points == t.players[players[start]].points {
+			end++
+		}
+		top := players[:end]
+		if len(top)%2 == 0 {
+			if pairPlayers(top, allowed) == nil {
+				return players[start]
+			}
+			continue
+		}
+		pairable := false
+		for _, floater := range players[end:] {
+			if pairPlayers(append(append([]int{}, top...), floater), allowed) != nil {
+				pairable = true
+				break
+			}
 		}
-		if pairing.playerb == id {
-			t.rounds[t.currentRound][i].playerbWins = wins
-			t.rounds[t.currentRound][i].playeraWins = losses
-			t.rounds[t.currentRound][i].draws = draws
+		if !pairable {
+			return players[start]
+		}
+	}
+	return players[len(players)-1]
+}
+
+func allOf(constraints []func(int, int) bool) func(int, int) bool {
+	return func(a int, b int) bool {
+		for _, constraint := range constraints {
+			if !constraint(a, b) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// pairingSearchBudget bounds the number of candidate pairings tried by pairPlayers.
+const pairingSearchBudget = 100000
+
+// pairPlayers pairs each player in order with the highest placed remaining opponent for which allowed
+// holds, backtracking when the remaining players cannot be paired. It returns nil if there is no such
+// pairing or the search budget runs out.
+func pairPlayers(players []int, allowed func(int, int) bool) [][2]int {
+	budget := pairingSearchBudget
+	return searchPairings(players, allowed, &budget)
+}
+
+func searchPairings(players []int, allowed func(int, int) bool, budget *int) [][2]int {
+	if len(players) == 0 {
+		return [][2]int{}
+	}
+	player0 := players[0]
+	for i := 1; i < len(players); i++ {
+		if *budget <= 0 {
 			return nil
 		}
+		*budget--
+		if !allowed(player0, players[i]) {
+			continue
+		}
+		rest := make([]int, 0, len(players)-2)
+		rest = append(rest, players[1:i]...)
+		rest = append(rest, players[i+1:]...)
+		if matches := searchPairings(rest, allowed, budget); matches != nil {
+			return append([][2]int{{player0, players[i]}}, matches...)
+		}
+	}
+	return nil
+}
+
+// AddResult reports the current round result of player id. It fails for byes, invalid scores and
+// matches already reported, see AddResults to replace a result. With TournamentConfig.ConfirmResults
+// the result is only entered once confirmed by the opponent or a judge, see AddJudgeResult.
+func (t *Tournament) AddResult(id int, wins int, losses int, draws int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return err
+	}
+	result := Result{Player: id, Wins: wins, Losses: losses, Draws: draws}
+	if err := t.validateResult(*pairing, result); err != nil {
+		return err
+	}
+	t.recordChange(t.pairingSnapshot(t.currentRound, pairing))
+	t.enterResult(pairing, result)
+	return nil
+}
+
+// enterResult enters a validated result, or submits it for confirmation with
+// TournamentConfig.ConfirmResults unless it overrides the reports. The caller records the change.
+func (t *Tournament) enterResult(pairing *Pairing, result Result) {
+	if t.config.ConfirmResults && !result.Override {
+		t.submitResult(pairing, ResultReport{Result: result})
+		return
+	}
+	before := describePairing(*pairing)
+	pairing.setResult(result.Player, result.Wins, result.Losses, result.Draws)
+	pairing.reports = nil
+	t.logChange("result", t.currentRound, result.Player, before, describePairing(*pairing))
+	t.emit(Event{Type: ResultRecorded, Round: t.currentRound, PlayerId: result.Player, Pairing: *pairing})
+}
+
+func (t *Tournament) AddResultByName(name string, wins int, losses int, draws int) error {
+	id, err := t.GetPlayerID(name)
+	if err != nil {
+		return err
 	}
-	return errors.New("player not found")
+	return t.AddResult(id, wins, losses, draws)
+}
+
+func (t *Tournament) AddResultByExternalId(externalId string, wins int, losses int, draws int) error {
+	id, err := t.GetPlayerIDByExternalId(externalId)
+	if err != nil {
+		return err
+	}
+	return t.AddResult(id, wins, losses, draws)
 }
 
 func (t *Tournament) GetRound() []Pairing {
 	return t.rounds[t.currentRound]
 }
+
+func (t *Tournament) findPairing(id int) (*Pairing, error) {
+	round := t.rounds[t.currentRound]
+	if i, ok := t.currentIndex()[id]; ok {
+		return &round[i], nil
+	}
+	return nil, ErrPlayerNotFound
+}
+
+// SetDieRoll records which player won the die roll in their current round match.
+func (t *Tournament) SetDieRoll(id int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return err
+	}
+	pairing.dieRoll = id
+	t.logChange("die_roll", t.currentRound, id, "", describePairing(*pairing))
+	return nil
+}
+
+// AddGame records the next game of the current round match of player first, who played first, like
+// AddGameResult. winner is the id of the player who won the game or 0 if the game was drawn.
+func (t *Tournament) AddGame(first int, winner int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	pairing, err := t.findPairing(first)
+	if err != nil {
+		return err
+	}
+	return t.AddGameResult(first, len(pairing.games)+1, first, winner, "")
+}
+
+func (t *Tournament) GetStatistics() Statistics {
+	stats := Statistics{}
+	for _, round := range t.rounds {
+		for _, pairing := range round {
+			stats.UnfinishedGames += pairing.unfinished
+			for _, game := range pairing.games {
+				stats.Games++
+				if game.winner == 0 {
+					continue
+				}
+				stats.DecidedGames++
+				if game.winner == game.first {
+					stats.OnThePlayWins++
+				}
+			}
+		}
+	}
+	if stats.DecidedGames > 0 {
+		stats.OnThePlayWinRate = float64(stats.OnThePlayWins) / float64(stats.DecidedGames)
+	}
+	return stats
+}
+
+func (t *Tournament) CurrentRoundNumber() int {
+	return t.currentRound
+}
+
+// CompletedRounds returns the number of rounds finished with NextRound.
+func (t *Tournament) CompletedRounds() int {
+	return t.currentRound - 1
+}
+
+// IsRoundComplete reports whether the current round has been paired and every result reported.
+func (t *Tournament) IsRoundComplete() bool {
+	if len(t.rounds[t.currentRound]) == 0 {
+		return false
+	}
+	for _, pairing := range t.rounds[t.currentRound] {
+		if !pairing.IsComplete() {
+			return false
+		}
+	}
+	return true
+}
+
+// AssignBye awards a player a bye in the given round. In the current round Pair pairs everyone else
+// around it. In a completed round it compensates a player who was wrongly left out, and the
+// standings are updated.
+func (t *Tournament) AssignBye(round int, id int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if _, ok := t.players[id]; !ok {
+		return ErrPlayerNotFound
+	}
+	if round < 1 || round > t.currentRound {
+		return ErrRoundOutOfRange
+	}
+	if _, err := findPairingIn(t.rounds[round], id); err == nil {
+		return ErrPlayerAlreadyPaired
+	}
+	t.rounds[round] = append(t.rounds[round], Pairing{playera: id, playerb: byeId, playeraWins: 2, playerbWins: 0, draws: 0, created: time.Now(), locked: round < t.currentRound})
+	t.logChange("assign_bye", round, id, "", "bye")
+	if round < t.currentRound {
+		t.opponents = nil
+		t.updatePlayerStandings()
+		t.emit(Event{Type: StandingsUpdated, Round: t.currentRound - 1})
+	}
+	return nil
+}
+
+// VoidRound discards the pairings and results of the current round so that it can be paired again.
+func (t *Tournament) VoidRound(reason string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if len(t.rounds[t.currentRound]) == 0 {
+		return ErrRoundNotPaired
+	}
+	t.voided = append(t.voided, VoidedRound{Round: t.currentRound, Reason: reason, Pairings: t.rounds[t.currentRound]})
+	t.rounds[t.currentRound] = Round{}
+	t.times[t.currentRound] = roundTimes{}
+	t.updatePlayerStandings()
+	t.logChange("void_round", t.currentRound, 0, "", reason)
+	return nil
+}
+
+func (t *Tournament) GetVoidedRounds() []VoidedRound {
+	return t.voided
+}
+
+// Reset discards all rounds, finals and results while keeping the registered players and
+// configuration, so the same roster can play a fresh event. Dropped and disqualified players are
+// reinstated, late players count as registered from the start and everyone has to check in again. A
+// finished tournament cannot be reset.
+func (t *Tournament) Reset() error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	t.currentRound = 1
+	t.rounds = make([]Round, 2)
+	t.times = make([]roundTimes, 2)
+	t.lastRanks = nil
+	t.voided = nil
+	t.stageStarts = nil
+	t.finals = nil
+	t.final = nil
+	for id, player := range t.players {
+		player.dropped = false
+		player.disqualified = false
+		player.disqualifiedReason = ""
+		player.joined = 0
+		player.checkedIn = false
+		t.players[id] = player
+	}
+	t.updatePlayerStandings()
+	t.logChange("reset", 0, 0, "", "")
+	return nil
+}
+
+// SetUnfinishedGames records how many games of a player's current round match were unfinished when
+// time was called. Unfinished games are not draws and are reported separately from the result.
+func (t *Tournament) SetUnfinishedGames(id int, count int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if count < 0 {
+		return ErrNegativeScore
+	}
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return err
+	}
+	if pairing.IsBye() {
+		return ErrByeResult
+	}
+	before := strconv.Itoa(pairing.unfinished)
+	pairing.unfinished = count
+	t.logChange("unfinished_games", t.currentRound, id, before, strconv.Itoa(count))
+	return nil
+}
@@ -1,6 +1,8 @@
 package swisstools
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -27,3 +29,379 @@ func TestAddResultBadId(t *testing.T) {
 		t.Fatal("Bogus player submitted result but AddResult did not return an error.")
 	}
 }
+
+func TestOnThePlayWinRate(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	if err := tournament.SetDieRoll(1); err != nil {
+		t.Fatalf("SetDieRoll returned an error: %s", err)
+	}
+	tournament.AddGame(1, 1)
+	tournament.AddGame(2, 1)
+	tournament.AddGame(1, 0)
+	tournament.AddGame(1, 1)
+	stats := tournament.GetStatistics()
+	if stats.Games != 4 || stats.DecidedGames != 3 || stats.OnThePlayWins != 2 {
+		t.Fatalf("Expecting 4 games, 3 decided, 2 won on the play, got %+v.", stats)
+	}
+	if err := tournament.AddGame(1, 5); err == nil {
+		t.Fatal("Winner outside of the pairing but AddGame did not return an error.")
+	}
+}
+
+func TestPairBracketDistance(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{MaxBracketDistance: 1})
+	for i, points := range []int{9, 6, 3, 0} {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i+1))
+		player := tournament.players[i+1]
+		player.points = points
+		tournament.players[i+1] = player
+	}
+	// Players 1 and 2 already met, so both have to be paired two brackets down.
+	tournament.rounds[0] = Round{{playera: 1, playerb: 2}}
+	err := tournament.Pair()
+	var distanceErr *BracketDistanceError
+	if !errors.As(err, &distanceErr) || len(distanceErr.Pairings) != 2 {
+		t.Fatalf("Expecting two bracket distance violations, got %v.", err)
+	}
+	if len(tournament.GetRound()) != 2 {
+		t.Fatalf("Expecting the round to be paired despite the violation, got %d pairings.", len(tournament.GetRound()))
+	}
+}
+
+func TestNextRoundUpdatesPoints(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 1, 0)
+	tournament.NextRound()
+	if tournament.players[1].points != 3 || tournament.players[2].points != 0 {
+		t.Fatalf("Expecting 3 and 0 points, got %d and %d.", tournament.players[1].points, tournament.players[2].points)
+	}
+	if err := tournament.Pair(); err != nil || len(tournament.GetRound()) != 1 {
+		t.Fatalf("Expecting the second round to be paired, got %v.", err)
+	}
+}
+
+func TestChessColors(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Chess: true})
+	for i := 1; i <= 10; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	for round := 1; round <= 4; round++ {
+		tournament.Pair()
+		for _, pairing := range tournament.GetRound() {
+			if pairing.White() == 0 || pairing.Black() == 0 || pairing.White() == pairing.Black() {
+				t.Fatalf("Expecting white and black to be allocated, got %+v.", pairing)
+			}
+			tournament.AddResult(pairing.White(), 1, 0, 0)
+		}
+		tournament.NextRound()
+	}
+	for id := 1; id <= 10; id++ {
+		history := tournament.sideHistory(id)
+		difference := 0
+		for r, color := range history {
+			difference += color
+			if r >= 2 && color != 0 && color == history[r-1] && color == history[r-2] {
+				t.Fatalf("Player %d got the same color three times in a row: %v.", id, history)
+			}
+		}
+		if difference > 2 || difference < -2 {
+			t.Fatalf("Player %d has a color difference of %d.", id, difference)
+		}
+	}
+}
+
+func TestPlayDraw(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{PlayDraw: true})
+	for i := 1; i <= 8; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	balance := map[int]int{}
+	for round := 1; round <= 4; round++ {
+		tournament.Pair()
+		for _, pairing := range tournament.GetRound() {
+			first, second := pairing.First(), pairing.playera+pairing.playerb-pairing.First()
+			if pairing.Side(first) != SideFirst || pairing.Side(second) != SideSecond {
+				t.Fatalf("Expecting one player on the play and one on the draw, got %+v.", pairing)
+			}
+			if balance[first] > balance[second] {
+				t.Fatalf("Expecting the player on the draw more often to be on the play, got %d and %d.", balance[first], balance[second])
+			}
+			balance[first]++
+			balance[second]--
+			tournament.AddResult(first, 2, 0, 0)
+		}
+		tournament.NextRound()
+	}
+	sides, _ := tournament.SideHistory(1)
+	sum := 0
+	for _, side := range sides {
+		sum += int(side)
+	}
+	if len(sides) != 5 || sum != balance[1] {
+		t.Fatalf("Expecting a side for every round, got %v.", sides)
+	}
+	history, _ := tournament.GetMatchHistory(1)
+	if history[0].Side == SideNone {
+		t.Fatalf("Expecting the side in the match history, got %+v.", history[0])
+	}
+}
+
+func TestRoundProgress(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	if tournament.IsRoundComplete() {
+		t.Fatal("Unpaired round reported as complete.")
+	}
+	tournament.Pair()
+	if tournament.IsRoundComplete() {
+		t.Fatal("Round without results reported as complete.")
+	}
+	tournament.AddResult(1, 2, 0, 0)
+	if !tournament.IsRoundComplete() {
+		t.Fatal("Round with all results reported as incomplete.")
+	}
+	tournament.NextRound()
+	if tournament.CurrentRoundNumber() != 2 || tournament.CompletedRounds() != 1 {
+		t.Fatalf("Expecting round 2 with 1 completed round, got %d and %d.", tournament.CurrentRoundNumber(), tournament.CompletedRounds())
+	}
+}
+
+func TestVoidRound(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	if err := tournament.VoidRound("fire alarm"); err == nil {
+		t.Fatal("Round not paired but VoidRound did not return an error.")
+	}
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	if err := tournament.VoidRound("fire alarm"); err != nil {
+		t.Fatalf("VoidRound returned an error: %s", err)
+	}
+	if len(tournament.GetRound()) != 0 || tournament.CurrentRoundNumber() != 1 {
+		t.Fatalf("Expecting an empty round 1, got round %d with %d pairings.", tournament.CurrentRoundNumber(), len(tournament.GetRound()))
+	}
+	voided := tournament.GetVoidedRounds()
+	if len(voided) != 1 || voided[0].Reason != "fire alarm" || voided[0].Round != 1 {
+		t.Fatalf("Expecting the voided round to be recorded, got %+v.", voided)
+	}
+	tournament.Pair()
+	if len(tournament.GetRound()) != 1 {
+		t.Fatal("Voided round could not be paired again.")
+	}
+}
+
+func TestReset(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.NextRound()
+	if err := tournament.Reset(); err != nil {
+		t.Fatalf("Reset returned an error: %s", err)
+	}
+	if tournament.CurrentRoundNumber() != 1 || len(tournament.GetRound()) != 0 {
+		t.Fatalf("Expecting an empty round 1 after reset, got round %d.", tournament.CurrentRoundNumber())
+	}
+	if len(tournament.players) != 2 || tournament.players[1].points != 0 {
+		t.Fatalf("Expecting both players with 0 points after reset, got %+v.", tournament.players)
+	}
+
+	tournament = NewTournamentWithConfig(TournamentConfig{Finals: FinalsConfig{Size: 2, Format: FinalsSingleElimination}})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Robin"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.CheckInPlayer(1)
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		tournament.AddResult(pairing.playera, 2, 0, 0)
+	}
+	tournament.NextRound()
+	tournament.AddPlayer("Kim")
+	tournament.DropPlayer(2)
+	tournament.DisqualifyPlayer(3, "Cheating")
+	if err := tournament.StartFinals(); err != nil {
+		t.Fatalf("StartFinals returned an error: %s", err)
+	}
+	if err := tournament.Reset(); err != nil {
+		t.Fatalf("Reset returned an error: %s", err)
+	}
+	if _, err := tournament.GetFinalsRound(); err != ErrFinalsNotStarted {
+		t.Fatalf("Expecting the finals to be discarded, got %v.", err)
+	}
+	for id, player := range tournament.players {
+		if player.dropped || player.disqualified || player.disqualifiedReason != "" || player.joined != 0 || player.checkedIn {
+			t.Fatalf("Expecting player %d to start afresh, got %+v.", id, player)
+		}
+	}
+	if standings := tournament.GetStandings(); len(standings) != 5 {
+		t.Fatalf("Expecting all five players in the standings, got %+v.", standings)
+	}
+}
+
+func TestAddResultByName(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.SetExternalId(2, "DCI-42")
+	tournament.Pair()
+	if err := tournament.AddResultByName("Dylan", 2, 1, 0); err != nil {
+		t.Fatalf("AddResultByName returned an error: %s", err)
+	}
+	if winner := tournament.GetRound()[0].Winner(); winner != 1 {
+		t.Fatalf("Expecting Dylan to win, got %d.", winner)
+	}
+	tournament.Undo()
+	if err := tournament.AddResultByExternalId("DCI-42", 2, 0, 0); err != nil {
+		t.Fatalf("AddResultByExternalId returned an error: %s", err)
+	}
+	if winner := tournament.GetRound()[0].Winner(); winner != 2 {
+		t.Fatalf("Expecting Sam to win, got %d.", winner)
+	}
+	if err := tournament.AddResultByName("Nobody", 2, 0, 0); err == nil {
+		t.Fatal("Unknown name but AddResultByName did not return an error.")
+	}
+}
+
+func TestPairNoRematches(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{NoRematches: true})
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.NextRound()
+	err := tournament.Pair()
+	var rematchErr *RematchError
+	if !errors.As(err, &rematchErr) || rematchErr.Points != 3 {
+		t.Fatalf("Expecting a rematch error in the 3 point bracket, got %v.", err)
+	}
+	if len(tournament.GetRound()) != 0 {
+		t.Fatal("Round was paired despite the rematch error.")
+	}
+}
+
+func TestStandingsFinalRound(t *testing.T) {
+	config, _ := Preset("mtg")
+	config.Rounds = 2
+	config.StandingsFinalRound = true
+	tournament := NewTournamentWithConfig(config)
+	for i := 1; i <= 8; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	tournament.rounds[1] = Round{
+		{playera: 1, playerb: 2, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 3, playerb: 4, playeraWins: 2, playerbWins: 1, draws: 0},
+		{playera: 5, playerb: 6, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 7, playerb: 8, playeraWins: 2, playerbWins: 1, draws: 0},
+	}
+	tournament.NextRound()
+	tournament.Pair()
+	standings := tournament.GetStandings()
+	for i, pairing := range tournament.GetRound() {
+		if pairing.playera != standings[2*i].Id || pairing.playerb != standings[2*i+1].Id {
+			t.Fatalf("Expecting table %d to pair ranks %d and %d, got %+v.", i+1, 2*i+1, 2*i+2, pairing)
+		}
+	}
+}
+
+func TestKingOfTheHillFinalRound(t *testing.T) {
+	config, _ := Preset("mtg")
+	config.Rounds = 2
+	config.KingOfTheHillFinalRound = true
+	tournament := NewTournamentWithConfig(config)
+	for i := 1; i <= 3; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	tournament.rounds[1] = Round{
+		{playera: 1, playerb: 2, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 3, playerb: byeId, playeraWins: 2, playerbWins: 0, draws: 0},
+	}
+	tournament.NextRound()
+	tournament.Pair()
+	standings := tournament.GetStandings()
+	round := tournament.GetRound()
+	if len(round) != 2 || round[0].playera != standings[0].Id || round[0].playerb != standings[1].Id || round[1].playera != standings[2].Id || !round[1].IsBye() {
+		t.Fatalf("Expecting rank 1 against rank 2 and a bye for rank 3, got %+v.", round)
+	}
+}
+
+func TestKingOfTheHillPhantomsAndByes(t *testing.T) {
+	config, _ := Preset("mtg")
+	config.Rounds = 2
+	config.KingOfTheHillFinalRound = true
+	tournament := NewTournamentWithConfig(config)
+	for i := 1; i <= 4; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	for i := 1; i <= 3; i++ {
+		tournament.AddPhantom(fmt.Sprintf("Phantom %d", i))
+	}
+	tournament.rounds[1] = Round{
+		{playera: 1, playerb: 5, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 2, playerb: 6, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 3, playerb: 4, playeraWins: 2, playerbWins: 0, draws: 0},
+		{playera: 7, playerb: byeId, playeraWins: 2, playerbWins: 0, draws: 0},
+	}
+	tournament.NextRound()
+	if err := tournament.Pair(); err != nil {
+		t.Fatalf("Pair returned an error: %s", err)
+	}
+	for _, pairing := range tournament.GetRound() {
+		if pairing.IsBye() {
+			if tournament.players[pairing.playera].phantom && pairing.playera != 7 {
+				continue
+			}
+			t.Fatalf("Expecting the bye for a phantom without one, got %+v.", pairing)
+		}
+		a, b := tournament.players[pairing.playera], tournament.players[pairing.playerb]
+		if a.phantom && b.phantom {
+			t.Fatalf("Expecting phantoms not to meet each other, got %+v.", pairing)
+		}
+		if (a.phantom || b.phantom) && !pairing.IsComplete() {
+			t.Fatalf("Expecting the match against a phantom to be decided, got %+v.", pairing)
+		}
+	}
+}
+
+func TestAssignBye(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Robin", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	if err := tournament.AssignBye(1, 1); err != nil {
+		t.Fatalf("AssignBye returned an error: %s", err)
+	}
+	if err := tournament.AssignBye(1, 1); err == nil {
+		t.Fatalf("Expecting an error when assigning a second bye.")
+	}
+	tournament.Pair()
+	round := tournament.GetRound()
+	if len(round) != 3 || !round[0].IsBye() || round[0].playera != 1 {
+		t.Fatalf("Expecting Dylan's bye and two matches, got %+v.", round)
+	}
+	for _, pairing := range round[1:] {
+		if pairing.IsBye() || pairing.playera == 1 || pairing.playerb == 1 {
+			t.Fatalf("Expecting everyone else to be paired around the bye, got %+v.", round)
+		}
+		tournament.AddResult(pairing.playera, 2, 0, 0)
+	}
+	tournament.AddPlayer("Jo")
+	tournament.NextRound()
+	if err := tournament.AssignBye(1, 6); err != nil {
+		t.Fatalf("AssignBye returned an error: %s", err)
+	}
+	if standing, _ := tournament.GetStandingForPlayer(6); standing.Points != 3 {
+		t.Fatalf("Expecting a late bye to count in the standings, got %+v.", standing)
+	}
+	if err := tournament.AssignBye(3, 2); err == nil {
+		t.Fatalf("Expecting an error for a future round.")
+	}
+}
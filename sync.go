@@ -0,0 +1,78 @@
+package swisstools
+
+import "sync"
+
+// SyncTournament wraps a Tournament for concurrent use, for example by the handlers of a web server.
+// Any number of goroutines may read through View while changes through Update are exclusive.
+type SyncTournament struct {
+	mu         sync.RWMutex
+	tournament Tournament
+}
+
+func NewSyncTournament(tournament Tournament) *SyncTournament {
+	s := &SyncTournament{tournament: tournament}
+	s.tournament.cachedStandings()
+	s.tournament.currentIndex()
+	return s
+}
+
+// View calls f with the tournament for reading. f must not change the tournament or keep it after
+// returning.
+func (s *SyncTournament) View(f func(t *Tournament)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f(&s.tournament)
+}
+
+// Update calls f with the tournament for changing it and returns the error returned by f.
+func (s *SyncTournament) Update(f func(t *Tournament) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := f(&s.tournament)
+	// Compute the standings and index the pairings now so that readers never fill the caches
+	// concurrently.
+	s.tournament.cachedStandings()
+	s.tournament.currentIndex()
+	return err
+}
+
+func (s *SyncTournament) AddResult(id int, wins int, losses int, draws int) error {
+	return s.Update(func(t *Tournament) error {
+		return t.AddResult(id, wins, losses, draws)
+	})
+}
+
+func (s *SyncTournament) Pair() error {
+	return s.Update(func(t *Tournament) error {
+		return t.Pair()
+	})
+}
+
+func (s *SyncTournament) PublishPairings() error {
+	return s.Update(func(t *Tournament) error {
+		return t.PublishPairings()
+	})
+}
+
+func (s *SyncTournament) NextRound() error {
+	return s.Update(func(t *Tournament) error {
+		return t.NextRound()
+	})
+}
+
+// GetRound returns a copy of the pairings of the current round.
+func (s *SyncTournament) GetRound() []Pairing {
+	var round []Pairing
+	s.View(func(t *Tournament) {
+		round = append([]Pairing{}, t.GetRound()...)
+	})
+	return round
+}
+
+func (s *SyncTournament) GetStandings() []PlayerStanding {
+	var standings []PlayerStanding
+	s.View(func(t *Tournament) {
+		standings = t.GetStandings()
+	})
+	return standings
+}
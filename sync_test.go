@@ -0,0 +1,41 @@
+package swisstools
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncTournament(t *testing.T) {
+	tournament := NewTournament()
+	for i := 0; i < 64; i++ {
+		tournament.AddPlayer(string(rune('A'+i/8)) + string(rune('a'+i%8)))
+	}
+	s := NewSyncTournament(tournament)
+	if err := s.Pair(); err != nil {
+		t.Fatalf("Pair returned an error: %s", err)
+	}
+	var wg sync.WaitGroup
+	for _, pairing := range s.GetRound() {
+		wg.Add(2)
+		go func(id int) {
+			defer wg.Done()
+			s.AddResult(id, 2, 0, 0)
+		}(pairing.playera)
+		go func() {
+			defer wg.Done()
+			s.GetStandings()
+		}()
+	}
+	wg.Wait()
+	complete := false
+	s.View(func(t *Tournament) {
+		complete = t.IsRoundComplete()
+	})
+	if !complete {
+		t.Fatalf("Expecting every result to be recorded.")
+	}
+	s.NextRound()
+	if standings := s.GetStandings(); standings[0].Points != 3 || standings[31].Points != 3 || standings[32].Points != 0 {
+		t.Fatalf("Expecting 32 winners, got %+v.", standings)
+	}
+}
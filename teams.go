@@ -0,0 +1,64 @@
+package swisstools
+
+// AddTeam registers a team of fixed partners, such as a Two-Headed Giant pair, which plays as one
+// unit. The team is paired, reports results and appears in the standings like a single player under
+// its own name and id, so the rest of the API works on teams unchanged. A tournament holds either
+// teams or players, not both.
+func (t *Tournament) AddTeam(name string, members ...string) error {
+	if len(members) < 2 {
+		return ErrTeamTooSmall
+	}
+	taken := map[string]bool{}
+	for id, player := range t.players {
+		if !player.phantom && len(player.members) == 0 {
+			return ErrIndividualTournament
+		}
+		for _, member := range t.players[id].members {
+			taken[nameKey(member)] = true
+		}
+	}
+	for _, member := range members {
+		if member == "" {
+			return ErrEmptyName
+		}
+		if taken[nameKey(member)] {
+			return ErrAlreadyOnTeam
+		}
+		taken[nameKey(member)] = true
+	}
+	return t.addPlayer(Player{name: name, members: append([]string{}, members...)}, "add_team")
+}
+
+// hasTeams returns whether the tournament is for teams added with AddTeam.
+func (t *Tournament) hasTeams() bool {
+	for _, player := range t.players {
+		if len(player.members) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamMembers returns the names of a team's members.
+func (t *Tournament) TeamMembers(id int) ([]string, error) {
+	player, ok := t.players[id]
+	if !ok {
+		return nil, ErrPlayerNotFound
+	}
+	if len(player.members) == 0 {
+		return nil, ErrNotATeam
+	}
+	return append([]string{}, player.members...), nil
+}
+
+// GetTeamByMember returns the id of the team a player is a member of.
+func (t *Tournament) GetTeamByMember(name string) (int, error) {
+	for id, player := range t.players {
+		for _, member := range player.members {
+			if nameKey(member) == nameKey(name) {
+				return id, nil
+			}
+		}
+	}
+	return 0, ErrPlayerNotFound
+}
@@ -0,0 +1,42 @@
+package swisstools
+
+import "testing"
+
+func TestTeams(t *testing.T) {
+	tournament := NewTournament()
+	if err := tournament.AddTeam("Giants", "Dylan"); err == nil {
+		t.Fatalf("Expecting an error for a team of one.")
+	}
+	tournament.AddTeam("Giants", "Dylan", "Sam")
+	tournament.AddTeam("Titans", "Alex", "Kim")
+	if err := tournament.AddTeam("Ogres", "Robin", "sam"); err != nil {
+		t.Fatalf("Expecting names to be unique only when normalized, got %s", err)
+	}
+	if err := tournament.AddTeam("Trolls", "Jo", "Dylan"); err == nil {
+		t.Fatalf("Expecting an error for a player on two teams.")
+	}
+	if err := tournament.AddPlayer("Lee"); err == nil {
+		t.Fatalf("Expecting an error for a single player among teams.")
+	}
+	tournament.AddTeam("Trolls", "Jo", "Lee")
+
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		tournament.AddResult(pairing.playera, 2, 1, 0)
+	}
+	tournament.NextRound()
+	id, err := tournament.GetTeamByMember("Kim")
+	if err != nil || id != 2 {
+		t.Fatalf("Expecting Kim to be on team 2, got %d and %v.", id, err)
+	}
+	standing, _ := tournament.GetStandingForPlayer(id)
+	if len(standing.Members) != 2 || standing.Members[0] != "Alex" || standing.Wins+standing.Losses != 1 {
+		t.Fatalf("Expecting a team standing with one match, got %+v.", standing)
+	}
+
+	data, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(data)
+	if members, err := loaded.TeamMembers(4); err != nil || members[1] != "Lee" {
+		t.Fatalf("Expecting members to survive a dump, got %v and %v.", members, err)
+	}
+}
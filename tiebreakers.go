@@ -0,0 +1,307 @@
+package swisstools
+
+import "sort"
+
+// Tiebreaker names a tiebreaker used to order players on equal points.
+type Tiebreaker string
+
+const (
+	// OpponentMatchWinPercentage is the average match win percentage of a player's opponents, each at
+	// least TournamentConfig.MinimumWinPercentage.
+	OpponentMatchWinPercentage Tiebreaker = "omw"
+	// GameWinPercentage is the share of game points a player earned out of the games they played.
+	GameWinPercentage Tiebreaker = "gw"
+	// OpponentGameWinPercentage is the average game win percentage of a player's opponents.
+	OpponentGameWinPercentage Tiebreaker = "ogw"
+	// OpponentOpponentMatchWinPercentage is the average OpponentMatchWinPercentage of a player's opponents.
+	OpponentOpponentMatchWinPercentage Tiebreaker = "oomw"
+	// Buchholz is the sum of a player's opponents' points. Following the FIDE rules the rounds an
+	// opponent did not play count as draws for their points, and every round the player did not play,
+	// a bye or a missed round, adds a virtual opponent who held the player's score and drew onwards.
+	Buchholz Tiebreaker = "buchholz"
+	// BuchholzCut1 is Buchholz without the lowest scoring opponent.
+	BuchholzCut1 Tiebreaker = "buchholz_cut1"
+	// SonnebornBerger is the sum of the points of the opponents a player beat plus half the points of
+	// the opponents they drew. Rounds an opponent missed, for example after dropping, count as draws
+	// for the opponent's points so that beating a player who withdrew early is not worth less.
+	SonnebornBerger Tiebreaker = "sonneborn_berger"
+	// MedianBuchholz is Buchholz without the highest and the lowest scoring opponent. It is the same
+	// as Buchholz for players with fewer than three opponents, virtual ones included.
+	MedianBuchholz Tiebreaker = "median_buchholz"
+	// CumulativeScore is the sum of a player's points after each round, favoring players who won
+	// early.
+	CumulativeScore Tiebreaker = "cumulative"
+	// HeadToHead is the points a player scored against opponents on the same points as them.
+	HeadToHead Tiebreaker = "head_to_head"
+)
+
+// allTiebreakers lists every tiebreaker, in the order they are exported.
+var allTiebreakers = []Tiebreaker{
+	OpponentMatchWinPercentage,
+	GameWinPercentage,
+	OpponentGameWinPercentage,
+	OpponentOpponentMatchWinPercentage,
+	Buchholz,
+	BuchholzCut1,
+	MedianBuchholz,
+	SonnebornBerger,
+	CumulativeScore,
+	HeadToHead,
+}
+
+// Tiebreakers holds every tiebreaker value of a player. Percentages are fractions between 0 and 1.
+type Tiebreakers struct {
+	OpponentMatchWinPercentage         float64
+	GameWinPercentage                  float64
+	OpponentGameWinPercentage          float64
+	OpponentOpponentMatchWinPercentage float64
+	Buchholz                           float64
+	BuchholzCut1                       float64
+	SonnebornBerger                    float64
+	MedianBuchholz                     float64
+	CumulativeScore                    float64
+	HeadToHead                         float64
+}
+
+func (tb Tiebreakers) value(tiebreaker Tiebreaker) float64 {
+	switch tiebreaker {
+	case OpponentMatchWinPercentage:
+		return tb.OpponentMatchWinPercentage
+	case GameWinPercentage:
+		return tb.GameWinPercentage
+	case OpponentGameWinPercentage:
+		return tb.OpponentGameWinPercentage
+	case OpponentOpponentMatchWinPercentage:
+		return tb.OpponentOpponentMatchWinPercentage
+	case Buchholz:
+		return tb.Buchholz
+	case BuchholzCut1:
+		return tb.BuchholzCut1
+	case SonnebornBerger:
+		return tb.SonnebornBerger
+	case MedianBuchholz:
+		return tb.MedianBuchholz
+	case CumulativeScore:
+		return tb.CumulativeScore
+	case HeadToHead:
+		return tb.HeadToHead
+	}
+	return 0
+}
+
+// playerRecord is everything about a player's matches needed for standings and tiebreakers.
+type playerRecord struct {
+	standing   PlayerStanding
+	matches    int   // Matches played including byes.
+	byes       int   // Byes received, included in matches.
+	opponents  []int // Opponents in round order, byes excluded.
+	outcomes   []int // Outcome against each opponent: 1 for a win, 0 for a draw and -1 for a loss.
+	gamePoints int
+	games      int
+	cumulative int // Sum of the points after each round.
+	missed     int // Completed rounds without a pairing, such as rounds after dropping.
+	// Byes and wins against phantoms, and the points they earned.
+	unplayed       int
+	unplayedPoints int
+	virtual        []virtualOpponent // One for every bye, phantom win and missed round.
+}
+
+// virtualOpponent stands in for the opponent of a round a player did not play. Following the FIDE
+// rules it starts with the player's score before the round plus what the player did not score in the
+// round, and draws every later round.
+type virtualOpponent struct {
+	round int
+	score int // Score after the round.
+}
+
+// recordsAfter collects every player's record from the results of rounds 1 to round.
+func (t *Tournament) recordsAfter(round int) map[int]*playerRecord {
+	records := map[int]*playerRecord{}
+	for id, player := range t.players {
+		records[id] = &playerRecord{standing: PlayerStanding{Id: id, Name: player.name, Dropped: player.dropped, Members: player.members}}
+	}
+	current := 0
+	// forfeit is 1 if the opponent forfeited the match, -1 if the player did and 0 if it was played.
+	record := func(id int, opponent int, wins int, losses int, draws int, unfinished int, forfeit int) {
+		r := records[id]
+		outcome, earned := 0, t.config.PointsDraw
+		switch {
+		case wins > losses:
+			r.standing.Wins++
+			outcome, earned = 1, t.config.PointsWin
+		case wins < losses:
+			r.standing.Losses++
+			outcome, earned = -1, t.config.PointsLoss
+		default:
+			r.standing.Draws++
+		}
+		// Forfeits and wins against phantoms count like byes.
+		unplayed := opponent == byeId || t.players[opponent].phantom || forfeit != 0
+		if unplayed {
+			r.unplayed++
+			r.unplayedPoints += earned
+			r.virtual = append(r.virtual, virtualOpponent{round: current, score: r.standing.Points + t.config.PointsWin - earned})
+		}
+		r.standing.Points += earned
+		r.matches++
+		if opponent == byeId && forfeit == 0 {
+			r.byes++
+		}
+		if forfeit >= 0 {
+			r.gamePoints += wins*t.config.PointsWin + draws*t.config.PointsDraw + losses*t.config.PointsLoss
+			r.games += wins + losses + draws
+		}
+		if t.config.UnfinishedGames == UnfinishedAsDraw {
+			r.gamePoints += unfinished * t.config.PointsDraw
+			r.games += unfinished
+		}
+		if !unplayed {
+			r.opponents = append(r.opponents, opponent)
+			r.outcomes = append(r.outcomes, outcome)
+		}
+	}
+	for r := t.pointsFrom(round); r <= round && r < len(t.rounds); r++ {
+		current = r
+		for _, record := range records {
+			record.missed++
+		}
+		for _, pairing := range t.rounds[r] {
+			records[pairing.playera].missed--
+			if pairing.playerb != byeId {
+				records[pairing.playerb].missed--
+			}
+			if pairing.playeraWins < 0 {
+				continue
+			}
+			if pairing.forfeitA && pairing.forfeitB {
+				record(pairing.playera, pairing.playerb, 0, 1, 0, 0, -1)
+				record(pairing.playerb, pairing.playera, 0, 1, 0, 0, -1)
+				continue
+			}
+			forfeitA, forfeitB := 0, 0
+			switch {
+			case pairing.forfeitA:
+				forfeitA, forfeitB = -1, 1
+			case pairing.forfeitB:
+				forfeitA, forfeitB = 1, -1
+			}
+			record(pairing.playera, pairing.playerb, pairing.playeraWins, pairing.playerbWins, pairing.draws, pairing.unfinished, forfeitA)
+			if pairing.playerb != byeId {
+				record(pairing.playerb, pairing.playera, pairing.playerbWins, pairing.playeraWins, pairing.draws, pairing.unfinished, forfeitB)
+			}
+		}
+		for id, player := range t.players {
+			if r >= player.joined {
+				continue
+			}
+			if _, err := findPairingIn(t.rounds[r], id); err != nil {
+				t.recordMissed(records, id, r, record)
+			}
+		}
+		for _, record := range records {
+			record.cumulative += record.standing.Points
+			if len(record.virtual) < record.unplayed+record.missed {
+				record.virtual = append(record.virtual, virtualOpponent{round: r, score: record.standing.Points + t.config.PointsWin})
+			}
+		}
+	}
+	// Virtual opponents draw every round after the one they stand in for.
+	for _, record := range records {
+		for i := range record.virtual {
+			record.virtual[i].score += (current - record.virtual[i].round) * t.config.PointsDraw
+		}
+	}
+	return records
+}
+
+// adjustedPoints is a player's points with rounds they did not play, missed rounds, byes and wins
+// against phantoms, counted as draws, as used for the tiebreakers of their opponents.
+func (t *Tournament) adjustedPoints(r *playerRecord) float64 {
+	return float64(r.standing.Points - r.unplayedPoints + (r.unplayed+r.missed)*t.config.PointsDraw)
+}
+
+func (t *Tournament) matchWinPercentage(r *playerRecord) float64 {
+	if r.matches == 0 {
+		return t.config.MinimumWinPercentage
+	}
+	return t.floorPercentage(float64(r.standing.Points) / float64(r.matches*t.config.PointsWin))
+}
+
+func (t *Tournament) gameWinPercentage(r *playerRecord) float64 {
+	if r.games == 0 {
+		return t.config.MinimumWinPercentage
+	}
+	return t.floorPercentage(float64(r.gamePoints) / float64(r.games*t.config.PointsWin))
+}
+
+func (t *Tournament) floorPercentage(percentage float64) float64 {
+	if percentage < t.config.MinimumWinPercentage {
+		return t.config.MinimumWinPercentage
+	}
+	return percentage
+}
+
+func (t *Tournament) opponentMatchWinPercentage(r *playerRecord, records map[int]*playerRecord) float64 {
+	if len(r.opponents) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, opponent := range r.opponents {
+		total += t.matchWinPercentage(records[opponent])
+	}
+	return total / float64(len(r.opponents))
+}
+
+func (t *Tournament) computeTiebreakers(id int, records map[int]*playerRecord) Tiebreakers {
+	r := records[id]
+	tb := Tiebreakers{
+		OpponentMatchWinPercentage: t.opponentMatchWinPercentage(r, records),
+		GameWinPercentage:          t.gameWinPercentage(r),
+		CumulativeScore:            float64(r.cumulative),
+	}
+	scores := []float64{}
+	for _, virtual := range r.virtual {
+		scores = append(scores, float64(virtual.score))
+	}
+	for _, opponent := range r.opponents {
+		scores = append(scores, t.adjustedPoints(records[opponent]))
+	}
+	for _, score := range scores {
+		tb.Buchholz += score
+	}
+	tb.BuchholzCut1, tb.MedianBuchholz = tb.Buchholz, tb.Buchholz
+	if len(scores) >= 2 {
+		sort.Float64s(scores)
+		tb.BuchholzCut1 -= scores[0]
+	}
+	if len(scores) >= 3 {
+		tb.MedianBuchholz -= scores[0] + scores[len(scores)-1]
+	}
+	if len(r.opponents) == 0 {
+		return tb
+	}
+	for i, opponent := range r.opponents {
+		o := records[opponent]
+		tb.OpponentGameWinPercentage += t.gameWinPercentage(o)
+		tb.OpponentOpponentMatchWinPercentage += t.opponentMatchWinPercentage(o, records)
+		switch r.outcomes[i] {
+		case 1:
+			tb.SonnebornBerger += t.adjustedPoints(o)
+		case 0:
+			tb.SonnebornBerger += t.adjustedPoints(o) / 2
+		}
+		if o.standing.Points == r.standing.Points {
+			switch r.outcomes[i] {
+			case 1:
+				tb.HeadToHead += float64(t.config.PointsWin)
+			case 0:
+				tb.HeadToHead += float64(t.config.PointsDraw)
+			default:
+				tb.HeadToHead += float64(t.config.PointsLoss)
+			}
+		}
+	}
+	tb.OpponentGameWinPercentage /= float64(len(r.opponents))
+	tb.OpponentOpponentMatchWinPercentage /= float64(len(r.opponents))
+	return tb
+}
@@ -0,0 +1,106 @@
+package swisstools
+
+import "testing"
+
+func TestTiebreakerPipeline(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	result := func(a int, b int, aWins int, bWins int, draws int) Pairing {
+		return Pairing{playera: a, playerb: b, playeraWins: aWins, playerbWins: bWins, draws: draws}
+	}
+	tournament.rounds = []Round{
+		{},
+		{result(1, 2, 2, 0, 0), result(3, 4, 0, 2, 0)},
+		{result(2, 4, 2, 0, 0), result(1, 3, 2, 0, 0)},
+		{result(1, 4, 1, 1, 1), result(2, 3, 1, 1, 1)},
+		{},
+	}
+	tournament.times = make([]roundTimes, 5)
+	tournament.currentRound = 4
+	tournament.updatePlayerStandings()
+
+	standing, _ := tournament.GetStandingForPlayer(1)
+	if standing.Tiebreakers.Buchholz != 9 || standing.Tiebreakers.MedianBuchholz != 4 || standing.Tiebreakers.CumulativeScore != 16 {
+		t.Fatalf("Expecting Buchholz 9, median 4 and cumulative 16, got %+v.", standing.Tiebreakers)
+	}
+	sam, _ := tournament.GetStandingForPlayer(2)
+	kim, _ := tournament.GetStandingForPlayer(4)
+	if sam.Tiebreakers.HeadToHead != 3 || kim.Tiebreakers.HeadToHead != 0 || sam.Tiebreakers.CumulativeScore != 7 || kim.Tiebreakers.CumulativeScore != 10 {
+		t.Fatalf("Expecting head to head 3 and 0, cumulative 7 and 10, got %+v and %+v.", sam.Tiebreakers, kim.Tiebreakers)
+	}
+	if kim.Rank != 2 || sam.Rank != 3 {
+		t.Fatalf("Expecting Kim ahead by name without tiebreakers, got ranks %d and %d.", kim.Rank, sam.Rank)
+	}
+
+	tournament.config.Tiebreakers = []Tiebreaker{HeadToHead, CumulativeScore}
+	tournament.updatePlayerStandings()
+	if standings := tournament.GetStandings(); standings[1].Id != 2 || standings[2].Id != 4 {
+		t.Fatalf("Expecting head to head to put Sam ahead of Kim, got %+v.", standings)
+	}
+	tournament.config.Tiebreakers = []Tiebreaker{CumulativeScore, HeadToHead}
+	tournament.updatePlayerStandings()
+	if standings := tournament.GetStandings(); standings[1].Id != 4 || standings[2].Id != 2 {
+		t.Fatalf("Expecting cumulative score to put Kim ahead of Sam, got %+v.", standings)
+	}
+}
+
+func TestSonnebornBergerRemovedPlayer(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{PointsWin: 2, PointsDraw: 1, PointsLoss: 0})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	result := func(a int, b int, aWins int, bWins int, draws int) Pairing {
+		return Pairing{playera: a, playerb: b, playeraWins: aWins, playerbWins: bWins, draws: draws}
+	}
+	// Kim beats Alex and withdraws, Dylan beat Kim in round 1 and drew Sam in round 2.
+	tournament.rounds = []Round{
+		{},
+		{result(1, 4, 1, 0, 0), result(2, 3, 0, 0, 1)},
+		{result(1, 2, 0, 0, 1), result(3, 4, 0, 1, 0)},
+		{result(1, 3, 1, 0, 0), result(2, byeId, 2, 0, 0)},
+		{},
+	}
+	tournament.times = make([]roundTimes, 5)
+	tournament.currentRound = 4
+	tournament.DropPlayer(4)
+	tournament.updatePlayerStandings()
+	standing, _ := tournament.GetStandingForPlayer(1)
+	// Kim's 2 points plus 1 for the missed round, half of Sam's 3 with the bye as a draw and Alex's 1.
+	if standing.Tiebreakers.SonnebornBerger != 5.5 {
+		t.Fatalf("Expecting Sonneborn-Berger 5.5, got %v.", standing.Tiebreakers.SonnebornBerger)
+	}
+}
+
+func TestBuchholzUnplayedRounds(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{PointsWin: 2, PointsDraw: 1, PointsLoss: 0, Tiebreakers: []Tiebreaker{BuchholzCut1}})
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	result := func(a int, b int, aWins int, bWins int, draws int) Pairing {
+		return Pairing{playera: a, playerb: b, playeraWins: aWins, playerbWins: bWins, draws: draws}
+	}
+	tournament.rounds = []Round{
+		{},
+		{result(1, 4, 1, 0, 0), result(2, 3, 0, 0, 1)},
+		{result(1, 2, 0, 0, 1), result(3, 4, 0, 1, 0)},
+		{result(1, 3, 1, 0, 0), result(2, byeId, 2, 0, 0)},
+		{},
+	}
+	tournament.times = make([]roundTimes, 5)
+	tournament.currentRound = 4
+	tournament.DropPlayer(4)
+	tournament.updatePlayerStandings()
+	expected := map[int][2]float64{
+		1: {7, 6},  // Kim 3 with the missed round as a draw, Sam 3 with the bye as a draw, Alex 1.
+		2: {8, 7},  // Alex 1, Dylan 5 and a virtual opponent on 2 for the bye.
+		4: {10, 9}, // Dylan 5, Alex 1 and a virtual opponent on 4 for the missed round.
+	}
+	for id, values := range expected {
+		standing, _ := tournament.GetStandingForPlayer(id)
+		if standing.Tiebreakers.Buchholz != values[0] || standing.Tiebreakers.BuchholzCut1 != values[1] {
+			t.Fatalf("Expecting Buchholz %v and Cut-1 %v for player %d, got %+v.", values[0], values[1], id, standing.Tiebreakers)
+		}
+	}
+}
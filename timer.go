@@ -0,0 +1,184 @@
+package swisstools
+
+import (
+	"sort"
+	"time"
+)
+
+// AddTimeExtension grants extra time to the current round match of a player, e.g. after a deck check
+// or a long ruling.
+func (t *Tournament) AddTimeExtension(id int, duration time.Duration, reason string) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if duration <= 0 {
+		return ErrInvalidExtension
+	}
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return err
+	}
+	if pairing.IsBye() {
+		return ErrByeMatch
+	}
+	pairing.extensions = append(pairing.extensions, Extension{Duration: duration, Reason: reason})
+	t.logReason("time_extension", t.currentRound, id, "", duration.String(), reason)
+	return nil
+}
+
+// GetTimeExtensions returns the extensions granted to the current round match of a player.
+func (t *Tournament) GetTimeExtensions(id int) ([]Extension, error) {
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Extension{}, pairing.extensions...), nil
+}
+
+// RoundEnd returns when time is called for the current round, from the pairing time and
+// TournamentConfig.RoundLength. It returns the zero time if the round is not paired or no round
+// length is configured.
+func (t *Tournament) RoundEnd() time.Time {
+	paired := t.times[t.currentRound].paired
+	if paired.IsZero() || t.config.RoundLength == 0 {
+		return time.Time{}
+	}
+	return paired.Add(t.config.RoundLength)
+}
+
+// MatchEnd returns when time is called for the current round match of a player, including any
+// extensions granted to it.
+func (t *Tournament) MatchEnd(id int) (time.Time, error) {
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	end := t.RoundEnd()
+	if end.IsZero() {
+		return end, ErrTimerNotRunning
+	}
+	return end.Add(pairing.totalExtension()), nil
+}
+
+// OverdueMatches returns the current round pairings without a result whose time, including
+// extensions, ran out before now.
+func (t *Tournament) OverdueMatches(now time.Time) []Pairing {
+	overdue := []Pairing{}
+	end := t.RoundEnd()
+	if end.IsZero() {
+		return overdue
+	}
+	for _, pairing := range t.rounds[t.currentRound] {
+		if !pairing.IsComplete() && now.After(end.Add(pairing.totalExtension())) {
+			overdue = append(overdue, pairing)
+		}
+	}
+	return overdue
+}
+
+// stamp records now as the creation time of the pairings of a round that do not have one.
+func stamp(round Round, now time.Time) {
+	for i := range round {
+		if round[i].created.IsZero() {
+			round[i].created = now
+		}
+	}
+}
+
+func (p Pairing) totalExtension() time.Duration {
+	var total time.Duration
+	for _, extension := range p.extensions {
+		total += extension.Duration
+	}
+	return total
+}
+
+// StartMatch records that the current round match of a player has begun, for matches that do not
+// start when the round is paired.
+func (t *Tournament) StartMatch(id int) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	pairing, err := t.findPairing(id)
+	if err != nil {
+		return err
+	}
+	switch {
+	case pairing.IsBye():
+		return ErrByeMatch
+	case pairing.IsComplete():
+		return ErrResultReported
+	case !pairing.started.IsZero():
+		return ErrMatchStarted
+	}
+	pairing.started = time.Now()
+	t.logChange("start_match", t.currentRound, id, "", describePairing(*pairing))
+	return nil
+}
+
+// CreatedAt returns when the pairing was made, or the zero time if unknown.
+func (p Pairing) CreatedAt() time.Time {
+	return p.created
+}
+
+// StartedAt returns when the match was started with StartMatch, or the zero time if it was not.
+func (p Pairing) StartedAt() time.Time {
+	return p.started
+}
+
+// ReportedAt returns when the result was first entered, or the zero time if it was not.
+func (p Pairing) ReportedAt() time.Time {
+	return p.reported
+}
+
+// Duration returns how long the match took, from its start, or its pairing if it was not started
+// explicitly, until its result was entered. It is 0 for byes and matches without timestamps.
+func (p Pairing) Duration() time.Duration {
+	start := p.started
+	if start.IsZero() {
+		start = p.created
+	}
+	if p.IsBye() || start.IsZero() || p.reported.IsZero() {
+		return 0
+	}
+	return p.reported.Sub(start)
+}
+
+// DurationStats summarizes how long the matches of a round took.
+type DurationStats struct {
+	Matches int // Played matches with a known duration.
+	Average time.Duration
+	Median  time.Duration
+	Longest time.Duration
+}
+
+// MatchDurations returns duration statistics for the matches of a round. Byes, forfeits and
+// intentional draws are left out as they were not played.
+func (t *Tournament) MatchDurations(round int) (DurationStats, error) {
+	if round < 1 || round > t.currentRound {
+		return DurationStats{}, ErrRoundOutOfRange
+	}
+	stats := DurationStats{}
+	durations := []time.Duration{}
+	var total time.Duration
+	for _, pairing := range t.rounds[round] {
+		duration := pairing.Duration()
+		if duration <= 0 || pairing.IsForfeit() || pairing.intentionalDraw {
+			continue
+		}
+		durations = append(durations, duration)
+		total += duration
+	}
+	if len(durations) == 0 {
+		return stats, nil
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	stats.Matches = len(durations)
+	stats.Average = total / time.Duration(len(durations))
+	stats.Median = durations[len(durations)/2]
+	if len(durations)%2 == 0 {
+		stats.Median = (durations[len(durations)/2-1] + durations[len(durations)/2]) / 2
+	}
+	stats.Longest = durations[len(durations)-1]
+	return stats, nil
+}
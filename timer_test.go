@@ -0,0 +1,72 @@
+package swisstools
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTimeExtensions(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{RoundLength: 50 * time.Minute})
+	for i := 1; i <= 4; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	tournament.Pair()
+	start := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	tournament.times[1].paired = start
+	extended := tournament.GetRound()[0].playera
+	if err := tournament.AddTimeExtension(extended, 5*time.Minute, "deck check"); err != nil {
+		t.Fatalf("AddTimeExtension returned an error: %s", err)
+	}
+	if end, _ := tournament.MatchEnd(extended); !end.Equal(start.Add(55 * time.Minute)) {
+		t.Fatalf("Expecting the match to end at 10:55, got %s.", end)
+	}
+	overdue := tournament.OverdueMatches(start.Add(52 * time.Minute))
+	if len(overdue) != 1 || overdue[0].playera == extended {
+		t.Fatalf("Expecting only the match without extension to be overdue, got %+v.", overdue)
+	}
+}
+
+func TestMatchTimestamps(t *testing.T) {
+	tournament := NewTournament()
+	for i := 1; i <= 6; i++ {
+		tournament.AddPlayer(fmt.Sprintf("Player %d", i))
+	}
+	tournament.Pair()
+	round := tournament.GetRound()
+	for _, pairing := range round {
+		if pairing.CreatedAt().IsZero() || !pairing.ReportedAt().IsZero() {
+			t.Fatalf("Expecting a creation time and no report time, got %+v.", pairing)
+		}
+	}
+	if err := tournament.StartMatch(round[0].playera); err != nil {
+		t.Fatalf("StartMatch returned an error: %s", err)
+	}
+	if err := tournament.StartMatch(round[0].playerb); err == nil {
+		t.Fatalf("Expecting an error starting a match twice.")
+	}
+	start := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	for i, minutes := range []int{20, 30, 45} {
+		pairing := &tournament.rounds[1][i]
+		pairing.created, pairing.started = start, time.Time{}
+		tournament.AddResult(pairing.playera, 2, 1, 0)
+		pairing.reported = start.Add(time.Duration(minutes) * time.Minute)
+	}
+	tournament.CorrectResult(1, round[0].playera, 2, 0, 0)
+	if duration := tournament.GetRound()[0].Duration(); duration != 20*time.Minute {
+		t.Fatalf("Expecting a correction to keep the report time, got %s.", duration)
+	}
+	stats, err := tournament.MatchDurations(1)
+	if err != nil {
+		t.Fatalf("MatchDurations returned an error: %s", err)
+	}
+	if stats.Matches != 3 || stats.Median != 30*time.Minute || stats.Longest != 45*time.Minute || stats.Average != 95*time.Minute/3 {
+		t.Fatalf("Expecting 3 matches with a median of 30m and a maximum of 45m, got %+v.", stats)
+	}
+
+	data, _ := tournament.DumpTournament()
+	loaded, _ := LoadTournament(data)
+	if reported := loaded.GetRound()[2].ReportedAt(); !reported.Equal(start.Add(45 * time.Minute)) {
+		t.Fatalf("Expecting the report time to be kept in a dump, got %s.", reported)
+	}
+}
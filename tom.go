@@ -0,0 +1,183 @@
+package swisstools
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// TOMEvent describes the sanctioned event for a Pokémon Tournament Operations Manager export.
+type TOMEvent struct {
+	Name          string
+	SanctionId    string // Tournament id issued by Play! Pokémon, e.g. "24-03-000123".
+	OrganizerId   string // Play! Pokémon id of the organizer.
+	OrganizerName string
+	City          string
+	State         string
+	Country       string
+	StartDate     time.Time
+}
+
+// TOM match outcome codes.
+const (
+	tomPlayer1Wins = 1
+	tomPlayer2Wins = 2
+	tomTie         = 3
+	tomBye         = 5
+)
+
+type tomTournament struct {
+	XMLName   xml.Name         `xml:"tournament"`
+	Type      int              `xml:"type,attr"`
+	Stage     int              `xml:"stage,attr"`
+	Version   string           `xml:"version,attr"`
+	GameType  string           `xml:"gametype,attr"`
+	Mode      string           `xml:"mode,attr"`
+	Data      tomData          `xml:"data"`
+	Elapsed   int              `xml:"timeelapsed"`
+	Players   []tomPlayer      `xml:"players>player"`
+	Pods      []tomPod         `xml:"pods>pod"`
+	Standings []tomStandingPod `xml:"standings>pod"`
+}
+
+type tomData struct {
+	Name         string       `xml:"name"`
+	Id           string       `xml:"id"`
+	City         string       `xml:"city"`
+	State        string       `xml:"state"`
+	Country      string       `xml:"country"`
+	RoundTime    int          `xml:"roundtime"`
+	Organizer    tomOrganizer `xml:"organizer"`
+	StartDate    string       `xml:"startdate"`
+	LessSwiss    bool         `xml:"lessswiss"`
+	AutoTable    bool         `xml:"autotablenumber"`
+	OverflowFrom int          `xml:"overflowtablestart"`
+}
+
+type tomOrganizer struct {
+	PopId string `xml:"popid,attr"`
+	Name  string `xml:"name,attr"`
+}
+
+type tomPlayer struct {
+	UserId    string `xml:"userid,attr"`
+	FirstName string `xml:"firstname"`
+	LastName  string `xml:"lastname"`
+}
+
+type tomPod struct {
+	Category string     `xml:"category,attr"`
+	Stage    int        `xml:"stage,attr"`
+	Rounds   []tomRound `xml:"rounds>round"`
+}
+
+type tomRound struct {
+	Number  int        `xml:"number,attr"`
+	Type    int        `xml:"type,attr"`
+	Stage   int        `xml:"stage,attr"`
+	Matches []tomMatch `xml:"matches>match"`
+}
+
+type tomMatch struct {
+	Outcome int         `xml:"outcome,attr"`
+	Player  *tomMatchId `xml:"player,omitempty"`
+	Player1 *tomMatchId `xml:"player1,omitempty"`
+	Player2 *tomMatchId `xml:"player2,omitempty"`
+	Table   int         `xml:"tablenumber"`
+}
+
+type tomMatchId struct {
+	UserId string `xml:"userid,attr"`
+}
+
+type tomStandingPod struct {
+	Category string              `xml:"category,attr"`
+	Type     string              `xml:"type,attr"`
+	Players  []tomStandingPlayer `xml:"player"`
+}
+
+type tomStandingPlayer struct {
+	Id    string `xml:"id,attr"`
+	Place int    `xml:"place,attr"`
+}
+
+// ExportTOM writes the completed rounds and standings in the Pokémon Tournament Operations Manager
+// (.tdf) format. Every player needs their Play! Pokémon id set as external id.
+func (t *Tournament) ExportTOM(w io.Writer, event TOMEvent) error {
+	export := tomTournament{
+		Type:     2,
+		Stage:    1,
+		Version:  "1.7",
+		GameType: "TRADING_CARD_GAME",
+		Mode:     "LEAGUECHALLENGE",
+		Data: tomData{
+			Name:      event.Name,
+			Id:        event.SanctionId,
+			City:      event.City,
+			State:     event.State,
+			Country:   event.Country,
+			RoundTime: int(t.config.RoundLength / time.Minute),
+			Organizer: tomOrganizer{PopId: event.OrganizerId, Name: event.OrganizerName},
+			StartDate: event.StartDate.Format("01/02/2006"),
+			AutoTable: true,
+		},
+	}
+	for _, id := range t.playerIdsByName() {
+		player := t.players[id]
+		if player.phantom {
+			continue
+		}
+		if player.externalId == "" {
+			return fmt.Errorf("player %s has no Play! Pokémon id", player.name)
+		}
+		first, last := splitName(player.name)
+		export.Players = append(export.Players, tomPlayer{UserId: player.externalId, FirstName: first, LastName: last})
+	}
+	pod := tomPod{Category: "2", Stage: 5}
+	for r := 1; r < t.currentRound; r++ {
+		round := tomRound{Number: r, Type: 3, Stage: 5}
+		for i, pairing := range t.rounds[r] {
+			match := tomMatch{Table: i + 1}
+			phantomA := t.players[pairing.playera].phantom
+			phantomB := !pairing.IsBye() && t.players[pairing.playerb].phantom
+			// Matches against phantoms are reported as byes.
+			if phantomA && (phantomB || pairing.IsBye()) {
+				continue
+			}
+			if pairing.IsBye() || phantomB {
+				match.Outcome = tomBye
+				match.Player = &tomMatchId{UserId: t.players[pairing.playera].externalId}
+			} else if phantomA {
+				match.Outcome = tomBye
+				match.Player = &tomMatchId{UserId: t.players[pairing.playerb].externalId}
+			} else {
+				match.Player1 = &tomMatchId{UserId: t.players[pairing.playera].externalId}
+				match.Player2 = &tomMatchId{UserId: t.players[pairing.playerb].externalId}
+				switch pairing.Winner() {
+				case pairing.playera:
+					match.Outcome = tomPlayer1Wins
+				case pairing.playerb:
+					match.Outcome = tomPlayer2Wins
+				default:
+					match.Outcome = tomTie
+				}
+			}
+			round.Matches = append(round.Matches, match)
+		}
+		pod.Rounds = append(pod.Rounds, round)
+	}
+	export.Pods = []tomPod{pod}
+	standings := tomStandingPod{Category: "2", Type: "finished"}
+	for _, standing := range t.GetStandings() {
+		standings.Players = append(standings.Players, tomStandingPlayer{Id: t.players[standing.Id].externalId, Place: standing.Rank})
+	}
+	export.Standings = []tomStandingPod{standings}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(export)
+}
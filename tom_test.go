@@ -0,0 +1,41 @@
+package swisstools
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportTOM(t *testing.T) {
+	config, _ := Preset("pokemon")
+	tournament := NewTournamentWithConfig(config)
+	tournament.AddPlayer("Ash Ketchum")
+	tournament.AddPlayer("Misty Waterflower")
+	tournament.AddPlayer("Brock Harrison")
+	event := TOMEvent{Name: "League Challenge", SanctionId: "24-03-000123", StartDate: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)}
+	var buf bytes.Buffer
+	if err := tournament.ExportTOM(&buf, event); err == nil {
+		t.Fatal("Players without Play! Pokémon ids but ExportTOM did not return an error.")
+	}
+	tournament.SetExternalId(1, "1001")
+	tournament.SetExternalId(2, "1002")
+	tournament.SetExternalId(3, "1003")
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		if !pairing.IsBye() {
+			tournament.AddResult(pairing.playera, 2, 0, 0)
+		}
+	}
+	tournament.NextRound()
+	buf.Reset()
+	if err := tournament.ExportTOM(&buf, event); err != nil {
+		t.Fatalf("ExportTOM returned an error: %s", err)
+	}
+	out := buf.String()
+	for _, expected := range []string{`<id>24-03-000123</id>`, `<startdate>03/02/2024</startdate>`, `<firstname>Ash</firstname>`, `<lastname>Ketchum</lastname>`, `<match outcome="5">`, `<match outcome="1">`, `place="1"`} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("Expecting %s in the export, got:\n%s", expected, out)
+		}
+	}
+}
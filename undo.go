@@ -0,0 +1,151 @@
+package swisstools
+
+// defaultUndoLimit is how many changes a new tournament can undo.
+const defaultUndoLimit = 20
+
+// SetUndoLimit sets how many of the most recent changes can be undone. 0 disables undo.
+func (t *Tournament) SetUndoLimit(limit int) {
+	t.undoLimit = limit
+	if len(t.undo) > limit {
+		t.undo = t.undo[len(t.undo)-limit:]
+	}
+	if len(t.redo) > limit {
+		t.redo = t.redo[len(t.redo)-limit:]
+	}
+}
+
+// undoState is what Undo restores: a dump of the whole tournament or, for changes to matches of a
+// single round, copies of those pairings only.
+type undoState struct {
+	dump     []byte
+	round    int
+	pairings map[int]Pairing // Pairings by index in the round.
+}
+
+// snapshot returns the state to restore when undoing the change about to be made, or nil if undo is
+// disabled or the tournament could not be dumped, see UndoError.
+func (t *Tournament) snapshot() *undoState {
+	if t.undoLimit <= 0 {
+		return nil
+	}
+	data, err := t.DumpTournament()
+	if err != nil {
+		t.undoFailed(err)
+		return nil
+	}
+	return &undoState{dump: data}
+}
+
+// pairingSnapshot returns the state to restore when undoing a change to the given pairings of a
+// round, which is much cheaper than a snapshot of the whole tournament in large events.
+func (t *Tournament) pairingSnapshot(round int, pairings ...*Pairing) *undoState {
+	if t.undoLimit <= 0 {
+		return nil
+	}
+	changed := map[*Pairing]bool{}
+	for _, pairing := range pairings {
+		changed[pairing] = true
+	}
+	state := &undoState{round: round, pairings: map[int]Pairing{}}
+	for i := range t.rounds[round] {
+		if changed[&t.rounds[round][i]] {
+			state.pairings[i] = t.rounds[round][i].copy()
+		}
+	}
+	return state
+}
+
+// undoFailed drops the undo history after a snapshot failed, as undoing would otherwise revert more
+// than the last change.
+func (t *Tournament) undoFailed(err error) {
+	t.undoErr = err
+	t.undo, t.redo = nil, nil
+}
+
+// UndoError returns why the undo history was last dropped, or nil.
+func (t *Tournament) UndoError() error {
+	return t.undoErr
+}
+
+// recordChange makes a change undoable given the state taken before it. Redo is no longer possible
+// after a new change.
+func (t *Tournament) recordChange(state *undoState) {
+	if state == nil {
+		return
+	}
+	t.undo = append(t.undo, state)
+	if len(t.undo) > t.undoLimit {
+		t.undo = t.undo[1:]
+	}
+	t.redo = nil
+}
+
+// Undo reverts the last change, such as AddResult, DropPlayer, Pair or NextRound.
+func (t *Tournament) Undo() error {
+	if len(t.undo) == 0 {
+		return ErrNothingToUndo
+	}
+	current, err := t.apply(t.undo[len(t.undo)-1])
+	if err != nil {
+		return err
+	}
+	t.undo = t.undo[:len(t.undo)-1]
+	t.redo = append(t.redo, current)
+	t.logChange("undo", t.currentRound, 0, "", "")
+	return nil
+}
+
+// Redo applies the last change reverted by Undo again.
+func (t *Tournament) Redo() error {
+	if len(t.redo) == 0 {
+		return ErrNothingToRedo
+	}
+	current, err := t.apply(t.redo[len(t.redo)-1])
+	if err != nil {
+		return err
+	}
+	t.redo = t.redo[:len(t.redo)-1]
+	t.undo = append(t.undo, current)
+	t.logChange("redo", t.currentRound, 0, "", "")
+	return nil
+}
+
+// apply restores a state and returns the state it replaced, to go back to it.
+func (t *Tournament) apply(state *undoState) (*undoState, error) {
+	if state.dump != nil {
+		current, err := t.DumpTournament()
+		if err != nil {
+			return nil, err
+		}
+		if err := t.restore(state.dump); err != nil {
+			return nil, err
+		}
+		return &undoState{dump: current}, nil
+	}
+	current := &undoState{round: state.round, pairings: map[int]Pairing{}}
+	for i, pairing := range state.pairings {
+		current.pairings[i] = t.rounds[state.round][i]
+		t.rounds[state.round][i] = pairing.copy()
+	}
+	if state.round < t.currentRound {
+		t.updatePlayerStandings()
+	} else {
+		t.invalidateStandings()
+	}
+	return current, nil
+}
+
+// restore replaces the state of the tournament with a snapshot, keeping what is not part of a dump.
+func (t *Tournament) restore(snapshot []byte) error {
+	restored, err := LoadTournament(snapshot)
+	if err != nil {
+		return err
+	}
+	restored.eligibility, restored.handlers, restored.ratingSystem = t.eligibility, t.handlers, t.ratingSystem
+	restored.pairingStrategy = t.pairingStrategy
+	restored.undoLimit, restored.undo, restored.redo, restored.undoErr = t.undoLimit, t.undo, t.redo, t.undoErr
+	restored.audit, restored.operator = t.audit, t.operator
+	restored.autosaver, restored.webhooks = t.autosaver, t.webhooks
+	*t = restored
+	return nil
+}
@@ -0,0 +1,105 @@
+package swisstools
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUndoRedo(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.NextRound()
+	tournament.DropPlayer(2)
+
+	if err := tournament.Undo(); err != nil {
+		t.Fatalf("Undo returned an error: %s", err)
+	}
+	if view, _ := tournament.GetPlayerView(2); view.Dropped {
+		t.Fatalf("Expecting the drop to be undone.")
+	}
+	tournament.Undo()
+	if tournament.CurrentRoundNumber() != 1 || !tournament.IsRoundComplete() {
+		t.Fatalf("Expecting to be back in a complete round 1, got round %d.", tournament.CurrentRoundNumber())
+	}
+	tournament.Undo()
+	if wins, _, _ := tournament.GetRound()[0].Result(); wins != -1 {
+		t.Fatalf("Expecting the result to be undone, got %+v.", tournament.GetRound()[0])
+	}
+	if err := tournament.Redo(); err != nil {
+		t.Fatalf("Redo returned an error: %s", err)
+	}
+	if standing := tournament.GetRound()[0]; standing.Winner() != 1 {
+		t.Fatalf("Expecting the result to be redone, got %+v.", standing)
+	}
+	tournament.AddResults([]Result{{Player: 2, Wins: 0, Losses: 2, Override: true}})
+	if err := tournament.Redo(); err == nil {
+		t.Fatalf("Expecting nothing to redo after a new change.")
+	}
+	tournament.Undo()
+	tournament.Undo()
+	tournament.Undo()
+	if len(tournament.GetRound()) != 0 {
+		t.Fatalf("Expecting the pairing to be undone, got %+v.", tournament.GetRound())
+	}
+	if err := tournament.Undo(); err == nil {
+		t.Fatalf("Expecting nothing left to undo.")
+	}
+
+	tournament.SetUndoLimit(1)
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.Undo()
+	if err := tournament.Undo(); err == nil {
+		t.Fatalf("Expecting only one change to be kept.")
+	}
+}
+
+func TestUndoResults(t *testing.T) {
+	tournament := NewTournament()
+	for _, name := range []string{"Dylan", "Sam", "Alex", "Kim"} {
+		tournament.AddPlayer(name)
+	}
+	tournament.Pair()
+	round := tournament.GetRound()
+	tournament.AddResults([]Result{{Player: round[0].playera, Wins: 2}, {Player: round[1].playera, Wins: 2}})
+	if err := tournament.Undo(); err != nil {
+		t.Fatalf("Undo returned an error: %s", err)
+	}
+	for _, pairing := range tournament.GetRound() {
+		if pairing.IsComplete() {
+			t.Fatalf("Expecting the whole batch to be undone, got %+v.", pairing)
+		}
+	}
+	a := round[0].playera
+	tournament.AddGameResult(a, 1, a, a, "")
+	tournament.AddGameResult(a, 2, a, a, "")
+	tournament.AddGameResult(a, 2, a, round[0].playerb, "")
+	tournament.Undo()
+	if games := tournament.GetRound()[0].Games(); len(games) != 2 || games[1].Winner() != a {
+		t.Fatalf("Expecting the corrected game to be restored, got %+v.", games)
+	}
+	tournament.Redo()
+	if games := tournament.GetRound()[0].Games(); games[1].Winner() != round[0].playerb {
+		t.Fatalf("Expecting the correction to be redone, got %+v.", games)
+	}
+}
+
+func TestUndoSnapshotFailure(t *testing.T) {
+	tournament := NewTournament()
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	player := tournament.players[1]
+	player.rating = Rating{Value: math.NaN()}
+	tournament.players[1] = player
+	tournament.DropPlayer(2)
+	if tournament.UndoError() == nil {
+		t.Fatal("Expecting the failed snapshot to be reported.")
+	}
+	if err := tournament.Undo(); err != ErrNothingToUndo {
+		t.Fatalf("Expecting the undo history to be dropped, got %v.", err)
+	}
+}
@@ -0,0 +1,227 @@
+package swisstools
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Webhook deliveries are retried this many times, waiting twice as long before each retry. An
+// attempt that gets no response within webhookTimeout fails, so that a hanging receiver cannot hold
+// up the deliveries after it.
+const (
+	webhookAttempts   = 5
+	webhookRetryDelay = time.Second
+	webhookTimeout    = 10 * time.Second
+	webhookQueue      = 256
+)
+
+// webhookPayload is the JSON body POSTed to webhooks. Pairings and standings use the dump format of
+// DumpTournament, whose version is given in Version.
+type webhookPayload struct {
+	Version   string           `json:"version"`
+	Event     EventType        `json:"event"`
+	Time      time.Time        `json:"time"`
+	Round     int              `json:"round,omitempty"`
+	PlayerId  int              `json:"player_id,omitempty"`
+	Pairing   *exportPairing   `json:"pairing,omitempty"`   // The match of a ResultRecorded event.
+	Pairings  []exportPairing  `json:"pairings,omitempty"`  // The round of a RoundPaired event.
+	Standings []exportStanding `json:"standings,omitempty"` // The standings of a StandingsUpdated event.
+}
+
+type webhook struct {
+	url        string
+	secret     string
+	events     map[EventType]bool // Every event if empty.
+	client     *http.Client
+	retryDelay time.Duration
+	queue      chan webhookDelivery
+	// done is closed when the webhook is removed. The queue is never closed, as copies of the
+	// tournament made for Undo and simulations share the webhook and may still send to it.
+	done    chan struct{}
+	mu      sync.Mutex
+	err     error
+	stopped bool
+}
+
+type webhookDelivery struct {
+	event EventType
+	body  []byte
+}
+
+// ConfigureWebhook POSTs a JSON payload to url for each of the given events, or for every event if
+// none are given. Deliveries are made in order in the background and retried with backoff when
+// they fail, see WebhookError.
+//
+// Each request carries the event type in the X-Swisstools-Event header and, if secret is not empty,
+// the hex encoded HMAC-SHA256 of the body keyed with secret in X-Swisstools-Signature as
+// "sha256=<hmac>". Webhooks are not part of a dump.
+func (t *Tournament) ConfigureWebhook(url string, events []EventType, secret string) error {
+	if url == "" {
+		return ErrEmptyURL
+	}
+	t.RemoveWebhook(url)
+	hook := &webhook{url: url, secret: secret, events: map[EventType]bool{}, client: &http.Client{Timeout: webhookTimeout}, retryDelay: webhookRetryDelay, queue: make(chan webhookDelivery, webhookQueue), done: make(chan struct{})}
+	for _, event := range events {
+		hook.events[event] = true
+	}
+	go hook.run()
+	t.webhooks = append(t.webhooks, hook)
+	return nil
+}
+
+// RemoveWebhook stops sending events to url once queued deliveries are made.
+func (t *Tournament) RemoveWebhook(url string) {
+	webhooks := []*webhook{}
+	for _, hook := range t.webhooks {
+		if hook.url == url {
+			hook.stop()
+		} else {
+			webhooks = append(webhooks, hook)
+		}
+	}
+	t.webhooks = webhooks
+}
+
+// WebhookError returns the error of the last delivery to url that failed every attempt, nil if
+// there was none.
+func (t *Tournament) WebhookError(url string) error {
+	for _, hook := range t.webhooks {
+		if hook.url == url {
+			hook.mu.Lock()
+			defer hook.mu.Unlock()
+			return hook.err
+		}
+	}
+	return nil
+}
+
+func (t *Tournament) notifyWebhooks(event Event) {
+	if len(t.webhooks) == 0 {
+		return
+	}
+	var body []byte
+	for _, hook := range t.webhooks {
+		if len(hook.events) > 0 && !hook.events[event.Type] {
+			continue
+		}
+		if body == nil {
+			var err error
+			if body, err = json.Marshal(t.webhookPayload(event)); err != nil {
+				return
+			}
+		}
+		hook.send(webhookDelivery{event: event.Type, body: body})
+	}
+}
+
+func (t *Tournament) webhookPayload(event Event) webhookPayload {
+	payload := webhookPayload{Version: exportVersion, Event: event.Type, Time: time.Now(), Round: event.Round, PlayerId: event.PlayerId}
+	switch event.Type {
+	case ResultRecorded:
+		payload.Pairing = &exportPairings(Round{event.Pairing})[0]
+	case RoundPaired:
+		if event.Round >= 1 && event.Round < len(t.rounds) {
+			payload.Pairings = exportPairings(t.rounds[event.Round])
+		}
+	case StandingsUpdated:
+		payload.Standings = exportStandings(t.standingsAfter(event.Round))
+	}
+	return payload
+}
+
+// send queues a delivery unless the webhook was removed.
+func (hook *webhook) send(delivery webhookDelivery) {
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if hook.stopped {
+		return
+	}
+	select {
+	case hook.queue <- delivery:
+	default:
+		hook.err = fmt.Errorf("webhook queue full, %s event dropped", delivery.event)
+	}
+}
+
+// stop ends the deliveries once those already queued are made.
+func (hook *webhook) stop() {
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if !hook.stopped {
+		hook.stopped = true
+		close(hook.done)
+	}
+}
+
+func (hook *webhook) run() {
+	for {
+		select {
+		case delivery := <-hook.queue:
+			hook.attempt(delivery)
+		case <-hook.done:
+			// Nothing is queued after done is closed.
+			for {
+				select {
+				case delivery := <-hook.queue:
+					hook.attempt(delivery)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// attempt makes a delivery, retrying with backoff.
+func (hook *webhook) attempt(delivery webhookDelivery) {
+	delay := hook.retryDelay
+	var err error
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		if err = hook.deliver(delivery); err == nil {
+			break
+		}
+		if attempt < webhookAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	if err != nil {
+		hook.fail(err)
+	}
+}
+
+func (hook *webhook) deliver(delivery webhookDelivery) error {
+	req, err := http.NewRequest(http.MethodPost, hook.url, bytes.NewReader(delivery.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Swisstools-Event", string(delivery.event))
+	if hook.secret != "" {
+		mac := hmac.New(sha256.New, []byte(hook.secret))
+		mac.Write(delivery.body)
+		req.Header.Set("X-Swisstools-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	resp, err := hook.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook %s: %s", hook.url, resp.Status)
+	}
+	return nil
+}
+
+func (hook *webhook) fail(err error) {
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	hook.err = err
+}
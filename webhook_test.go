@@ -0,0 +1,115 @@
+package swisstools
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhook(t *testing.T) {
+	received := make(chan webhookPayload, 10)
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(body)
+		if r.Header.Get("X-Swisstools-Signature") != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+			t.Errorf("Invalid signature %q.", r.Header.Get("X-Swisstools-Signature"))
+		}
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		payload := webhookPayload{}
+		json.Unmarshal(body, &payload)
+		if r.Header.Get("X-Swisstools-Event") != string(payload.Event) {
+			t.Errorf("Expecting the event header to match the payload, got %q.", r.Header.Get("X-Swisstools-Event"))
+		}
+		received <- payload
+	}))
+	defer server.Close()
+
+	tournament := NewTournament()
+	tournament.ConfigureWebhook(server.URL, []EventType{RoundPaired, ResultRecorded, StandingsUpdated}, "secret")
+	tournament.webhooks[0].retryDelay = time.Millisecond
+	tournament.AddPlayer("Dylan")
+	tournament.AddPlayer("Sam")
+	tournament.Pair()
+	tournament.AddResult(1, 2, 0, 0)
+	tournament.NextRound()
+	next := func() webhookPayload {
+		select {
+		case payload := <-received:
+			return payload
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expecting a webhook delivery.")
+		}
+		return webhookPayload{}
+	}
+	if paired := next(); paired.Event != RoundPaired || paired.Version != exportVersion || len(paired.Pairings) != 1 {
+		t.Fatalf("Expecting the pairings of round 1 after a retry, got %+v.", paired)
+	}
+	if result := next(); result.Event != ResultRecorded || result.Pairing == nil || result.Pairing.PlayerAWins+result.Pairing.PlayerBWins != 2 {
+		t.Fatalf("Expecting the reported match, got %+v.", result)
+	}
+	if standings := next(); standings.Event != StandingsUpdated || len(standings.Standings) != 2 || standings.Standings[0].Points != 3 {
+		t.Fatalf("Expecting the standings after round 1, got %+v.", standings)
+	}
+	if err := tournament.WebhookError(server.URL); err != nil {
+		t.Fatalf("WebhookError returned an error: %s", err)
+	}
+	tournament.RemoveWebhook(server.URL)
+	if len(tournament.webhooks) != 0 {
+		t.Fatalf("Expecting the webhook to be removed.")
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	tournament := NewTournament()
+	tournament.ConfigureWebhook(server.URL, nil, "")
+	hook := tournament.webhooks[0]
+	if hook.client.Timeout != webhookTimeout {
+		t.Fatalf("Expecting deliveries to time out after %s, got %s.", webhookTimeout, hook.client.Timeout)
+	}
+	hook.client.Timeout = 10 * time.Millisecond
+	hook.retryDelay = time.Millisecond
+	tournament.AddPlayer("Dylan")
+	deadline := time.Now().Add(5 * time.Second)
+	for tournament.WebhookError(server.URL) == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expecting a delivery to a hanging receiver to fail.")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	tournament.RemoveWebhook(server.URL)
+}
+
+func TestRemoveWebhookFromCopy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	tournament := NewTournament()
+	tournament.ConfigureWebhook(server.URL, nil, "")
+	copied := tournament
+	tournament.RemoveWebhook(server.URL)
+	// The copy still holds the removed webhook and must not panic sending to it.
+	copied.AddPlayer("Dylan")
+	copied.RemoveWebhook(server.URL)
+}
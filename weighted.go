@@ -0,0 +1,125 @@
+package swisstools
+
+// PairingAlgorithm selects the built-in pairing strategy used when none is set with
+// SetPairingStrategy.
+type PairingAlgorithm string
+
+const (
+	// PairingBacktracking uses SwissStrategy.
+	PairingBacktracking PairingAlgorithm = ""
+	// PairingWeighted uses WeightedStrategy.
+	PairingWeighted PairingAlgorithm = "weighted"
+)
+
+// Penalties of the weighted pairing, each tier outweighing all lower ones combined for events of up
+// to about a hundred players.
+const (
+	phantomPenalty  int64 = 1 << 58 // Phantoms paired against each other.
+	rematchPenalty  int64 = 1 << 56 // Per earlier meeting, or earlier bye for a bye.
+	byePenalty      int64 = 1 << 49 // Per bye, see TournamentConfig.MaxByes.
+	avoidPenalty    int64 = 1 << 42 // Players with an AvoidPairing constraint.
+	limitPenalty    int64 = 1 << 35 // Beyond TournamentConfig.MaxBracketDistance.
+	distancePenalty int64 = 1 << 20 // Per squared score bracket distance.
+	colorPenalty    int64 = 1 << 13 // Incompatible colors in chess mode.
+	matchingBase    int64 = 1 << 60
+)
+
+// WeightedStrategy pairs the round with a maximum weight matching over all possible pairings. Unlike
+// SwissStrategy, which settles for the first acceptable pairing from the top down, it finds the round
+// with the fewest rematches and repeated byes, then the fewest extra byes, then the fewest avoided
+// pairings, see AddPairingConstraint, then the fewest pairings beyond the bracket limit, then
+// the smallest score differences and finally the fewest color conflicts. Players close in the
+// standings are preferred, and byes go to players as SwissStrategy gives them, extra ones allowed by
+// TournamentConfig.MaxByes only to avoid rematches.
+type WeightedStrategy struct{}
+
+func (WeightedStrategy) Pair(state TournamentState) ([]Pairing, error) {
+	t := state.t
+	if t.seeded() {
+		// The first round has no history to weigh.
+		return SwissStrategy{}.Pair(state)
+	}
+	players := state.ids()
+	withinLimit := t.bracketLimit(players)
+	brackets := map[int]int{}
+	for _, id := range players {
+		if _, ok := brackets[t.players[id].points]; !ok {
+			brackets[t.players[id].points] = len(brackets)
+		}
+	}
+	meetings := map[[2]int]int64{}
+	earlierByes := map[int]int64{}
+	for r := 1; r < t.currentRound; r++ {
+		for _, pairing := range t.rounds[r] {
+			if pairing.IsBye() {
+				earlierByes[pairing.playera]++
+			} else {
+				meetings[[2]int{pairing.playera, pairing.playerb}]++
+				meetings[[2]int{pairing.playerb, pairing.playera}]++
+			}
+		}
+	}
+
+	edges := []weightedEdge{}
+	for i, a := range players {
+		for j := i + 1; j < len(players); j++ {
+			b := players[j]
+			if (t.config.NoRematches && meetings[[2]int{a, b}] > 0) || t.pairingConstraint(a, b) == NeverPair {
+				continue
+			}
+			penalty := meetings[[2]int{a, b}]*rematchPenalty + int64(j-i)
+			if t.players[a].phantom && t.players[b].phantom {
+				penalty += phantomPenalty
+			}
+			if t.pairingConstraint(a, b) == AvoidPairing {
+				penalty += avoidPenalty
+			}
+			if !withinLimit(a, b) {
+				penalty += limitPenalty
+			}
+			distance := int64(brackets[t.players[a].points] - brackets[t.players[b].points])
+			penalty += distance * distance * distancePenalty
+			if !t.colorsCompatible(a, b) {
+				penalty += colorPenalty
+			}
+			edges = append(edges, weightedEdge{i: i, j: j, weight: 2 * (matchingBase - penalty)})
+		}
+	}
+	// Bye vertices follow the players. Unused ones are matched with each other.
+	byes := len(players)%2 + t.extraByes(len(players))
+	for i := len(players); i < len(players)+byes; i++ {
+		for j := i + 1; j < len(players)+byes; j++ {
+			edges = append(edges, weightedEdge{i: i, j: j, weight: 2 * matchingBase})
+		}
+	}
+	if byes > 0 {
+		index := map[int]int{}
+		for i, id := range players {
+			index[id] = i
+		}
+		for place, id := range t.byeCandidates(players) {
+			distance := int64(len(brackets) - 1 - brackets[t.players[id].points])
+			penalty := earlierByes[id]*rematchPenalty + byePenalty + distance*distance*distancePenalty + int64(place)
+			for bye := len(players); bye < len(players)+byes; bye++ {
+				edges = append(edges, weightedEdge{i: index[id], j: bye, weight: 2 * (matchingBase - penalty)})
+			}
+		}
+	}
+
+	mate := maxWeightMatching(len(players)+byes, edges, true)
+	pairings := []Pairing{}
+	for i, id := range players {
+		if mate[i] == -1 {
+			if !t.config.NoRematches {
+				return nil, ErrNoValidPairing
+			}
+			return nil, &RematchError{Points: t.players[t.rematchBracket(players)].points}
+		}
+		if mate[i] >= len(players) {
+			pairings = append([]Pairing{NewBye(id)}, pairings...)
+		} else if mate[i] > i {
+			pairings = append(pairings, NewPairing(id, players[mate[i]]))
+		}
+	}
+	return pairings, nil
+}
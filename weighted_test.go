@@ -0,0 +1,39 @@
+package swisstools
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWeightedPairing(t *testing.T) {
+	for run := 0; run < 20; run++ {
+		tournament := NewTournamentWithConfig(TournamentConfig{PairingAlgorithm: PairingWeighted, NoRematches: true})
+		for _, name := range []string{"Dylan", "Sam", "Alex", "Kim", "Robin", "Jo", "Lee"} {
+			tournament.AddPlayer(name)
+		}
+		byes := map[int]bool{}
+		for round := 1; round <= 5; round++ {
+			if err := tournament.Pair(); err != nil {
+				t.Fatalf("Pair returned an error in round %d: %s", round, err)
+			}
+			for _, pairing := range tournament.GetRound() {
+				if pairing.IsBye() {
+					if byes[pairing.playera] {
+						t.Fatalf("Expecting no repeated byes, got a second one for %d in round %d.", pairing.playera, round)
+					}
+					byes[pairing.playera] = true
+					continue
+				}
+				for r := 1; r < round; r++ {
+					for _, earlier := range tournament.rounds[r] {
+						if (earlier.playera == pairing.playera && earlier.playerb == pairing.playerb) || (earlier.playera == pairing.playerb && earlier.playerb == pairing.playera) {
+							t.Fatalf("Expecting no rematches, got %+v in round %d.", pairing, round)
+						}
+					}
+				}
+				tournament.AddResult(pairing.playera, 2, rand.Intn(2), 0)
+			}
+			tournament.NextRound()
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package swisstools
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// WEREvent describes the sanctioned event for a Wizards Event Reporter export.
+type WEREvent struct {
+	Name           string
+	SanctionNumber string // Event number issued by Wizards of the Coast.
+	Format         string // Format code, e.g. "STANDARD" or "MODERN".
+	Organizer      string // Wizards account or DCI number of the organizer.
+	StartDate      time.Time
+	// IdMetaKey is the player metadata key holding each player's Wizards account or DCI number, see
+	// SetPlayerMeta. The external id is used for players without it.
+	IdMetaKey string
+}
+
+// WER match outcome codes.
+const (
+	werPlayed = 1
+	werDraw   = 2
+	werBye    = 5
+)
+
+type werEvent struct {
+	XMLName        xml.Name      `xml:"event"`
+	Type           string        `xml:"type,attr"`
+	Title          string        `xml:"title,attr"`
+	SanctionNumber string        `xml:"sanctionnumber,attr"`
+	Format         string        `xml:"format,attr"`
+	Rounds         int           `xml:"numberofrounds,attr"`
+	StartDate      string        `xml:"startdate,attr"`
+	Coordinator    string        `xml:"coordinator,attr"`
+	Persons        []werPerson   `xml:"participation>person"`
+	Matches        []werRound    `xml:"matches>round"`
+	Standings      []werStanding `xml:"standings>standing"`
+}
+
+type werPerson struct {
+	Id    string `xml:"id,attr"`
+	First string `xml:"first,attr"`
+	Last  string `xml:"last,attr"`
+}
+
+type werRound struct {
+	Number  int        `xml:"number,attr"`
+	Matches []werMatch `xml:"match"`
+}
+
+type werMatch struct {
+	Person   string `xml:"person,attr"`
+	Opponent string `xml:"opponent,attr,omitempty"`
+	Win      int    `xml:"win,attr"`
+	Loss     int    `xml:"loss,attr"`
+	Draw     int    `xml:"draw,attr"`
+	Outcome  int    `xml:"outcome,attr"`
+	Table    int    `xml:"table,attr,omitempty"`
+}
+
+type werStanding struct {
+	Rank   int    `xml:"rank,attr"`
+	Person string `xml:"person,attr"`
+	Points int    `xml:"points,attr"`
+}
+
+// ExportWER writes the players, completed rounds and standings in the Wizards Event Reporter XML
+// format, for reconciling results with sanctioning software. Every player needs an id, see
+// WEREvent.IdMetaKey.
+func (t *Tournament) ExportWER(w io.Writer, event WEREvent) error {
+	export := werEvent{
+		Type:           "Sanctioned",
+		Title:          event.Name,
+		SanctionNumber: event.SanctionNumber,
+		Format:         event.Format,
+		Rounds:         t.config.Rounds,
+		StartDate:      event.StartDate.Format("2006-01-02"),
+		Coordinator:    event.Organizer,
+	}
+	ids := map[int]string{}
+	for _, id := range t.playerIdsByName() {
+		player := t.players[id]
+		if player.phantom {
+			continue
+		}
+		ids[id] = player.meta[event.IdMetaKey]
+		if event.IdMetaKey == "" || ids[id] == "" {
+			ids[id] = player.externalId
+		}
+		if ids[id] == "" {
+			return fmt.Errorf("player %s has no Wizards id", player.name)
+		}
+		first, last := splitName(player.name)
+		export.Persons = append(export.Persons, werPerson{Id: ids[id], First: first, Last: last})
+	}
+	for r := 1; r < t.currentRound; r++ {
+		round := werRound{Number: r}
+		for _, pairing := range t.rounds[r] {
+			phantomA := t.players[pairing.playera].phantom
+			phantomB := !pairing.IsBye() && t.players[pairing.playerb].phantom
+			// Matches against phantoms are reported as byes.
+			if phantomA && (phantomB || pairing.IsBye()) {
+				continue
+			}
+			match := werMatch{Win: pairing.playeraWins, Loss: pairing.playerbWins, Draw: pairing.draws, Outcome: werPlayed, Table: pairing.table}
+			switch {
+			case pairing.IsBye() || phantomB:
+				match = werMatch{Person: ids[pairing.playera], Win: 2, Outcome: werBye}
+			case phantomA:
+				match = werMatch{Person: ids[pairing.playerb], Win: 2, Outcome: werBye}
+			default:
+				match.Person, match.Opponent = ids[pairing.playera], ids[pairing.playerb]
+				if pairing.Winner() == 0 {
+					match.Outcome = werDraw
+				}
+			}
+			round.Matches = append(round.Matches, match)
+		}
+		export.Matches = append(export.Matches, round)
+	}
+	for _, standing := range t.GetStandings() {
+		export.Standings = append(export.Standings, werStanding{Rank: standing.Rank, Person: ids[standing.Id], Points: standing.Points})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(export)
+}
+
+// splitName splits a full name at the last space into first and last name.
+func splitName(name string) (string, string) {
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
@@ -0,0 +1,39 @@
+package swisstools
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportWER(t *testing.T) {
+	tournament := NewTournamentWithConfig(TournamentConfig{Rounds: 3})
+	tournament.AddPlayer("Jace Beleren")
+	tournament.AddPlayer("Chandra Nalaar")
+	tournament.AddPlayer("Gideon Jura")
+	event := WEREvent{Name: "FNM", SanctionNumber: "10-1234567", Format: "MODERN", StartDate: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), IdMetaKey: "dci"}
+	var buf bytes.Buffer
+	if err := tournament.ExportWER(&buf, event); err == nil {
+		t.Fatal("Players without ids but ExportWER did not return an error.")
+	}
+	tournament.SetPlayerMeta(1, "dci", "1001")
+	tournament.SetPlayerMeta(2, "dci", "1002")
+	tournament.SetExternalId(3, "1003")
+	tournament.Pair()
+	for _, pairing := range tournament.GetRound() {
+		if !pairing.IsBye() {
+			tournament.AddResult(pairing.playera, 2, 1, 0)
+		}
+	}
+	tournament.NextRound()
+	if err := tournament.ExportWER(&buf, event); err != nil {
+		t.Fatalf("ExportWER returned an error: %s", err)
+	}
+	out := buf.String()
+	for _, expected := range []string{`sanctionnumber="10-1234567"`, `<person id="1001" first="Jace" last="Beleren">`, `id="1003"`, `win="2" loss="1" draw="0" outcome="1"`, `outcome="5"`, `<round number="1">`} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("Expecting %s in the export, got:\n%s", expected, out)
+		}
+	}
+}